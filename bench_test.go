@@ -76,8 +76,8 @@ func BenchmarkBuildFromAppendedFiles(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		builder := NewBuilder(BuildOptions{
 			BaseDir:        baseDir,
-			LowercasePaths: true,
-			BackslashPaths: true,
+			LowercasePaths: Bool(true),
+			BackslashPaths: Bool(true),
 		})
 
 		for _, in := range inputs {
@@ -103,8 +103,8 @@ func BenchmarkBuildFromAppendedFilesWorkers(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				builder := NewBuilder(BuildOptions{
 					BaseDir:        baseDir,
-					LowercasePaths: true,
-					BackslashPaths: true,
+					LowercasePaths: Bool(true),
+					BackslashPaths: Bool(true),
 					Workers:        workers,
 				})
 
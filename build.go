@@ -5,70 +5,168 @@
 package texheaders
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/woozymasta/paa"
 )
 
-// WorkersAuto enables automatic worker selection for BuildOptions.Workers.
-const WorkersAuto = -1
+// Worker modes for BuildOptions.Workers.
+const (
+	// WorkersAuto enables automatic worker selection from host CPU count.
+	WorkersAuto = -1
+	// WorkersAdaptive starts like WorkersAuto and tunes worker count from
+	// measured throughput, since header scanning is mostly IO-bound.
+	WorkersAdaptive = -2
+)
+
+// SortMode controls order of built entries.
+type SortMode int
+
+const (
+	// SortLexical sorts raw input paths byte-wise (default).
+	SortLexical SortMode = iota
+	// SortNone keeps registration order.
+	SortNone
+	// SortEngine sorts by lowercase backslash-separated stored path, as official tools do.
+	SortEngine
+	// SortCustom sorts raw input paths with BuildOptions.SortCompare.
+	SortCustom
+)
 
 // BuildOptions controls builder behavior.
 type BuildOptions struct {
 	// SuffixOverrides maps normalized path to forced suffix type value.
-	SuffixOverrides map[string]uint32 `json:"suffix_overrides,omitempty" yaml:"suffix_overrides,omitempty"`
+	SuffixOverrides map[string]SuffixType `json:"suffix_overrides,omitempty" yaml:"suffix_overrides,omitempty"`
+	// SuffixRules infers suffix types of paths without override; built-in
+	// rules when nil.
+	SuffixRules *SuffixRuleset `json:"-" yaml:"-"`
 	// BaseDir is used for relative paths stored in PAAFile.
 	// If empty, absolute input paths are made relative to current working dir when possible.
 	BaseDir string `json:"base_dir,omitempty" yaml:"base_dir,omitempty"`
+	// PBOPrefix is prepended to stored paths of inputs not covered by
+	// a $PBOPREFIX$ file found by AppendDir.
+	PBOPrefix string `json:"pbo_prefix,omitempty" yaml:"pbo_prefix,omitempty"`
+	// PathRemap rewrites source path prefixes before computing stored paths.
+	// The first matching rule wins and takes precedence over BaseDir.
+	PathRemap []PathRemap `json:"path_remap,omitempty" yaml:"path_remap,omitempty"`
 	// SkipInvalid keeps building when one input fails.
 	SkipInvalid bool `json:"skip_invalid,omitempty" yaml:"skip_invalid,omitempty"`
+	// MaxIssues fails SkipInvalid build once more than this many inputs were
+	// skipped; zero means no limit. Warnings are not counted.
+	MaxIssues int `json:"max_issues,omitempty" yaml:"max_issues,omitempty"`
+	// CollectErrors keeps building after failures and returns all of them in
+	// one *BuildError instead of stopping at the first one.
+	// Ignored when SkipInvalid is set.
+	CollectErrors bool `json:"collect_errors,omitempty" yaml:"collect_errors,omitempty"`
+	// MaxErrors stops CollectErrors build after this many failures; zero means no limit.
+	MaxErrors int `json:"max_errors,omitempty" yaml:"max_errors,omitempty"`
+	// AllowSourceImages accepts .png, .tga and .dds inputs, deriving entries as
+	// the paa converter would produce them and storing paths with .paa extension.
+	AllowSourceImages bool `json:"allow_source_images,omitempty" yaml:"allow_source_images,omitempty"`
+	// ComputeMissingColors decodes the smallest mip and computes average and max
+	// colors when source paa lacks AVGCTAGG or MAXCTAGG.
+	ComputeMissingColors bool `json:"compute_missing_colors,omitempty" yaml:"compute_missing_colors,omitempty"`
+	// RecomputeFlags derives alpha flags from decoded smallest mip alpha
+	// instead of trusting source GALF tag.
+	RecomputeFlags bool `json:"recompute_flags,omitempty" yaml:"recompute_flags,omitempty"`
+	// MaxTextureSize is the largest top mip dimension accepted without warning.
+	// If zero, DefaultMaxTextureSize is used.
+	MaxTextureSize int `json:"max_texture_size,omitempty" yaml:"max_texture_size,omitempty"`
+	// Placeholder emits placeholder entries for missing source files instead
+	// of failing or skipping them; such entries are reported as warnings.
+	Placeholder *PlaceholderOptions `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	// DetectDuplicates hashes source files during Build and reports
+	// byte-identical textures stored under different paths via Duplicates.
+	DetectDuplicates bool `json:"detect_duplicates,omitempty" yaml:"detect_duplicates,omitempty"`
+	// ReportEntries collects per-entry summaries of Build for Report.
+	// WriteStream never collects them, keeping its memory flat.
+	ReportEntries bool `json:"report_entries,omitempty" yaml:"report_entries,omitempty"`
+	// DedupeInputs drops inputs whose normalized entry path was already seen.
+	DedupeInputs bool `json:"dedupe_inputs,omitempty" yaml:"dedupe_inputs,omitempty"`
 	// LowercasePaths stores entry paths in lowercase.
-	LowercasePaths bool `json:"lowercase_paths,omitempty" yaml:"lowercase_paths,omitempty"`
+	// If nil, it is enabled; use Bool(false) to keep original case.
+	LowercasePaths *bool `json:"lowercase_paths,omitempty" yaml:"lowercase_paths,omitempty"`
 	// BackslashPaths stores entry paths with backslash separators.
-	BackslashPaths bool `json:"backslash_paths,omitempty" yaml:"backslash_paths,omitempty"`
+	// If nil, it is enabled; use Bool(false) to keep forward slashes.
+	BackslashPaths *bool `json:"backslash_paths,omitempty" yaml:"backslash_paths,omitempty"`
+	// StrictPaths fails inputs whose stored path escapes the base directory
+	// (contains ".." segments) or stays absolute.
+	StrictPaths bool `json:"strict_paths,omitempty" yaml:"strict_paths,omitempty"`
+	// SymlinkPolicy controls how AppendDir treats symbolic links and junctions.
+	SymlinkPolicy SymlinkPolicy `json:"symlink_policy,omitempty" yaml:"symlink_policy,omitempty"`
+	// SortMode controls order of built entries.
+	SortMode SortMode `json:"sort_mode,omitempty" yaml:"sort_mode,omitempty"`
+	// SortCompare compares two raw input paths for SortCustom mode,
+	// returning a negative number when a sorts before b.
+	SortCompare func(a, b string) int `json:"-" yaml:"-"`
+	// Logger receives debug-level per-file and warn-level issue records.
+	// If nil, nothing is logged.
+	Logger *slog.Logger `json:"-" yaml:"-"`
+	// Retry retries transient I/O errors of every input before it fails.
+	Retry RetryOptions `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// MaxOpenFiles limits source files open or being stated at once,
+	// independently of Workers. Zero means unlimited.
+	MaxOpenFiles int `json:"max_open_files,omitempty" yaml:"max_open_files,omitempty"`
 	// Workers controls parallelism in Build.
 	//  - Workers <= 1 disables parallel build (default, no worker overhead).
 	//  - Workers == WorkersAuto selects workers automatically from host CPU count.
+	//  - Workers == WorkersAdaptive scales workers by measured throughput.
 	//  - Workers > 1 enables parallel entry build with that worker count.
 	Workers int `json:"workers,omitempty" yaml:"workers,omitempty"`
 }
 
-// BuildIssue reports one skipped input in lenient mode.
+// BuildIssue reports one skipped input in lenient mode or one warning about built entry.
 type BuildIssue struct {
 	// Path is the path of the skipped input.
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
-	// Error is the error message of the skipped input.
+	// Error is the error message of the skipped input or warning text.
 	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Err is the original error of the skipped input; nil for warnings.
+	Err error `json:"-" yaml:"-"`
+	// Kind is the issue category.
+	Kind IssueKind `json:"kind,omitempty" yaml:"kind,omitempty"`
+	// Warning marks issue of a built entry which was not skipped.
+	Warning bool `json:"warning,omitempty" yaml:"warning,omitempty"`
 }
 
 // Builder builds texheaders file from source texture files.
 type Builder struct {
-	inputs       []string     // inputs is the list of source texture paths.
-	issues       []BuildIssue // issues is the list of skipped inputs.
-	opts         BuildOptions // opts is the builder options.
-	inputsSorted bool         // inputsSorted tracks whether inputs are already sorted lexicographically.
+	inputs        []string               // inputs is the list of source texture paths.
+	infos         map[string]fs.FileInfo // infos holds caller-supplied file info by input path.
+	issues        []BuildIssue           // issues is the list of skipped inputs.
+	failures      []BuildIssue           // failures collects failed inputs in CollectErrors mode.
+	stats         BuildStats             // stats summarizes the last build.
+	lastInputs    []string               // lastInputs holds inputs of the last build.
+	hashes        contentHashes          // hashes groups sources by content in DetectDuplicates mode.
+	pool          chan struct{}          // pool limits entry builds shared with other builders, if set.
+	files         chan struct{}          // files limits open source files, nil when unlimited.
+	reportEntries []ReportEntry          // reportEntries summarizes entries of the last build.
+	prefixRoots   []prefixRoot           // prefixRoots maps directories with $PBOPREFIX$ to their prefix.
+	opts          BuildOptions           // opts is the builder options.
+	inputsSorted  bool                   // inputsSorted tracks whether inputs are already sorted lexicographically.
+	streaming     bool                   // streaming marks WriteStream build, which retains no entries.
+	mu            sync.Mutex             // mu guards inputs, infos, inputsSorted and prefixRoots.
 }
 
 // NewBuilder creates a new builder with options.
 func NewBuilder(opts BuildOptions) *Builder {
-	if !opts.LowercasePaths {
-		opts.LowercasePaths = true
-	}
-
-	if !opts.BackslashPaths {
-		opts.BackslashPaths = true
-	}
-
 	return &Builder{
 		opts:   opts,
+		files:  newFileLimit(opts.MaxOpenFiles),
 		inputs: make([]string, 0, 16),
 		// Empty or append-increasing input is already sorted.
 		inputsSorted: true,
@@ -77,7 +175,18 @@ func NewBuilder(opts BuildOptions) *Builder {
 }
 
 // Append registers one source texture path for build.
+//
+// Append, AppendWithInfo, AppendMany, AppendDir, Remove and Replace are safe for concurrent use;
+// Build must not run concurrently with them or with another Build.
 func (b *Builder) Append(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.appendLocked(path)
+}
+
+// appendLocked registers one path; caller holds mu.
+func (b *Builder) appendLocked(path string) error {
 	if strings.TrimSpace(path) == "" {
 		return ErrEmptyInputPath
 	}
@@ -90,10 +199,37 @@ func (b *Builder) Append(path string) error {
 	return nil
 }
 
+// AppendWithInfo registers one source texture path with file info already
+// known to the caller, e.g. from a prior directory walk or archive index.
+//
+// Build takes source size from info instead of calling Stat, which saves
+// one syscall per input on network file systems.
+func (b *Builder) AppendWithInfo(path string, info fs.FileInfo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.appendLocked(path); err != nil {
+		return err
+	}
+
+	if info != nil {
+		if b.infos == nil {
+			b.infos = make(map[string]fs.FileInfo)
+		}
+
+		b.infos[path] = info
+	}
+
+	return nil
+}
+
 // AppendMany registers multiple source texture paths for build.
 func (b *Builder) AppendMany(paths ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	for _, path := range paths {
-		if err := b.Append(path); err != nil {
+		if err := b.appendLocked(path); err != nil {
 			return err
 		}
 	}
@@ -101,113 +237,403 @@ func (b *Builder) AppendMany(paths ...string) error {
 	return nil
 }
 
+// Remove unregisters all appended inputs equal to path after cleaning.
+func (b *Builder) Remove(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	want := filepath.Clean(path)
+	out := b.inputs[:0]
+	for _, in := range b.inputs {
+		if filepath.Clean(in) != want {
+			out = append(out, in)
+			continue
+		}
+
+		delete(b.infos, in)
+	}
+
+	if len(out) == len(b.inputs) {
+		return fmt.Errorf("%w: %s", ErrInputNotFound, path)
+	}
+
+	clear(b.inputs[len(out):])
+	b.inputs = out
+	return nil
+}
+
+// Replace swaps all appended inputs equal to oldPath with newPath.
+func (b *Builder) Replace(oldPath, newPath string) error {
+	if strings.TrimSpace(newPath) == "" {
+		return ErrEmptyInputPath
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	want := filepath.Clean(oldPath)
+	found := false
+	for i, in := range b.inputs {
+		if filepath.Clean(in) == want {
+			delete(b.infos, in)
+			b.inputs[i] = newPath
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrInputNotFound, oldPath)
+	}
+
+	b.inputsSorted = sort.StringsAreSorted(b.inputs)
+	return nil
+}
+
 // Inputs returns a copy of currently appended paths.
 func (b *Builder) Inputs() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	out := make([]string, len(b.inputs))
 	copy(out, b.inputs)
 	return out
 }
 
-// Issues returns skipped input issues collected during Build with SkipInvalid=true.
+// Issues returns issues collected during last Build: skipped inputs with
+// SkipInvalid=true and warnings about built entries.
 func (b *Builder) Issues() []BuildIssue {
 	out := make([]BuildIssue, len(b.issues))
 	copy(out, b.issues)
 	return out
 }
 
-// Build compiles appended source files into texheaders model.
-func (b *Builder) Build() (*File, error) {
-	if !b.inputsSorted {
-		sort.Strings(b.inputs)
-		b.inputsSorted = true
-	}
+// Reset clears inputs, issues and $PBOPREFIX$ roots while keeping options.
+func (b *Builder) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
+	b.inputs = b.inputs[:0]
+	b.infos = nil
 	b.issues = b.issues[:0]
+	b.failures = nil
+	b.prefixRoots = nil
+	b.inputsSorted = true
+}
+
+// Clone returns an independent copy of builder with its options, inputs and issues.
+func (b *Builder) Clone() *Builder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := &Builder{
+		opts:         b.opts,
+		files:        newFileLimit(b.opts.MaxOpenFiles),
+		inputs:       append(make([]string, 0, len(b.inputs)), b.inputs...),
+		infos:        maps.Clone(b.infos),
+		issues:       append(make([]BuildIssue, 0, len(b.issues)), b.issues...),
+		prefixRoots:  append([]prefixRoot(nil), b.prefixRoots...),
+		inputsSorted: b.inputsSorted,
+	}
 
+	c.opts.SuffixOverrides = maps.Clone(b.opts.SuffixOverrides)
+	c.opts.PathRemap = append([]PathRemap(nil), b.opts.PathRemap...)
+	if b.opts.SuffixRules != nil {
+		c.opts.SuffixRules = b.opts.SuffixRules.Clone()
+	}
+
+	if b.opts.LowercasePaths != nil {
+		c.opts.LowercasePaths = Bool(*b.opts.LowercasePaths)
+	}
+
+	if b.opts.BackslashPaths != nil {
+		c.opts.BackslashPaths = Bool(*b.opts.BackslashPaths)
+	}
+
+	if b.opts.Placeholder != nil {
+		placeholder := *b.opts.Placeholder
+		c.opts.Placeholder = &placeholder
+	}
+
+	return c
+}
+
+// Build compiles appended source files into texheaders model.
+func (b *Builder) Build() (*File, error) {
+	return b.build(nil)
+}
+
+// build compiles appended inputs, carrying over unchanged entries from reuse.
+func (b *Builder) build(reuse map[string]*TextureEntry) (*File, error) {
+	inputs := b.snapshotInputs()
 	file := &File{
 		Magic:    FileMagic,
 		Version:  SupportedVersion,
-		Textures: make([]TextureEntry, 0, len(b.inputs)),
+		Textures: make([]TextureEntry, 0, len(inputs)),
+	}
+
+	err := b.runEntries(inputs, reuse, func(entry *TextureEntry) error {
+		file.Textures = append(file.Textures, *entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// snapshotInputs returns a copy of registered inputs in SortMode order.
+func (b *Builder) snapshotInputs() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.opts.SortMode == SortLexical {
+		if !b.inputsSorted {
+			sort.Strings(b.inputs)
+			b.inputsSorted = true
+		}
+
+		return b.buildInputs()
 	}
 
-	if len(b.inputs) == 0 {
-		return file, nil
+	inputs := b.buildInputs()
+	switch b.opts.SortMode {
+	case SortEngine:
+		keys := make(map[string]string, len(inputs))
+		for _, in := range inputs {
+			keys[in] = strings.ToLower(strings.ReplaceAll(b.normalizePath(in), "/", "\\"))
+		}
+
+		slices.SortStableFunc(inputs, func(x, y string) int {
+			return strings.Compare(keys[x], keys[y])
+		})
+	case SortCustom:
+		if b.opts.SortCompare != nil {
+			slices.SortStableFunc(inputs, b.opts.SortCompare)
+		}
 	}
 
-	workers := resolveBuildWorkers(b.opts.Workers, len(b.inputs))
+	return inputs
+}
+
+// entryResult is one built entry or its error.
+type entryResult struct {
+	err   error
+	entry TextureEntry
+}
+
+// entryJob is one input scheduled for a worker with its result slot.
+type entryJob struct {
+	out  chan entryResult
+	path string
+}
+
+// runEntries builds inputs and passes entries to emit in input order.
+//
+// Issues are reset and collected; failures of CollectErrors mode are
+// returned together as *BuildError.
+func (b *Builder) runEntries(inputs []string, reuse map[string]*TextureEntry, emit func(*TextureEntry) error) error {
+	b.issues = b.issues[:0]
+	b.failures = nil
+	b.lastInputs = inputs
+	b.reportEntries = nil
+	b.hashes.groups = nil
+	start := b.resetStats()
+	defer func() {
+		b.stats.Duration = time.Since(start)
+		b.logger().Debug("build finished",
+			"inputs", len(inputs),
+			"entries", b.stats.Entries,
+			"failed", b.stats.Failed,
+			"duration", b.stats.Duration,
+		)
+	}()
+
+	if err := b.produceEntries(inputs, reuse, emit); err != nil {
+		return err
+	}
+
+	if len(b.failures) > 0 {
+		return &BuildError{Failures: b.failures}
+	}
+
+	return nil
+}
+
+// produceEntries runs serial or parallel entry production for runEntries.
+//
+// Parallel builds keep at most twice the worker count of results in flight,
+// so memory does not grow with input count.
+func (b *Builder) produceEntries(inputs []string, reuse map[string]*TextureEntry, emit func(*TextureEntry) error) error {
+	workers := resolveBuildWorkers(b.opts.Workers, len(inputs))
 
 	// Handle serial build.
 	if workers <= 1 {
-		for _, in := range b.inputs {
-			entry, err := b.buildEntry(in)
-			if err != nil {
-				if b.opts.SkipInvalid {
-					b.issues = append(b.issues, BuildIssue{
-						Path:  in,
-						Error: err.Error(),
-					})
-					continue
-				}
-
-				return nil, fmt.Errorf("build %q: %w", in, err)
+		for _, in := range inputs {
+			entry, err := b.entryFor(in, reuse)
+			if err = b.collectEntry(in, &entry, err, emit); err != nil {
+				return err
 			}
-
-			file.Textures = append(file.Textures, entry)
 		}
 
-		return file, nil
+		return nil
 	}
-	if workers > len(b.inputs) {
-		workers = len(b.inputs)
+
+	var scaler *workerScaler
+	if b.opts.Workers == WorkersAdaptive {
+		scaler = newWorkerScaler(autoBuildWorkers(len(inputs)), workers)
 	}
 
-	// Initialize result arrays.
-	entries := make([]TextureEntry, len(b.inputs))
-	errs := make([]error, len(b.inputs))
-	jobs := make(chan int, len(b.inputs))
+	jobs := make(chan entryJob, workers)
+	queue := make(chan chan entryResult, workers*2)
+	done := make(chan struct{})
 	var wg sync.WaitGroup
-	wg.Add(workers)
 	for range workers {
-		go func() {
-			defer wg.Done()
-			for i := range jobs {
-				entry, err := b.buildEntry(b.inputs[i])
-				if err != nil {
-					errs[i] = err
-					continue
+		wg.Go(func() {
+			for job := range jobs {
+				if scaler != nil {
+					scaler.acquire()
+				}
+
+				entry, err := b.entryFor(job.path, reuse)
+				if scaler != nil {
+					scaler.release()
 				}
 
-				entries[i] = entry
+				job.out <- entryResult{entry: entry, err: err}
 			}
-		}()
+		})
 	}
 
-	// Dispatch jobs to workers.
-	for i := range b.inputs {
-		jobs <- i
+	// Dispatch jobs, queueing result slots in input order.
+	go func() {
+		defer close(jobs)
+		defer close(queue)
+
+		for _, in := range inputs {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			out := make(chan entryResult, 1)
+			select {
+			case queue <- out:
+			case <-done:
+				return
+			}
+
+			jobs <- entryJob{path: in, out: out}
+		}
+	}()
+
+	var err error
+	i := 0
+	for out := range queue {
+		res := <-out
+		if err = b.collectEntry(inputs[i], &res.entry, res.err, emit); err != nil {
+			break
+		}
+
+		if scaler != nil {
+			scaler.observe()
+		}
+
+		i++
+	}
+
+	close(done)
+	// Drain queued slots so dispatcher can exit.
+	for range queue {
 	}
-	close(jobs)
+
 	wg.Wait()
+	return err
+}
 
-	// Collect results from workers.
-	for i, in := range b.inputs {
-		if errs[i] == nil {
-			file.Textures = append(file.Textures, entries[i])
-			continue
+// collectEntry records issues of one built input and emits successful entry.
+func (b *Builder) collectEntry(in string, entry *TextureEntry, err error, emit func(*TextureEntry) error) error {
+	if err != nil && b.opts.Placeholder != nil && errors.Is(err, fs.ErrNotExist) {
+		*entry = b.placeholderEntry(in)
+		b.issues = append(b.issues, BuildIssue{
+			Path:    in,
+			Error:   "missing texture replaced by placeholder",
+			Err:     err,
+			Kind:    IssuePlaceholder,
+			Warning: true,
+		})
+		b.logger().Warn("texture placeholder", "path", in)
+		err = nil
+	}
+
+	if err != nil {
+		b.stats.recordFailure()
+		issue := newBuildIssue(in, err)
+		b.logger().Warn("texture failed", "path", in, "kind", issue.Kind, "error", err)
+
+		switch {
+		case b.opts.SkipInvalid:
+			b.issues = append(b.issues, issue)
+			if b.opts.MaxIssues > 0 && b.stats.Failed > b.opts.MaxIssues {
+				return fmt.Errorf("%w: %d skipped inputs, limit %d: build %q: %w", ErrTooManyIssues, b.stats.Failed, b.opts.MaxIssues, in, err)
+			}
+
+			return nil
+		case b.opts.CollectErrors:
+			b.failures = append(b.failures, issue)
+			if b.opts.MaxErrors > 0 && len(b.failures) >= b.opts.MaxErrors {
+				return &BuildError{Failures: b.failures, Truncated: true}
+			}
+
+			return nil
 		}
 
-		if b.opts.SkipInvalid {
-			b.issues = append(b.issues, BuildIssue{
-				Path:  in,
-				Error: errs[i].Error(),
-			})
+		return fmt.Errorf("build %q: %w", in, err)
+	}
+
+	warnings := b.entryWarnings(in, entry)
+	for _, w := range warnings {
+		b.logger().Warn("texture warning", "path", in, "kind", w.Kind, "warning", w.Error)
+	}
+
+	b.logger().Debug("texture built",
+		"path", in,
+		"entry", entry.PAAFile,
+		"pax_format", entry.PaxFormat,
+		"suffix_type", entry.PaxSuffixType,
+		"mipmaps", entry.MipMapCount,
+	)
+
+	b.issues = append(b.issues, warnings...)
+	b.stats.recordEntry(entry, len(warnings))
+	if b.opts.ReportEntries && !b.streaming {
+		b.reportEntries = append(b.reportEntries, newReportEntry(in, entry))
+	}
+
+	return emit(entry)
+}
+
+// buildInputs returns a snapshot of inputs to build, deduplicated when
+// DedupeInputs is set; caller holds mu.
+func (b *Builder) buildInputs() []string {
+	if !b.opts.DedupeInputs {
+		return slices.Clone(b.inputs)
+	}
+
+	seen := make(map[string]struct{}, len(b.inputs))
+	out := make([]string, 0, len(b.inputs))
+	for _, in := range b.inputs {
+		key := strings.ToLower(b.normalizePath(in))
+		if _, ok := seen[key]; ok {
 			continue
 		}
 
-		return nil, fmt.Errorf("build %q: %w", in, errs[i])
+		seen[key] = struct{}{}
+		out = append(out, in)
 	}
 
-	return file, nil
+	return out
 }
 
 // Write builds and writes texheaders model to stream.
@@ -242,50 +668,90 @@ func (b *Builder) WriteFile(path string) error {
 func (b *Builder) buildEntry(path string) (TextureEntry, error) {
 	var entry TextureEntry
 
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".paa":
-	case ".pac":
-		return entry, fmt.Errorf("%w: %s", ErrPACUnsupported, path)
-	default:
-		return entry, fmt.Errorf("%w: %s", ErrUnsupportedInputFormat, path)
+	ext, err := b.sourceExt(path)
+	if err != nil {
+		return entry, err
 	}
 
-	fh, err := os.Open(path)
+	fh, err := b.openSource(path)
 	if err != nil {
 		return entry, fmt.Errorf("open source: %w", err)
 	}
 
-	defer func() {
-		_ = fh.Close()
-	}()
+	defer b.closeSource(fh)
 
-	info, err := fh.Stat()
-	if err != nil {
-		return entry, fmt.Errorf("stat source: %w", err)
+	if isSourceImageExt(ext) {
+		return b.deriveEntry(fh, b.normalizePath(path), ext)
 	}
 
-	meta, err := paa.DecodeMetadataHeaders(fh)
-	if err != nil {
-		return entry, fmt.Errorf("scan paa metadata: %w", err)
+	info, ok := b.infos[path]
+	if !ok {
+		if info, err = fh.Stat(); err != nil {
+			return entry, fmt.Errorf("stat source: %w", err)
+		}
 	}
 
-	paxFormat, err := paxTypeToU8(meta.Type)
-	if err != nil {
-		return entry, err
+	return b.scanEntry(fh, info.Size(), b.normalizePath(path), ext)
+}
+
+// sourceExt returns lowercase extension of supported source path.
+func (b *Builder) sourceExt(path string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch {
+	case ext == ".paa", ext == ".pac":
+		return ext, nil
+	case b.opts.AllowSourceImages && isSourceImageExt(ext):
+		return ext, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedInputFormat, path)
+	}
+}
+
+// scanEntry builds one texture entry from source stream of known size.
+func (b *Builder) scanEntry(r io.Reader, size int64, rel, ext string) (TextureEntry, error) {
+	var (
+		entry     TextureEntry
+		meta      *paa.MetadataHeaders
+		pac       *pacHeaders
+		paxFormat uint8
+		err       error
+	)
+
+	if strings.EqualFold(ext, ".pac") {
+		if pac, err = decodePACHeaders(r); err != nil {
+			return entry, fmt.Errorf("scan pac metadata: %w", err)
+		}
+
+		meta, paxFormat = &pac.meta, uint8(PaxFormatP8)
+	} else {
+		if meta, err = paa.DecodeMetadataHeaders(r); err != nil {
+			return entry, fmt.Errorf("%w: scan paa metadata: %w", ErrCorruptSource, err)
+		}
+
+		if paxFormat, err = paxTypeToU8(meta.Type); err != nil {
+			return entry, err
+		}
+
+		if ra, ok := r.(io.ReaderAt); ok {
+			b.applyPixelMetadata(ra, size, meta)
+		}
 	}
 
-	rel := b.normalizePath(path)
 	entry.ColorPaletteCount = 1
 	entry.PalettePtr = 0
+	if pac != nil {
+		entry.ColorPaletteCount = pac.paletteCount
+		entry.PalettePtr = pac.paletteOffset
+	}
+
 	entry.ClampFlags = 0
 	entry.TransparentColor = 0xFFFFFFFF
 	entry.LittleEndian = true
 	entry.IsPAA = strings.EqualFold(ext, ".paa")
 	entry.PAAFile = rel
-	entry.PaxFormat = uint32(meta.Type)
+	entry.PaxFormat = PaxFormat(paxFormat)
 	entry.PaxSuffixType = b.resolveSuffixType(rel)
-	entry.PaxFileSize, err = int64ToU32Strict(info.Size())
+	entry.PaxFileSize, err = int64ToU32Strict(size)
 	if err != nil {
 		return entry, err
 	}
@@ -300,9 +766,9 @@ func (b *Builder) buildEntry(path string) (TextureEntry, error) {
 }
 
 // resolveSuffixType resolves suffix type with optional per-path override.
-func (b *Builder) resolveSuffixType(rel string) uint32 {
+func (b *Builder) resolveSuffixType(rel string) SuffixType {
 	key := rel
-	if b.opts.LowercasePaths {
+	if b.lowercasePaths() {
 		key = strings.ToLower(key)
 	}
 
@@ -312,7 +778,12 @@ func (b *Builder) resolveSuffixType(rel string) uint32 {
 		}
 	}
 
-	v, _ := GuessSuffixTypeFromPath(rel)
+	rules := b.opts.SuffixRules
+	if rules == nil {
+		rules = defaultSuffixRuleset
+	}
+
+	v, _ := rules.Guess(rel)
 	return v
 }
 
@@ -322,30 +793,58 @@ func (b *Builder) normalizePath(in string) string {
 	baseDir := strings.TrimSpace(b.opts.BaseDir)
 
 	rel := cleanIn
-	if baseDir != "" {
-		if r, err := filepath.Rel(baseDir, cleanIn); err == nil {
-			rel = r
-		}
-	} else if filepath.IsAbs(cleanIn) {
-		if cwd, err := os.Getwd(); err == nil {
-			if r, relErr := filepath.Rel(cwd, cleanIn); relErr == nil {
+	if prefixed, ok := matchPrefixRoot(cleanIn, b.prefixRoots); ok {
+		rel = prefixed
+	} else {
+		if remapped, ok := remapPath(cleanIn, b.opts.PathRemap); ok {
+			rel = remapped
+		} else if baseDir != "" {
+			if r, err := filepath.Rel(baseDir, cleanIn); err == nil {
 				rel = r
 			}
+		} else if filepath.IsAbs(cleanIn) {
+			if cwd, err := os.Getwd(); err == nil {
+				if r, relErr := filepath.Rel(cwd, cleanIn); relErr == nil {
+					rel = r
+				}
+			}
 		}
+
+		rel = joinPrefix(b.opts.PBOPrefix, rel)
 	}
 
-	if b.opts.BackslashPaths {
+	return b.formatPath(rel)
+}
+
+// formatPath applies separator and case options to stored path.
+func (b *Builder) formatPath(rel string) string {
+	if b.backslashPaths() {
 		rel = strings.ReplaceAll(rel, "/", "\\")
 	}
 
 	rel = strings.TrimPrefix(rel, ".\\")
-	if b.opts.LowercasePaths {
+	if b.lowercasePaths() {
 		rel = strings.ToLower(rel)
 	}
 
 	return rel
 }
 
+// lowercasePaths reports whether stored paths are lowercased, true by default.
+func (b *Builder) lowercasePaths() bool {
+	return b.opts.LowercasePaths == nil || *b.opts.LowercasePaths
+}
+
+// backslashPaths reports whether stored paths use backslashes, true by default.
+func (b *Builder) backslashPaths() bool {
+	return b.opts.BackslashPaths == nil || *b.opts.BackslashPaths
+}
+
+// Bool returns pointer to v for optional boolean options.
+func Bool(v bool) *bool {
+	return &v
+}
+
 // assignColorHeaders maps PAA header color metadata into entry color fields.
 func assignColorHeaders(entry *TextureEntry, meta *paa.MetadataHeaders) {
 	if meta.HasAverageColor {
@@ -360,11 +859,7 @@ func assignColorHeaders(entry *TextureEntry, meta *paa.MetadataHeaders) {
 		entry.HasMaxCtagg = false
 	}
 
-	// BI stores byte color as B,G,R,A while float tuple is exposed as R,G,B,A.
-	entry.AverageColorF[0] = float32(entry.AverageColor[2]) / 255.0
-	entry.AverageColorF[1] = float32(entry.AverageColor[1]) / 255.0
-	entry.AverageColorF[2] = float32(entry.AverageColor[0]) / 255.0
-	entry.AverageColorF[3] = float32(entry.AverageColor[3]) / 255.0
+	entry.syncAverageColorF()
 }
 
 // assignFlagHeaders maps GALF metadata flags into alpha booleans.
@@ -408,6 +903,18 @@ func assignMipmaps(entry *TextureEntry, mips []paa.MipHeader, paxFormat uint8) e
 	return nil
 }
 
+// logger returns configured logger or a discarding one.
+func (b *Builder) logger() *slog.Logger {
+	if b.opts.Logger != nil {
+		return b.opts.Logger
+	}
+
+	return discardLogger
+}
+
+// discardLogger drops all records when BuildOptions.Logger is nil.
+var discardLogger = slog.New(slog.DiscardHandler)
+
 // resolveBuildWorkers resolves requested worker setting to an effective count.
 func resolveBuildWorkers(requested, fileCount int) int {
 	if fileCount <= 1 {
@@ -417,6 +924,8 @@ func resolveBuildWorkers(requested, fileCount int) int {
 	switch {
 	case requested == WorkersAuto:
 		return autoBuildWorkers(fileCount)
+	case requested == WorkersAdaptive:
+		return min(maxAdaptiveWorkers, fileCount)
 	case requested <= 1:
 		return 1
 	default:
@@ -450,7 +959,7 @@ func floorPow2(v int) int {
 // intToU32Strict safely converts int to uint32 without unsafe cast.
 func intToU32Strict(v int) (uint32, error) {
 	if v < 0 || uint64(v) > math.MaxUint32 {
-		return 0, fmt.Errorf("value out of uint32 range: %d", v)
+		return 0, fmt.Errorf("%w: uint32: %d", ErrOutOfRange, v)
 	}
 
 	return uint32(v), nil
@@ -459,7 +968,7 @@ func intToU32Strict(v int) (uint32, error) {
 // int64ToU32Strict safely converts int64 to uint32 without unsafe cast.
 func int64ToU32Strict(v int64) (uint32, error) {
 	if v < 0 || uint64(v) > math.MaxUint32 {
-		return 0, fmt.Errorf("value out of uint32 range: %d", v)
+		return 0, fmt.Errorf("%w: uint32: %d", ErrOutOfRange, v)
 	}
 
 	return uint32(v), nil
@@ -467,26 +976,11 @@ func int64ToU32Strict(v int64) (uint32, error) {
 
 // paxTypeToU8 maps known paa pax types to uint8 texheaders format field.
 func paxTypeToU8(t paa.PaxType) (uint8, error) {
-	switch t {
-	case paa.PaxGRAYA:
-		return 1, nil
-	case paa.PaxARGBA5:
-		return 3, nil
-	case paa.PaxARGB4:
-		return 4, nil
-	case paa.PaxARGB8:
-		return 5, nil
-	case paa.PaxDXT1:
-		return 6, nil
-	case paa.PaxDXT2:
-		return 7, nil
-	case paa.PaxDXT3:
-		return 8, nil
-	case paa.PaxDXT4:
-		return 9, nil
-	case paa.PaxDXT5:
-		return 10, nil
-	default:
-		return 0, fmt.Errorf("unsupported pax format: %d", t)
+	format, err := PaxTypeToFormat(t)
+	if err != nil {
+		return 0, err
 	}
+
+	u8, _ := format.mipFormat()
+	return u8, nil
 }
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadBuildOptions reads build options from JSON or YAML config file.
+func LoadBuildOptions(path string) (BuildOptions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return BuildOptions{}, fmt.Errorf("read %q: %w", path, err)
+	}
+
+	opts, err := ParseBuildOptions(data)
+	if err != nil {
+		return BuildOptions{}, fmt.Errorf("parse %q: %w", path, err)
+	}
+
+	return opts, nil
+}
+
+// ParseBuildOptions decodes build options config keyed by BuildOptions JSON
+// names, accepting the same JSON and YAML forms as ParseSuffixRules.
+// Unknown keys and out-of-range values are rejected; fields without JSON
+// name (SuffixRules, SortCompare, Logger) must be set in code.
+func ParseBuildOptions(data []byte) (BuildOptions, error) {
+	var opts BuildOptions
+	if err := decodeConfig(data, &opts); err != nil {
+		return BuildOptions{}, fmt.Errorf("%w: %w", ErrInvalidBuildOptions, err)
+	}
+
+	if err := checkBuildOptions(&opts); err != nil {
+		return BuildOptions{}, fmt.Errorf("%w: %w", ErrInvalidBuildOptions, err)
+	}
+
+	return opts, nil
+}
+
+// checkBuildOptions reports first config value builder cannot use.
+func checkBuildOptions(opts *BuildOptions) error {
+	for _, v := range []struct {
+		name  string
+		value int
+	}{
+		{"max_issues", opts.MaxIssues},
+		{"max_errors", opts.MaxErrors},
+		{"max_texture_size", opts.MaxTextureSize},
+		{"max_open_files", opts.MaxOpenFiles},
+		{"retry.attempts", opts.Retry.Attempts},
+	} {
+		if v.value < 0 {
+			return fmt.Errorf("%s: negative value %d", v.name, v.value)
+		}
+	}
+
+	if opts.Retry.Backoff < 0 || opts.Retry.MaxBackoff < 0 {
+		return errors.New("retry: negative backoff")
+	}
+
+	if opts.Workers < WorkersAdaptive {
+		return fmt.Errorf("workers: %d, want %d (adaptive), %d (auto) or more", opts.Workers, WorkersAdaptive, WorkersAuto)
+	}
+
+	switch opts.SortMode {
+	case SortLexical, SortNone, SortEngine:
+	default:
+		return fmt.Errorf("sort_mode: %d, want %d (lexical), %d (none) or %d (engine)", opts.SortMode, SortLexical, SortNone, SortEngine)
+	}
+
+	switch opts.SymlinkPolicy {
+	case SymlinkFollow, SymlinkSkip, SymlinkError:
+	default:
+		return fmt.Errorf("symlink_policy: %d, want %d (follow), %d (skip) or %d (error)", opts.SymlinkPolicy, SymlinkFollow, SymlinkSkip, SymlinkError)
+	}
+
+	for i, r := range opts.PathRemap {
+		if strings.TrimSpace(r.From) == "" {
+			return fmt.Errorf("path_remap[%d]: empty from", i)
+		}
+	}
+
+	return nil
+}
+
+// decodeConfig decodes JSON or YAML subset config into v, rejecting unknown
+// fields. Input starting with '{' is JSON, anything else YAML.
+func decodeConfig(data []byte, v any) error {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		converted, err := yamlToJSON(data)
+		if err != nil {
+			return err
+		}
+		data = converted
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
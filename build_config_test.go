@@ -0,0 +1,87 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBuildOptions(t *testing.T) {
+	t.Parallel()
+
+	yaml := `# pipeline build
+base_dir: P:\mymod
+pbo_prefix: mymod
+path_remap:
+  - from: P:\mymod\data
+    to: mymod\data
+suffix_overrides:
+  'mymod\data\sky.paa': diffuse_linear
+skip_invalid: true
+max_issues: 10
+lowercase_paths: false
+sort_mode: 2
+workers: -1
+placeholder:
+  pax_format: DXT5
+  width: 64
+retry:
+  attempts: 3
+  backoff: 100000000
+`
+	path := filepath.Join(t.TempDir(), "texheaders.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	opts, err := LoadBuildOptions(path)
+	if err != nil {
+		t.Fatalf("LoadBuildOptions() error: %v", err)
+	}
+
+	if opts.BaseDir != `P:\mymod` || opts.PBOPrefix != "mymod" || len(opts.PathRemap) != 1 || opts.PathRemap[0].To != `mymod\data` {
+		t.Fatalf("LoadBuildOptions() paths = %+v", opts)
+	}
+
+	if opts.SuffixOverrides[`mymod\data\sky.paa`] != SuffixDiffuseLinear || opts.LowercasePaths == nil || *opts.LowercasePaths {
+		t.Fatalf("LoadBuildOptions() overrides/case = %+v", opts)
+	}
+
+	if opts.SortMode != SortEngine || opts.Workers != WorkersAuto || opts.MaxIssues != 10 || !opts.SkipInvalid {
+		t.Fatalf("LoadBuildOptions() build = %+v", opts)
+	}
+
+	if opts.Placeholder == nil || opts.Placeholder.PaxFormat != PaxFormatDXT5 || opts.Placeholder.Width != 64 || opts.Retry.Attempts != 3 || opts.Retry.Backoff.Milliseconds() != 100 {
+		t.Fatalf("LoadBuildOptions() placeholder/retry = %+v %+v", opts.Placeholder, opts.Retry)
+	}
+
+	if _, err := ParseBuildOptions([]byte(`{"workers": 4}`)); err != nil {
+		t.Fatalf("ParseBuildOptions(json) error: %v", err)
+	}
+
+	if _, err := LoadBuildOptions(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("LoadBuildOptions(missing) error = %v", err)
+	}
+}
+
+func TestParseBuildOptions_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, data := range map[string]string{
+		"unknown key":    "base_dir: x\nbogus: 1\n",
+		"bad type":       "workers: many\n",
+		"bad suffix":     "suffix_overrides:\n  a.paa: shiny\n",
+		"negative":       "max_issues: -1\n",
+		"workers":        "workers: -3\n",
+		"custom sort":    "sort_mode: 3\n",
+		"symlink":        "symlink_policy: 7\n",
+		"empty remap":    "path_remap:\n  - to: x\n",
+		"negative retry": "retry:\n  backoff: -1\n",
+		"bad yaml":       "base_dir: [x\n",
+	} {
+		if _, err := ParseBuildOptions([]byte(data)); !errors.Is(err, ErrInvalidBuildOptions) {
+			t.Fatalf("ParseBuildOptions(%s) error = %v, want %v", name, err, ErrInvalidBuildOptions)
+		}
+	}
+}
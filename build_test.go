@@ -1,12 +1,16 @@
 package texheaders
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -25,8 +29,8 @@ func TestBuilder_BuildMatchesFixtureJSON(t *testing.T) {
 
 	b := NewBuilder(BuildOptions{
 		BaseDir:        baseDir,
-		LowercasePaths: true,
-		BackslashPaths: true,
+		LowercasePaths: Bool(true),
+		BackslashPaths: Bool(true),
 	})
 
 	for _, tex := range wantFile.Textures {
@@ -41,15 +45,14 @@ func TestBuilder_BuildMatchesFixtureJSON(t *testing.T) {
 		t.Fatalf("Build() error: %v", err)
 	}
 
-	wantMap := mapEntriesByPath(wantFile.Textures)
-	gotMap := mapEntriesByPath(got.Textures)
-	for path, wantEntry := range wantMap {
-		gotEntry, ok := gotMap[path]
+	for _, wantEntry := range wantFile.Textures {
+		path := wantEntry.PAAFile
+		gotEntry, ok := got.FindByPath(path)
 		if !ok {
 			t.Fatalf("missing generated entry for %q", path)
 		}
 
-		if err = assertEntryEqual(path, wantEntry, gotEntry); err != nil {
+		if err = assertEntryEqual(path, wantEntry, *gotEntry); err != nil {
 			t.Fatalf("entry mismatch: %v", err)
 		}
 	}
@@ -66,8 +69,8 @@ func TestBuilder_SkipInvalid(t *testing.T) {
 	b := NewBuilder(BuildOptions{
 		BaseDir:        baseDir,
 		SkipInvalid:    true,
-		LowercasePaths: true,
-		BackslashPaths: true,
+		LowercasePaths: Bool(true),
+		BackslashPaths: Bool(true),
 	})
 
 	if err = b.Append(filepath.Join(baseDir, "test_co.paa")); err != nil {
@@ -107,8 +110,8 @@ func TestBuilder_FailFastInvalid(t *testing.T) {
 
 	b := NewBuilder(BuildOptions{
 		BaseDir:        baseDir,
-		LowercasePaths: true,
-		BackslashPaths: true,
+		LowercasePaths: Bool(true),
+		BackslashPaths: Bool(true),
 	})
 
 	invalidPath := filepath.Join(t.TempDir(), "not_a_texture.txt")
@@ -178,14 +181,14 @@ func TestBuilder_ParallelParity(t *testing.T) {
 
 	serial := NewBuilder(BuildOptions{
 		BaseDir:        baseDir,
-		LowercasePaths: true,
-		BackslashPaths: true,
+		LowercasePaths: Bool(true),
+		BackslashPaths: Bool(true),
 		Workers:        1,
 	})
 	parallel := NewBuilder(BuildOptions{
 		BaseDir:        baseDir,
-		LowercasePaths: true,
-		BackslashPaths: true,
+		LowercasePaths: Bool(true),
+		BackslashPaths: Bool(true),
 		Workers:        4,
 	})
 
@@ -236,6 +239,8 @@ func TestResolveBuildWorkers(t *testing.T) {
 		{name: "auto large set", requested: WorkersAuto, fileCount: 100, want: 4}, // 20/4=5 -> floorPow2=4
 		{name: "auto small set", requested: WorkersAuto, fileCount: 3, want: 2},
 		{name: "single file always serial", requested: WorkersAuto, fileCount: 1, want: 1},
+		{name: "adaptive pool capped", requested: WorkersAdaptive, fileCount: 1000, want: 64},
+		{name: "adaptive pool by files", requested: WorkersAdaptive, fileCount: 3, want: 3},
 	}
 
 	for _, tt := range tests {
@@ -246,15 +251,6 @@ func TestResolveBuildWorkers(t *testing.T) {
 	}
 }
 
-func mapEntriesByPath(in []TextureEntry) map[string]TextureEntry {
-	out := make(map[string]TextureEntry, len(in))
-	for _, e := range in {
-		out[e.PAAFile] = e
-	}
-
-	return out
-}
-
 func stringsFromBackslashes(in string) string {
 	return filepath.FromSlash(strings.ReplaceAll(in, "\\", "/"))
 }
@@ -303,3 +299,243 @@ func assertEntryEqual(path string, want, got TextureEntry) error {
 func float32Near(a, b, eps float32) bool {
 	return float32(math.Abs(float64(a-b))) <= eps
 }
+
+func TestBuilder_DedupeInputs(t *testing.T) {
+	t.Parallel()
+
+	baseDir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatalf("filepath.Abs(testdata) error: %v", err)
+	}
+
+	b := NewBuilder(BuildOptions{
+		BaseDir:      baseDir,
+		DedupeInputs: true,
+	})
+
+	if err = b.AppendMany(
+		filepath.Join(baseDir, "test_co.paa"),
+		filepath.Join(baseDir, ".", "test_co.paa"),
+		filepath.Join(baseDir, "sub", "..", "test_co.paa"),
+	); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(got.Textures) != 1 {
+		t.Fatalf("textures = %d, want 1", len(got.Textures))
+	}
+}
+
+func TestBuilder_ResetAndClone(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{
+		SkipInvalid:     true,
+		SuffixOverrides: map[string]SuffixType{"a_co.paa": 1},
+		PathRemap:       []PathRemap{{From: "P:/mymod", To: "mymod"}},
+		SuffixRules:     NewSuffixRuleset(SuffixRule{Token: "_hm", Type: SuffixAmbientShadow}),
+	})
+	if err := b.AppendMany("b.paa", "a.paa"); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	c := b.Clone()
+	c.opts.SuffixOverrides["a_co.paa"] = 2
+	c.opts.PathRemap[0].To = "other"
+	c.opts.SuffixRules.RemoveRule("_hm")
+	if err := c.Append("c.paa"); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if _, ok := b.opts.SuffixRules.Guess("rock_hm.paa"); !ok || b.opts.SuffixOverrides["a_co.paa"] != 1 ||
+		b.opts.PathRemap[0].To != "mymod" {
+		t.Fatalf("Clone() shares options with original: %+v", b.opts)
+	}
+
+	if got := len(b.Inputs()); got != 2 {
+		t.Fatalf("original inputs = %d, want 2", got)
+	}
+
+	if got := c.Inputs(); len(got) != 3 || c.inputsSorted {
+		t.Fatalf("clone inputs = %v sorted=%v, want 3 unsorted", got, c.inputsSorted)
+	}
+
+	b.Reset()
+	if len(b.Inputs()) != 0 || len(b.Issues()) != 0 || !b.inputsSorted {
+		t.Fatalf("Reset() left state: inputs=%v issues=%v", b.Inputs(), b.Issues())
+	}
+
+	if !b.opts.SkipInvalid || len(c.Inputs()) != 3 {
+		t.Fatal("Reset() changed options or clone state")
+	}
+}
+
+func TestBuilder_RemoveReplace(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{})
+	if err := b.AppendMany("a.paa", "b.paa", "c.paa"); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	if err := b.Remove("./b.paa"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+
+	if err := b.Remove("b.paa"); !errors.Is(err, ErrInputNotFound) {
+		t.Fatalf("Remove(missing) error = %v, want %v", err, ErrInputNotFound)
+	}
+
+	if err := b.Replace("a.paa", "d.paa"); err != nil {
+		t.Fatalf("Replace() error: %v", err)
+	}
+
+	if err := b.Replace("c.paa", " "); !errors.Is(err, ErrEmptyInputPath) {
+		t.Fatalf("Replace(empty) error = %v, want %v", err, ErrEmptyInputPath)
+	}
+
+	if err := b.Replace("a.paa", "e.paa"); !errors.Is(err, ErrInputNotFound) {
+		t.Fatalf("Replace(missing) error = %v, want %v", err, ErrInputNotFound)
+	}
+
+	got := b.Inputs()
+	if len(got) != 2 || got[0] != "d.paa" || got[1] != "c.paa" || b.inputsSorted {
+		t.Fatalf("inputs = %v sorted=%v, want [d.paa c.paa] unsorted", got, b.inputsSorted)
+	}
+}
+
+func TestBuilder_ConcurrentAppend(t *testing.T) {
+	t.Parallel()
+
+	const producers, perProducer = 8, 100
+
+	b := NewBuilder(BuildOptions{})
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Go(func() {
+			for i := range perProducer {
+				path := fmt.Sprintf("p%d/t%03d_co.paa", p, i)
+				if i%2 == 0 {
+					_ = b.Append(path)
+					continue
+				}
+
+				_ = b.AppendMany(path)
+			}
+		})
+	}
+
+	wg.Wait()
+	if got := len(b.Inputs()); got != producers*perProducer {
+		t.Fatalf("inputs = %d, want %d", got, producers*perProducer)
+	}
+}
+
+func TestBuilder_Logger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	b := NewBuilder(BuildOptions{BaseDir: "testdata", SkipInvalid: true, Logger: logger})
+	if err := b.AppendMany("testdata/test_co.paa", "testdata/missing_co.paa"); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	if _, err := b.Build(); err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`"level":"DEBUG","msg":"texture built","path":"testdata/test_co.paa"`,
+		`"level":"WARN","msg":"texture failed","path":"testdata/missing_co.paa","kind":"not_found"`,
+		`"msg":"build finished","inputs":2,"entries":1,"failed":1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestBuilder_SortMode(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"a/x.paa", "Z.paa", "a0.paa"}
+	tests := []struct {
+		name string
+		opts BuildOptions
+		want []string
+	}{
+		{name: "lexical", opts: BuildOptions{}, want: []string{"Z.paa", "a/x.paa", "a0.paa"}},
+		{name: "none", opts: BuildOptions{SortMode: SortNone}, want: inputs},
+		{name: "engine", opts: BuildOptions{SortMode: SortEngine}, want: []string{"a0.paa", "a/x.paa", "Z.paa"}},
+		{name: "custom", opts: BuildOptions{SortMode: SortCustom, SortCompare: func(a, b string) int {
+			return len(a) - len(b)
+		}}, want: []string{"Z.paa", "a0.paa", "a/x.paa"}},
+	}
+
+	for _, tt := range tests {
+		b := NewBuilder(tt.opts)
+		if err := b.AppendMany(inputs...); err != nil {
+			t.Fatalf("AppendMany() error: %v", err)
+		}
+
+		got := b.snapshotInputs()
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Fatalf("%s: order = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_AppendWithInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error: %v", err)
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err = b.AppendWithInfo(path, info); err != nil {
+		t.Fatalf("AppendWithInfo() error: %v", err)
+	}
+
+	// Source grows after the walk; supplied size must be used without Stat.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("OpenFile() error: %v", err)
+	}
+	if _, err = f.Write([]byte{0}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	_ = f.Close()
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if size := int64(got.Textures[0].PaxFileSize); size != info.Size() {
+		t.Fatalf("PaxFileSize = %d, want supplied %d", size, info.Size())
+	}
+
+	if err = b.Remove(path); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+
+	if len(b.infos) != 0 {
+		t.Fatalf("infos = %d after Remove, want 0", len(b.infos))
+	}
+
+	if err = b.AppendWithInfo(" ", info); !errors.Is(err, ErrEmptyInputPath) {
+		t.Fatalf("AppendWithInfo(empty) error = %v, want ErrEmptyInputPath", err)
+	}
+}
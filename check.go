@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// checkHeaderSize is the number of leading bytes read by CheckInputs.
+const checkHeaderSize = 8
+
+// pngMagic is the PNG file signature.
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+// CheckInputs verifies existence, extension, readability and leading header
+// bytes of all appended inputs in parallel, without producing entries.
+//
+// Returned issues are in input order; nil means every input passed.
+func (b *Builder) CheckInputs() []BuildIssue {
+	inputs := b.snapshotInputs()
+	errs := make([]error, len(inputs))
+
+	workers := resolveBuildWorkers(b.opts.Workers, len(inputs))
+	if workers <= 1 {
+		workers = resolveBuildWorkers(WorkersAuto, len(inputs))
+	}
+
+	jobs := make(chan int, workers)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range jobs {
+				errs[i] = b.checkInput(inputs[i])
+			}
+		})
+	}
+
+	for i := range inputs {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	var issues []BuildIssue
+	for i, err := range errs {
+		if err != nil {
+			issues = append(issues, newBuildIssue(inputs[i], err))
+		}
+	}
+
+	return issues
+}
+
+// checkInput verifies one input is supported, readable and starts with a valid header.
+func (b *Builder) checkInput(path string) error {
+	ext, err := b.sourceExt(path)
+	if err != nil {
+		return err
+	}
+
+	fh, err := b.openSource(path)
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+
+	defer b.closeSource(fh)
+
+	head := make([]byte, checkHeaderSize)
+	n, err := io.ReadFull(fh, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return fmt.Errorf("%w: empty file", ErrCorruptSource)
+		}
+
+		return fmt.Errorf("read source: %w", err)
+	}
+
+	head = head[:n]
+	switch ext {
+	case ".paa":
+		if n < 2 || !isPAATypeTag(binary.LittleEndian.Uint16(head)) {
+			return fmt.Errorf("%w: unknown paa type tag", ErrCorruptSource)
+		}
+	case sourceExtPNG:
+		if !bytes.Equal(head, pngMagic) {
+			return fmt.Errorf("%w: missing png signature", ErrCorruptSource)
+		}
+	case sourceExtDDS:
+		if !bytes.HasPrefix(head, []byte("DDS ")) {
+			return fmt.Errorf("%w: missing dds signature", ErrCorruptSource)
+		}
+	}
+
+	return nil
+}
+
+// isPAATypeTag reports whether v is a known paa type tag.
+func isPAATypeTag(v uint16) bool {
+	switch v {
+	case 0xFF01, 0xFF02, 0xFF03, 0xFF04, 0xFF05, 0x4444, 0x1555, 0x8888, 0x8080:
+		return true
+	default:
+		return false
+	}
+}
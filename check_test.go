@@ -0,0 +1,52 @@
+package texheaders
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_CheckInputs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "ok_co.paa")
+	files := map[string][]byte{
+		"bad_co.paa":   []byte("bad!"),
+		"empty_co.paa": nil,
+		"ok_co.png":    []byte("\x89PNG\r\n\x1a\n"),
+		"bad_co.dds":   []byte("XXXX"),
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", name, err)
+		}
+	}
+
+	b := NewBuilder(BuildOptions{AllowSourceImages: true})
+	for _, name := range []string{"ok_co.paa", "bad_co.paa", "empty_co.paa", "ok_co.png", "bad_co.dds", "missing_co.paa", "notes.txt"} {
+		if err := b.Append(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	want := map[string]IssueKind{
+		"bad_co.paa":     IssueCorruptPAA,
+		"empty_co.paa":   IssueCorruptPAA,
+		"bad_co.dds":     IssueCorruptPAA,
+		"missing_co.paa": IssueNotFound,
+		"notes.txt":      IssueUnsupportedFormat,
+	}
+
+	issues := b.CheckInputs()
+	if len(issues) != len(want) {
+		t.Fatalf("CheckInputs() = %+v, want %d issues", issues, len(want))
+	}
+
+	for _, issue := range issues {
+		if kind := want[filepath.Base(issue.Path)]; issue.Kind != kind {
+			t.Fatalf("issue %s kind = %q, want %q", issue.Path, issue.Kind, kind)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// defaultConfigName is file name written by config init.
+const defaultConfigName = "texheaders.yaml"
+
+// starterConfig is commented build config written by config init; every key
+// is a BuildOptions field accepted by texheaders.LoadBuildOptions.
+const starterConfig = `# texheaders build options, see texheaders.LoadBuildOptions.
+# Uncomment and edit keys as needed; unknown keys are rejected.
+
+# Directory stored entry paths are relative to.
+# base_dir: P:\mymod
+
+# Prefix prepended to paths not covered by a $PBOPREFIX$ file.
+# pbo_prefix: mymod
+
+# Source path prefixes rewritten before paths are stored; first match wins.
+# path_remap:
+#   - from: P:\mymod\data
+#     to: mymod\data
+
+# Suffix types forced for normalized entry paths.
+# suffix_overrides:
+#   'mymod\data\sky_co.paa': diffuse_linear
+
+# Keep building when one input fails, up to max_issues skipped inputs (0: no limit).
+skip_invalid: false
+# max_issues: 0
+
+# Stored path form; both default to true.
+# lowercase_paths: true
+# backslash_paths: true
+
+# Fail inputs whose stored path is absolute or contains "..".
+strict_paths: false
+
+# Entry order: 0 lexical, 1 registration order, 2 engine (lowercase backslash).
+sort_mode: 0
+
+# Links met by directory scans: 0 follow, 1 skip, 2 error.
+symlink_policy: 0
+
+# Largest top mip dimension accepted without warning (0: 4096).
+# max_texture_size: 4096
+
+# Parallel entry builds: 0 or 1 sequential, -1 auto, -2 adaptive, N workers.
+workers: -1
+
+# Limit of source files open at once (0: unlimited).
+# max_open_files: 0
+
+# Retry transient I/O errors; backoff values are nanoseconds.
+# retry:
+#   attempts: 3
+#   backoff: 100000000
+#   max_backoff: 1000000000
+
+# Emit placeholder entries for missing sources instead of failing.
+# placeholder:
+#   pax_format: DXT5
+#   width: 64
+#   height: 64
+
+# Hash sources and report byte-identical textures.
+detect_duplicates: false
+`
+
+// runConfig dispatches config subcommands.
+func runConfig(e *env, args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "init":
+			return runConfigInit(e, args[1:])
+		case "check":
+			return runConfigCheck(e, args[1:])
+		case "-h", "-help", "--help":
+			e.configUsage()
+			return exitOK
+		}
+	}
+
+	e.configUsage()
+	return exitUsage
+}
+
+// configUsage prints config subcommands.
+func (e *env) configUsage() {
+	fmt.Fprintln(e.stderr, "Usage: texheaders config <init|check> [flags] [args]")
+	fmt.Fprintln(e.stderr)
+	fmt.Fprintln(e.stderr, "  init            write commented starter "+defaultConfigName)
+	fmt.Fprintln(e.stderr, "  check <config>  validate build options config")
+}
+
+// runConfigInit writes starter config to stdout or file.
+func runConfigInit(e *env, args []string) int {
+	fs := e.newFlagSet("config init", "")
+	output := fs.String("o", "-", `output path, "-" for stdout (e.g. `+defaultConfigName+`)`)
+	force := fs.Bool("force", false, "overwrite existing output file")
+	_, code, ok := e.parse(fs, args, 0, 0)
+	if !ok {
+		return code
+	}
+
+	if *output == "-" {
+		if _, err := io.WriteString(e.stdout, starterConfig); err != nil {
+			return e.fail("config init", err)
+		}
+		return exitOK
+	}
+
+	if !*force {
+		if _, err := os.Stat(*output); err == nil {
+			return e.fail("config init", fmt.Errorf("%s already exists; use --force to overwrite", *output))
+		}
+	}
+
+	out, err := os.Create(*output)
+	if err != nil {
+		return e.fail("config init", err)
+	}
+
+	if _, err = io.WriteString(out, starterConfig); err != nil {
+		_ = out.Close()
+		return e.fail("config init", err)
+	}
+
+	if err := out.Close(); err != nil {
+		return e.fail("config init", err)
+	}
+
+	return exitOK
+}
+
+// runConfigCheck validates config against LoadBuildOptions and prints
+// decoded options in structured output modes.
+func runConfigCheck(e *env, args []string) int {
+	fs := e.newFlagSet("config check", "<config>")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	opts, err := texheaders.LoadBuildOptions(pos[0])
+	if err != nil {
+		return e.fail("config check", err)
+	}
+
+	err = e.render(view{
+		data: opts,
+		rows: [][]string{{"config", "status"}, {pos[0], "ok"}},
+		text: func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "%s: ok\n", pos[0])
+			return err
+		},
+	})
+	if err != nil {
+		return e.fail("config check", err)
+	}
+
+	return exitOK
+}
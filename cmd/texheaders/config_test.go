@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfig(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := runCLI(t, "config", "init")
+	if code != exitOK || stdout != starterConfig {
+		t.Fatalf("config init = %d, stdout %q", code, stdout)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, defaultConfigName)
+	if code, _, stderr := runCLI(t, "config", "init", "-o", path); code != exitOK {
+		t.Fatalf("config init -o = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr := runCLI(t, "config", "init", "-o", path); code != exitFailure || !strings.Contains(stderr, "--force") {
+		t.Fatalf("config init -o(existing) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr := runCLI(t, "config", "init", "-o", path, "--force"); code != exitOK {
+		t.Fatalf("config init --force = %d, stderr %q", code, stderr)
+	}
+
+	if code, stdout, stderr := runCLI(t, "config", "check", path); code != exitOK || stdout != path+": ok\n" {
+		t.Fatalf("config check(starter) = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	if code, stdout, _ := runCLI(t, "config", "check", "--output", "json", path); code != exitOK || !strings.Contains(stdout, `"workers": -1`) {
+		t.Fatalf("config check --output json = %d, stdout %q", code, stdout)
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("workers: 2\nbase_dri: x\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	if code, _, stderr := runCLI(t, "config", "check", bad); code != exitFailure || !strings.Contains(stderr, "base_dri") {
+		t.Fatalf("config check(bad) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, _ := runCLI(t, "config"); code != exitUsage {
+		t.Fatalf("config = %d, want %d", code, exitUsage)
+	}
+}
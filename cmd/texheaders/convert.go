@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// formatBin names binary texHeaders.bin encoding in --from and --to flags.
+const formatBin = "bin"
+
+// runConvert converts index between binary and JSON/YAML text forms.
+func runConvert(e *env, args []string) int {
+	fs := e.newFlagSet("convert", "<in> <out>")
+	from := fs.String("from", "", "input format: bin, json or yaml (default: by extension)")
+	to := fs.String("to", "", "output format: bin, json or yaml (default: by extension)")
+	pos, code, ok := e.parse(fs, args, 2, 2)
+	if !ok {
+		return code
+	}
+
+	inFormat, err := resolveFormat(pos[0], *from)
+	if err != nil {
+		return e.fail("convert", err)
+	}
+
+	outFormat, err := resolveFormat(pos[1], *to)
+	if err != nil {
+		return e.fail("convert", err)
+	}
+
+	f, err := e.readIndex(pos[0], inFormat)
+	if err != nil {
+		return e.fail("convert", err)
+	}
+
+	if err := e.writeIndex(pos[1], outFormat, f); err != nil {
+		return e.fail("convert", err)
+	}
+
+	return exitOK
+}
+
+// resolveFormat returns explicit format or one implied by path extension;
+// paths without known text extension are binary, "-" needs explicit format.
+func resolveFormat(path, explicit string) (string, error) {
+	switch explicit {
+	case formatBin, string(texheaders.TextFormatJSON), string(texheaders.TextFormatYAML):
+		return explicit, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown format %q", explicit)
+	}
+
+	if path == "-" {
+		return "", fmt.Errorf("format of %q must be set with --from or --to", path)
+	}
+
+	if tf, ok := texheaders.TextFormatFromPath(path); ok {
+		return string(tf), nil
+	}
+
+	return formatBin, nil
+}
+
+// readIndex reads index in format from path, "-" for stdin.
+func (e *env) readIndex(path, format string) (*texheaders.File, error) {
+	r := e.stdin
+	if path != "-" {
+		fh, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = fh.Close() }()
+		r = fh
+	}
+
+	if format == formatBin {
+		return texheaders.Read(r)
+	}
+
+	return texheaders.Import(r, texheaders.TextFormat(format))
+}
+
+// writeIndex writes index in format to path, "-" for stdout. Output is
+// encoded fully before the file is created, so failures leave no partial file.
+func (e *env) writeIndex(path, format string, f *texheaders.File) error {
+	var buf bytes.Buffer
+	var err error
+	if format == formatBin {
+		err = texheaders.Write(&buf, f)
+	} else {
+		err = texheaders.Export(&buf, f, texheaders.TextFormat(format))
+	}
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		_, err = io.Copy(e.stdout, &buf)
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err = out.Write(buf.Bytes()); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConvert(t *testing.T) {
+	t.Parallel()
+
+	want, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	dir := t.TempDir()
+	for _, ext := range []string{".json", ".yaml"} {
+		text := filepath.Join(dir, "index"+ext)
+		if code, _, stderr := runCLI(t, "convert", fixturePath, text); code != exitOK {
+			t.Fatalf("convert bin->%s = %d, stderr %q", ext, code, stderr)
+		}
+
+		back := filepath.Join(dir, "back"+ext+".bin")
+		if code, _, stderr := runCLI(t, "convert", text, back); code != exitOK {
+			t.Fatalf("convert %s->bin = %d, stderr %q", ext, code, stderr)
+		}
+
+		got, err := os.ReadFile(back)
+		if err != nil {
+			t.Fatalf("ReadFile() error: %v", err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s round trip changed file bytes", ext)
+		}
+	}
+
+	code, stdout, _ := runCLI(t, "convert", "--to", "yaml", fixturePath, "-")
+	if code != exitOK || !strings.HasPrefix(stdout, "magic: 0DHT\n") {
+		t.Fatalf("convert to stdout = %d, stdout %.40q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "convert", fixturePath, "-"); code != exitFailure || !strings.Contains(stderr, "--from or --to") {
+		t.Fatalf("convert to - without --to = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr := runCLI(t, "convert", "--to", "toml", fixturePath, "x"); code != exitFailure || !strings.Contains(stderr, `unknown format "toml"`) {
+		t.Fatalf("convert --to toml = %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// dupesResult is structured output of dupes command.
+type dupesResult struct {
+	Paths   []texheaders.PathDuplicate  `json:"paths"`
+	Content []texheaders.DuplicateGroup `json:"content,omitempty"`
+}
+
+// runDupes lists entries sharing a path and, with --content, entries whose
+// sources are byte-identical; it exits 1 when any group is found.
+func runDupes(e *env, args []string) int {
+	fs := e.newFlagSet("dupes", "<texHeaders.bin>")
+	content := fs.Bool("content", false, "also hash source textures and group identical ones")
+	baseDir := fs.String("base-dir", "", "directory entry paths resolve under for --content (default: index directory)")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("dupes", err)
+	}
+
+	res := dupesResult{Paths: f.PathDuplicates()}
+	if res.Paths == nil {
+		res.Paths = []texheaders.PathDuplicate{}
+	}
+
+	if *content {
+		dir := *baseDir
+		if dir == "" {
+			dir = filepath.Dir(pos[0])
+		}
+
+		if res.Content, err = texheaders.ContentDuplicates(f, dir); err != nil {
+			return e.fail("dupes", err)
+		}
+	}
+
+	rows := [][]string{{"kind", "group", "path", "detail"}}
+	for i, g := range res.Paths {
+		for j, p := range g.Paths {
+			rows = append(rows, []string{"path", strconv.Itoa(i + 1), p, "entry " + strconv.Itoa(g.Entries[j])})
+		}
+	}
+	for i, g := range res.Content {
+		for _, p := range g.Paths {
+			rows = append(rows, []string{"content", strconv.Itoa(i + 1), p, g.Hash})
+		}
+	}
+
+	err = e.render(view{
+		data: res,
+		rows: rows,
+		text: func(w io.Writer) error {
+			for _, g := range res.Paths {
+				entries := make([]string, len(g.Entries))
+				for j, i := range g.Entries {
+					entries[j] = "#" + strconv.Itoa(i)
+				}
+				fmt.Fprintf(w, "path %s: %s\n", strings.Join(entries, " "), strings.Join(g.Paths, ", "))
+			}
+			for _, g := range res.Content {
+				fmt.Fprintf(w, "content %.12s (%d bytes): %s\n", g.Hash, g.Size, strings.Join(g.Paths, ", "))
+			}
+			_, err := fmt.Fprintf(w, "%d path groups, %d content groups\n", len(res.Paths), len(res.Content))
+			return err
+		},
+	})
+	if err != nil {
+		return e.fail("dupes", err)
+	}
+
+	if len(res.Paths) > 0 || len(res.Content) > 0 {
+		return exitFailure
+	}
+
+	return exitOK
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunDupes(t *testing.T) {
+	t.Parallel()
+
+	if code, stdout, _ := runCLI(t, "dupes", fixturePath); code != exitOK || stdout != "0 path groups, 0 content groups\n" {
+		t.Fatalf("dupes(fixture) = %d, stdout %q", code, stdout)
+	}
+
+	dir := t.TempDir()
+	index := writeFixture(t, dir, func(f *texheaders.File) {
+		f.Textures = f.Textures[:4]
+		f.Textures[0].PAAFile = "rock_co.paa"
+		f.Textures[1].PAAFile = "Rock_CO.paa"
+		f.Textures[2].PAAFile = "stone_co.paa"
+		f.Textures[3].PAAFile = "grass_co.paa"
+	})
+
+	src, err := os.ReadFile("../../testdata/test_co.paa")
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	for _, name := range []string{"rock_co.paa", "stone_co.paa"} {
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o600); err != nil {
+			t.Fatalf("WriteFile() error: %v", err)
+		}
+	}
+
+	code, stdout, _ := runCLI(t, "dupes", index)
+	if code != exitFailure || !strings.Contains(stdout, "path #0 #1: rock_co.paa, Rock_CO.paa\n") || strings.Contains(stdout, "\ncontent ") {
+		t.Fatalf("dupes = %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "dupes", "--content", index, "--output", "csv")
+	if code != exitFailure || !strings.Contains(stdout, "content,1,rock_co.paa,") || !strings.Contains(stdout, "content,1,stone_co.paa,") {
+		t.Fatalf("dupes --content = %d, stdout %q", code, stdout)
+	}
+}
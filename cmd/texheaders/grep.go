@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runGrep prints or exports entries matching field predicates and exits 1
+// when none matches.
+func runGrep(e *env, args []string) int {
+	fs := e.newFlagSet("grep", "<texHeaders.bin>")
+	var paths, suffixes, formats stringList
+	fs.Var(&paths, "path", "glob of entry paths, repeatable (e.g. **/*_co.paa)")
+	fs.Var(&suffixes, "suffix", "suffix type, repeatable or comma-separated (e.g. normal_map)")
+	fs.Var(&formats, "format", "pax format, repeatable or comma-separated (e.g. dxt5)")
+	var q texheaders.Query
+	fs.IntVar(&q.MinWidth, "min-width", 0, "smallest top mip width")
+	fs.IntVar(&q.MaxWidth, "max-width", 0, "largest top mip width")
+	fs.IntVar(&q.MinHeight, "min-height", 0, "smallest top mip height")
+	fs.IntVar(&q.MaxHeight, "max-height", 0, "largest top mip height")
+	output := fs.String("o", "", `export matching entries to index file, "-" for stdout`)
+	to := fs.String("to", "", "export format: bin, json or yaml (default: by extension)")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	q.Paths = paths
+	for _, s := range suffixes {
+		t, err := texheaders.ParseSuffixType(s)
+		if err != nil {
+			return e.fail("grep", err)
+		}
+		q.Suffixes = append(q.Suffixes, t)
+	}
+
+	for _, s := range formats {
+		pf, err := texheaders.ParsePaxFormat(s)
+		if err != nil {
+			return e.fail("grep", err)
+		}
+		q.Formats = append(q.Formats, pf)
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("grep", err)
+	}
+
+	matched, err := f.Query(q)
+	if err != nil {
+		return e.fail("grep", err)
+	}
+
+	if *output != "" {
+		format, err := resolveFormat(*output, *to)
+		if err != nil {
+			return e.fail("grep", err)
+		}
+
+		if err := e.writeIndex(*output, format, matched); err != nil {
+			return e.fail("grep", err)
+		}
+
+		if *output != "-" {
+			fmt.Fprintf(e.stdout, "%d of %d entries written to %s\n", len(matched.Textures), len(f.Textures), *output)
+		}
+	} else {
+		rows := entryRows(matched)
+		if err := e.render(view{data: rows, rows: entryTable(rows)}); err != nil {
+			return e.fail("grep", err)
+		}
+	}
+
+	if len(matched.Textures) == 0 {
+		return exitFailure
+	}
+
+	return exitOK
+}
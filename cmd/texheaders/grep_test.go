@@ -0,0 +1,52 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunGrep(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "grep", fixturePath, "--suffix", "normal_map", "--format", "dxt5", "--min-width", "128")
+	if code != exitOK {
+		t.Fatalf("grep = %d, stderr %q", code, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "PATH ") {
+		t.Fatalf("grep stdout = %q", stdout)
+	}
+
+	for _, line := range lines[1:] {
+		if !strings.Contains(line, " DXT5 ") || !strings.Contains(line, " normal_map ") {
+			t.Fatalf("grep matched %q", line)
+		}
+	}
+
+	out := filepath.Join(t.TempDir(), "subset.bin")
+	code, stdout, _ = runCLI(t, "grep", fixturePath, "--path", "*_co.paa,*_ca.paa", "-o", out)
+	if code != exitOK || stdout != "2 of 46 entries written to "+out+"\n" {
+		t.Fatalf("grep -o = %d, stdout %q", code, stdout)
+	}
+
+	subset, err := texheaders.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	if len(subset.Textures) != 2 || subset.Textures[0].PAAFile != "test_ca.paa" {
+		t.Fatalf("grep -o subset = %+v", subset.Textures)
+	}
+
+	if code, stdout, _ := runCLI(t, "grep", fixturePath, "--min-width", "4096"); code != exitFailure || stdout != "PATH  FORMAT  SUFFIX  WIDTH  HEIGHT  MIPS  SIZE\n" {
+		t.Fatalf("grep(no match) = %d, stdout %q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "grep", fixturePath, "--suffix", "shiny"); code != exitFailure || !strings.Contains(stderr, "shiny") {
+		t.Fatalf("grep(bad suffix) = %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// entryRow is entry summary printed by list and inspect.
+type entryRow struct {
+	Path    string                `json:"path"`
+	Format  texheaders.PaxFormat  `json:"format"`
+	Suffix  texheaders.SuffixType `json:"suffix"`
+	Width   int                   `json:"width"`
+	Height  int                   `json:"height"`
+	MipMaps int                   `json:"mipmaps"`
+	Size    uint32                `json:"size"`
+}
+
+// inspectResult is structured output of inspect command.
+type inspectResult struct {
+	File     string     `json:"file"`
+	Magic    string     `json:"magic"`
+	Textures []entryRow `json:"textures"`
+	Version  uint32     `json:"version"`
+	Entries  int        `json:"entries"`
+}
+
+// runInspect prints header, entry count and entry table of index file.
+func runInspect(e *env, args []string) int {
+	fs := e.newFlagSet("inspect", "<texHeaders.bin>")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("inspect", err)
+	}
+
+	rows := entryRows(f)
+	res := inspectResult{File: pos[0], Magic: f.Magic, Version: f.Version, Entries: len(rows), Textures: rows}
+	err = e.render(view{
+		data: res,
+		rows: entryTable(rows),
+		text: func(w io.Writer) error {
+			fmt.Fprintf(w, "File:     %s\n", res.File)
+			fmt.Fprintf(w, "Magic:    %s\n", res.Magic)
+			fmt.Fprintf(w, "Version:  %d\n", res.Version)
+			fmt.Fprintf(w, "Entries:  %d\n\n", res.Entries)
+			return writeRows(w, entryTable(rows))
+		},
+	})
+	if err != nil {
+		return e.fail("inspect", err)
+	}
+
+	return exitOK
+}
+
+// runList prints entry table of index file.
+func runList(e *env, args []string) int {
+	fs := e.newFlagSet("list", "<texHeaders.bin>")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("list", err)
+	}
+
+	rows := entryRows(f)
+	if err := e.render(view{data: rows, rows: entryTable(rows)}); err != nil {
+		return e.fail("list", err)
+	}
+
+	return exitOK
+}
+
+// entryRows returns summary of every entry in file order.
+func entryRows(f *texheaders.File) []entryRow {
+	rows := make([]entryRow, 0, len(f.Textures))
+	for _, entry := range f.All() {
+		rows = append(rows, entryRow{
+			Path:    entry.PAAFile,
+			Format:  entry.PaxFormat,
+			Suffix:  entry.PaxSuffixType,
+			Width:   int(entry.Width()),
+			Height:  int(entry.Height()),
+			MipMaps: len(entry.MipMaps),
+			Size:    entry.PaxFileSize,
+		})
+	}
+
+	return rows
+}
+
+// entryTable returns header and one record per entry summary.
+func entryTable(rows []entryRow) [][]string {
+	out := make([][]string, 0, len(rows)+1)
+	out = append(out, []string{"path", "format", "suffix", "width", "height", "mips", "size"})
+	for _, r := range rows {
+		out = append(out, []string{
+			r.Path, r.Format.String(), r.Suffix.String(),
+			strconv.Itoa(r.Width), strconv.Itoa(r.Height), strconv.Itoa(r.MipMaps),
+			strconv.FormatUint(uint64(r.Size), 10),
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunInspect(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "inspect", fixturePath)
+	if code != exitOK {
+		t.Fatalf("inspect = %d, stderr %q", code, stderr)
+	}
+
+	for _, want := range []string{"Magic:    0DHT", "Version:  1", "PATH", "test_nohq.paa", "DXT5", "normal_map"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("inspect output missing %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRunList(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := runCLI(t, "list", fixturePath)
+	if code != exitOK {
+		t.Fatalf("list = %d", code)
+	}
+
+	if strings.Contains(stdout, "Magic:") || !strings.Contains(stdout, "test_co.paa") {
+		t.Fatalf("list output:\n%s", stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "list", filepath.Join(t.TempDir(), "missing.bin")); code != exitFailure || !strings.Contains(stderr, "texheaders list:") {
+		t.Fatalf("list(missing) = %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+// Command texheaders inspects, validates and edits texHeaders.bin files.
+//
+// Usage:
+//
+//	texheaders <command> [flags] [args]
+//
+// Run "texheaders help" for the list of commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Process exit codes.
+const (
+	// exitOK means command succeeded.
+	exitOK = 0
+	// exitFailure means command failed at runtime.
+	exitFailure = 1
+	// exitUsage means invalid command line.
+	exitUsage = 2
+)
+
+// command is one CLI subcommand.
+type command struct {
+	run     func(env *env, args []string) int // run executes command with its arguments.
+	name    string
+	summary string // summary is one-line help text.
+}
+
+// env carries command streams and output format.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	output outputFormat // output is --output value, global or per command.
+}
+
+// commands lists subcommands in help order.
+var commands = []command{
+	{name: "inspect", summary: "print header, entry count and entry table", run: runInspect},
+	{name: "list", summary: "print entry table", run: runList},
+	{name: "validate", summary: "validate index, exit 0/1/2/3 for ok/warnings/errors/usage", run: runValidate},
+	{name: "convert", summary: "convert index between bin, JSON and YAML", run: runConvert},
+	{name: "verify", summary: "verify entries against source textures", run: runVerify},
+	{name: "stat", summary: "print format, suffix and dimension statistics", run: runStat},
+	{name: "suffix", summary: "guess suffix types or audit stored ones", run: runSuffix},
+	{name: "update", summary: "refresh index from new, changed and removed textures", run: runUpdate},
+	{name: "show", summary: "print all fields of one entry", run: runShow},
+	{name: "remap", summary: "rewrite entry path prefixes", run: runRemap},
+	{name: "pbo", summary: "extract or inject texHeaders.bin in PBO archive", run: runPBO},
+	{name: "orphans", summary: "compare index with textures on disk", run: runOrphans},
+	{name: "dupes", summary: "find entries sharing a path or source content", run: runDupes},
+	{name: "config", summary: "write or check build options config", run: runConfig},
+	{name: "grep", summary: "print or export entries matching field filters", run: runGrep},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes CLI with arguments (without program name) and returns exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdin: os.Stdin, stdout: stdout, stderr: stderr, output: outputTable}
+
+	global := flag.NewFlagSet("texheaders", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() {}
+	global.Var(&e.output, "output", "output format: table, json, yaml or csv")
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			e.usage(stdout)
+			return exitOK
+		}
+		e.usage(stderr)
+		return exitUsage
+	}
+
+	args = global.Args()
+	if len(args) == 0 {
+		e.usage(stderr)
+		return exitUsage
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		e.usage(stdout)
+		return exitOK
+	}
+
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(e, args[1:])
+		}
+	}
+
+	fmt.Fprintf(stderr, "texheaders: unknown command %q\n\n", name)
+	e.usage(stderr)
+	return exitUsage
+}
+
+// usage prints command list.
+func (e *env) usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: texheaders [--output table|json|yaml|csv] <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "texheaders <command> -h" for command flags.`)
+}
+
+// newFlagSet returns flag set of command reporting errors to stderr.
+func (e *env) newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: texheaders %s [flags] %s\n", name, args)
+		if hasFlags(fs) {
+			fmt.Fprintln(e.stderr, "\nFlags:")
+			fs.PrintDefaults()
+		}
+	}
+
+	return fs
+}
+
+// parse parses flags placed before, between or after positional arguments
+// and checks positional count is within [minArgs, maxArgs] (maxArgs < 0 for
+// unlimited). It returns positional arguments and exit code; ok is false
+// when command must stop with that code.
+func (e *env) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) (pos []string, code int, ok bool) {
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, exitOK, false
+			}
+			return nil, exitUsage, false
+		}
+
+		if fs.NArg() == 0 {
+			break
+		}
+
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(pos) < minArgs || (maxArgs >= 0 && len(pos) > maxArgs) {
+		fs.Usage()
+		return nil, exitUsage, false
+	}
+
+	return pos, exitOK, true
+}
+
+// fail prints error prefixed with command name and returns exitFailure.
+func (e *env) fail(name string, err error) int {
+	fmt.Fprintf(e.stderr, "texheaders %s: %v\n", name, err)
+	return exitFailure
+}
+
+// stringList is repeatable flag collecting values; comma-separated values
+// are split.
+type stringList []string
+
+// String implements flag.Value.
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set implements flag.Value.
+func (l *stringList) Set(v string) error {
+	for part := range strings.SplitSeq(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+
+	return nil
+}
+
+// hasFlags reports whether flag set defines any flag.
+func hasFlags(fs *flag.FlagSet) bool {
+	found := false
+	fs.VisitAll(func(*flag.Flag) { found = true })
+	return found
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// fixturePath is official texHeaders.bin shared with library tests.
+const fixturePath = "../../testdata/texHeaders.bin"
+
+// runCLI runs CLI and returns exit code, stdout and stderr.
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// writeFixture writes copy of fixture changed by mutate into dir and
+// returns its path.
+func writeFixture(t *testing.T, dir string, mutate func(*texheaders.File)) string {
+	t.Helper()
+
+	f, err := texheaders.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	if mutate != nil {
+		mutate(f)
+	}
+
+	path := filepath.Join(dir, "texHeaders.bin")
+	if err := texheaders.WriteFile(path, f); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	return path
+}
+
+func TestRunUsage(t *testing.T) {
+	t.Parallel()
+
+	if code, _, stderr := runCLI(t); code != exitUsage || !strings.Contains(stderr, "Commands:") {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+
+	if code, stdout, _ := runCLI(t, "help"); code != exitOK || !strings.Contains(stdout, "inspect") {
+		t.Fatalf("run(help) = %d, stdout %q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "bogus"); code != exitUsage || !strings.Contains(stderr, `unknown command "bogus"`) {
+		t.Fatalf("run(bogus) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr := runCLI(t, "list", "-h"); code != exitOK || !strings.Contains(stderr, "Usage: texheaders list") {
+		t.Fatalf("run(list -h) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, _ := runCLI(t, "list", "a.bin", "b.bin"); code != exitUsage {
+		t.Fatalf("run(list a b) = %d, want %d", code, exitUsage)
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runOrphans lists textures on disk missing from index and entries without
+// backing file, exiting 1 when index and directory differ.
+func runOrphans(e *env, args []string) int {
+	fs := e.newFlagSet("orphans", "<texHeaders.bin>")
+	dir := fs.String("dir", "", "directory scanned for textures (default: index directory)")
+	prefix := fs.String("prefix", "", "path prefix entries were built with")
+	var exts, excludes stringList
+	fs.Var(&exts, "ext", "source extensions, repeatable or comma-separated (default: .paa)")
+	fs.Var(&excludes, "exclude", "glob of paths to skip, repeatable (e.g. **/backup/**)")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("orphans", err)
+	}
+
+	root := *dir
+	if root == "" {
+		root = filepath.Dir(pos[0])
+	}
+
+	cmp, err := texheaders.CompareWithDir(f, root, texheaders.DirCompareOptions{
+		Build: texheaders.BuildOptions{PBOPrefix: *prefix},
+		Dir:   texheaders.DirOptions{Extensions: exts, Exclude: excludes},
+	})
+	if err != nil {
+		return e.fail("orphans", err)
+	}
+
+	rows := [][]string{{"kind", "path", "disk"}}
+	for _, p := range cmp.NotIndexed {
+		rows = append(rows, []string{"not_indexed", p, p})
+	}
+	for _, p := range cmp.Missing {
+		rows = append(rows, []string{"missing", p, ""})
+	}
+	for _, m := range cmp.CaseMismatches {
+		rows = append(rows, []string{"case_mismatch", m.Entry, m.Disk})
+	}
+
+	err = e.render(view{
+		data: cmp,
+		rows: rows,
+		text: func(w io.Writer) error {
+			for _, p := range cmp.NotIndexed {
+				fmt.Fprintf(w, "not indexed: %s\n", p)
+			}
+			for _, p := range cmp.Missing {
+				fmt.Fprintf(w, "missing:     %s\n", p)
+			}
+			for _, m := range cmp.CaseMismatches {
+				fmt.Fprintf(w, "case:        %s (disk: %s)\n", m.Entry, m.Disk)
+			}
+			_, err := fmt.Fprintf(w, "%d not indexed, %d missing, %d case mismatches\n",
+				len(cmp.NotIndexed), len(cmp.Missing), len(cmp.CaseMismatches))
+			return err
+		},
+	})
+	if err != nil {
+		return e.fail("orphans", err)
+	}
+
+	if !cmp.Clean() {
+		return exitFailure
+	}
+
+	return exitOK
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunOrphans(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	index := writeFixture(t, dir, func(f *texheaders.File) {
+		f.Textures = f.Textures[:3]
+		f.Textures[0].PAAFile = `data\rock_co.paa`
+		f.Textures[1].PAAFile = `data\Grass_CO.paa`
+		f.Textures[2].PAAFile = `data\gone_co.paa`
+	})
+
+	src, err := os.ReadFile("../../testdata/test_co.paa")
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	for _, name := range []string{"rock_co.paa", "grass_co.paa", "new_co.paa"} {
+		if err := os.WriteFile(filepath.Join(dir, "data", name), src, 0o600); err != nil {
+			t.Fatalf("WriteFile() error: %v", err)
+		}
+	}
+
+	code, stdout, stderr := runCLI(t, "orphans", index)
+	if code != exitFailure {
+		t.Fatalf("orphans = %d, stderr %q", code, stderr)
+	}
+
+	for _, want := range []string{
+		`not indexed: data\new_co.paa`,
+		`missing:     data\gone_co.paa`,
+		`case:        data\Grass_CO.paa (disk: data\grass_co.paa)`,
+		"1 not indexed, 1 missing, 1 case mismatches",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("orphans stdout missing %q:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, "orphans", index, "--output", "csv", "--exclude", "**/new_co.paa")
+	if code != exitFailure || strings.Contains(stdout, "new_co") || !strings.HasPrefix(stdout, "kind,path,disk\n") {
+		t.Fatalf("orphans --exclude = %d, stdout %q", code, stdout)
+	}
+
+	clean := writeFixture(t, t.TempDir(), func(f *texheaders.File) { f.Textures = nil })
+	if code, stdout, _ := runCLI(t, "orphans", clean, "--dir", t.TempDir()); code != exitOK || !strings.Contains(stdout, "0 not indexed, 0 missing") {
+		t.Fatalf("orphans(clean) = %d, stdout %q", code, stdout)
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// Output formats of --output flag.
+const (
+	// outputTable is human-readable aligned text.
+	outputTable = "table"
+	// outputJSON is indented JSON.
+	outputJSON = "json"
+	// outputYAML is block-style YAML.
+	outputYAML = "yaml"
+	// outputCSV is header row followed by records.
+	outputCSV = "csv"
+)
+
+// outputFormat is --output flag value.
+type outputFormat string
+
+// String implements flag.Value.
+func (o *outputFormat) String() string {
+	return string(*o)
+}
+
+// Set implements flag.Value.
+func (o *outputFormat) Set(v string) error {
+	switch v = strings.ToLower(v); v {
+	case outputTable, outputJSON, outputYAML, outputCSV:
+		*o = outputFormat(v)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (want table, json, yaml or csv)", v)
+	}
+}
+
+// view is command result rendered in --output format.
+type view struct {
+	data any                     // data is encoded for json and yaml output.
+	rows [][]string              // rows is header row followed by records for csv and default table output.
+	text func(w io.Writer) error // text writes table output when rows alone do not fit it.
+}
+
+// outputFlags registers --output and its --json alias bound to env output.
+func (e *env) outputFlags(fs *flag.FlagSet) {
+	fs.Var(&e.output, "output", "output format: table, json, yaml or csv")
+	fs.BoolFunc("json", "alias for --output json", func(string) error {
+		e.output = outputJSON
+		return nil
+	})
+}
+
+// structured reports whether output is encoded data rather than text.
+func (e *env) structured() bool {
+	return e.output == outputJSON || e.output == outputYAML
+}
+
+// render writes v to stdout in selected output format.
+func (e *env) render(v view) error {
+	switch e.output {
+	case outputJSON:
+		return texheaders.EncodeText(e.stdout, v.data, texheaders.TextFormatJSON)
+	case outputYAML:
+		return texheaders.EncodeText(e.stdout, v.data, texheaders.TextFormatYAML)
+	case outputCSV:
+		w := csv.NewWriter(e.stdout)
+		if err := w.WriteAll(v.rows); err != nil {
+			return err
+		}
+		return w.Error()
+	}
+
+	if v.text != nil {
+		return v.text(e.stdout)
+	}
+
+	return writeRows(e.stdout, v.rows)
+}
+
+// writeRows writes rows as aligned table with upper-cased header.
+func writeRows(w io.Writer, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, row := range rows {
+		line := strings.Join(row, "\t")
+		if i == 0 {
+			line = strings.ToUpper(line)
+		}
+		fmt.Fprintln(tw, line)
+	}
+
+	return tw.Flush()
+}
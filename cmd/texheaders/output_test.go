@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestRunOutputFormats(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "--output", "csv", "list", fixturePath)
+	if code != exitOK {
+		t.Fatalf("--output csv list = %d, stderr %q", code, stderr)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll() error: %v", err)
+	}
+
+	if len(records) != 47 || strings.Join(records[0], ",") != "path,format,suffix,width,height,mips,size" {
+		t.Fatalf("--output csv list = %d records, header %q", len(records), records[0])
+	}
+
+	if records[1][0] != "test_as.paa" || records[1][3] != "128" {
+		t.Fatalf("--output csv list first record = %q", records[1])
+	}
+
+	code, stdout, _ = runCLI(t, "stat", fixturePath, "--output", "yaml")
+	if code != exitOK || !strings.Contains(stdout, "\nentries: 46\n") {
+		t.Fatalf("stat --output yaml = %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "--output=json", "suffix", "audit", fixturePath)
+	if code != exitOK || stdout != "[]\n" {
+		t.Fatalf("--output=json suffix audit = %d, stdout %q", code, stdout)
+	}
+
+	if code, stdout, _ := runCLI(t, "--output", "json", "list", "--output", "table", fixturePath); code != exitOK || !strings.HasPrefix(stdout, "PATH ") {
+		t.Fatalf("command --output override = %d, stdout %q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "--output", "xml", "list", fixturePath); code != exitUsage || !strings.Contains(stderr, `unknown output format "xml"`) {
+		t.Fatalf("--output xml = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, _ := runCLI(t, "show", "--output", "xml", fixturePath, "test_co.paa"); code != exitUsage {
+		t.Fatalf("show --output xml = %d, want %d", code, exitUsage)
+	}
+}
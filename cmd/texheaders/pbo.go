@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runPBO dispatches pbo subcommands.
+func runPBO(e *env, args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "extract":
+			return runPBOExtract(e, args[1:])
+		case "inject":
+			return runPBOInject(e, args[1:])
+		case "-h", "-help", "--help":
+			e.pboUsage()
+			return exitOK
+		}
+	}
+
+	e.pboUsage()
+	return exitUsage
+}
+
+// pboUsage prints pbo subcommands.
+func (e *env) pboUsage() {
+	fmt.Fprintln(e.stderr, "Usage: texheaders pbo <extract|inject> [flags] [args]")
+	fmt.Fprintln(e.stderr)
+	fmt.Fprintln(e.stderr, "  extract <addon.pbo>                   write texHeaders.bin stored in archive")
+	fmt.Fprintln(e.stderr, "  inject <addon.pbo> <texHeaders.bin>   replace or add texHeaders.bin in archive")
+}
+
+// runPBOExtract writes index stored in PBO archive.
+func runPBOExtract(e *env, args []string) int {
+	fs := e.newFlagSet("pbo extract", "<addon.pbo>")
+	output := fs.String("o", texheaders.DefaultOutputName, `output path, "-" for stdout`)
+	to := fs.String("to", "", "output format: bin, json or yaml (default: by extension)")
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	format, err := resolveFormat(*output, *to)
+	if err != nil {
+		return e.fail("pbo extract", err)
+	}
+
+	f, err := texheaders.ReadPBOIndex(pos[0])
+	if err != nil {
+		return e.fail("pbo extract", err)
+	}
+
+	if err := e.writeIndex(*output, format, f); err != nil {
+		return e.fail("pbo extract", err)
+	}
+
+	return exitOK
+}
+
+// runPBOInject stores index into PBO archive without repacking other entries.
+func runPBOInject(e *env, args []string) int {
+	fs := e.newFlagSet("pbo inject", "<addon.pbo> <texHeaders.bin>")
+	output := fs.String("o", "", "output archive path (default: rewrite archive in place)")
+	from := fs.String("from", "", "index format: bin, json or yaml (default: by extension)")
+	pos, code, ok := e.parse(fs, args, 2, 2)
+	if !ok {
+		return code
+	}
+
+	format, err := resolveFormat(pos[1], *from)
+	if err != nil {
+		return e.fail("pbo inject", err)
+	}
+
+	f, err := e.readIndex(pos[1], format)
+	if err != nil {
+		return e.fail("pbo inject", err)
+	}
+
+	out := *output
+	if out == "" {
+		out = pos[0]
+	}
+
+	if err := texheaders.InjectPBOIndex(pos[0], out, f); err != nil {
+		return e.fail("pbo inject", err)
+	}
+
+	return exitOK
+}
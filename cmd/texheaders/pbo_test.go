@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// writeEmptyPBO writes archive with version header, prefix property and no
+// entries.
+func writeEmptyPBO(t *testing.T, path string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteByte(0)
+	buf.Write([]byte{'s', 'r', 'e', 'V'})
+	buf.Write(make([]byte, 16))
+	buf.WriteString("prefix\x00addon\x00\x00")
+	buf.Write(make([]byte, 21))
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+}
+
+func TestRunPBO(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	writeEmptyPBO(t, pboPath)
+
+	if code, _, stderr := runCLI(t, "pbo", "extract", pboPath, "-o", "-", "--to", "bin"); code != exitFailure || !strings.Contains(stderr, "no texHeaders.bin") {
+		t.Fatalf("pbo extract(empty) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr := runCLI(t, "pbo", "inject", pboPath, fixturePath); code != exitOK {
+		t.Fatalf("pbo inject = %d, stderr %q", code, stderr)
+	}
+
+	out := filepath.Join(dir, "index.json")
+	if code, _, stderr := runCLI(t, "pbo", "extract", pboPath, "-o", out); code != exitOK {
+		t.Fatalf("pbo extract = %d, stderr %q", code, stderr)
+	}
+
+	fh, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer func() { _ = fh.Close() }()
+
+	got, err := texheaders.Import(fh, texheaders.TextFormatJSON)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+
+	want, err := texheaders.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	if !texheaders.EqualFiles(got, want, texheaders.EqualOptions{}) {
+		t.Fatalf("extracted index differs from injected fixture")
+	}
+
+	if code, _, _ := runCLI(t, "pbo"); code != exitUsage {
+		t.Fatalf("pbo = %d, want %d", code, exitUsage)
+	}
+}
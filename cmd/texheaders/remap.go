@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runRemap rewrites entry paths by prefix substitution and normalization.
+func runRemap(e *env, args []string) int {
+	fs := e.newFlagSet("remap", "<texHeaders.bin>")
+	var from, to stringList
+	fs.Var(&from, "from", "path prefix to replace, repeatable; pairs with --to in order")
+	fs.Var(&to, "to", "replacement prefix, repeatable")
+	canonical := fs.Bool("canonical", false, "lowercase paths and use backslash separators")
+	output := fs.String("o", "", "output path (default: rewrite index in place)")
+	dryRun := fs.Bool("dry-run", false, "print changed paths without writing")
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	if len(from) != len(to) {
+		fmt.Fprintln(e.stderr, "texheaders remap: --from and --to must be given the same number of times")
+		return exitUsage
+	}
+
+	if len(from) == 0 && !*canonical {
+		fmt.Fprintln(e.stderr, "texheaders remap: nothing to do, set --from/--to or --canonical")
+		return exitUsage
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("remap", err)
+	}
+
+	rewriters := make([]texheaders.PathRewriter, 0, len(from)+1)
+	for i := range from {
+		rewriters = append(rewriters, texheaders.ReplacePathPrefix(from[i], to[i]))
+	}
+	if *canonical {
+		rewriters = append(rewriters, texheaders.CanonicalPath)
+	}
+
+	before := make([]string, len(f.Textures))
+	for i := range f.Textures {
+		before[i] = f.Textures[i].PAAFile
+	}
+
+	if err := f.RewritePaths(texheaders.ChainRewriters(rewriters...)); err != nil {
+		return e.fail("remap", err)
+	}
+
+	changed := 0
+	for i := range f.Textures {
+		if f.Textures[i].PAAFile != before[i] {
+			changed++
+			if *dryRun {
+				fmt.Fprintf(e.stdout, "%s -> %s\n", before[i], f.Textures[i].PAAFile)
+			}
+		}
+	}
+	fmt.Fprintf(e.stdout, "%d of %d paths changed\n", changed, len(f.Textures))
+
+	if *dryRun {
+		return exitOK
+	}
+
+	out := *output
+	if out == "" {
+		out = pos[0]
+	}
+
+	if err := texheaders.WriteFile(out, f); err != nil {
+		return e.fail("remap", err)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunRemap(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	index := writeFixture(t, dir, func(f *texheaders.File) {
+		f.Textures[0].PAAFile = `OldMod\Data\Rock_CO.paa`
+		f.Textures[1].PAAFile = `oldmod/data/rock_nohq.paa`
+		f.Textures[2].PAAFile = `newmod\rock_co.paa`
+	})
+
+	code, stdout, _ := runCLI(t, "remap", index, "--from", `oldmod\`, "--to", `newmod\`, "--dry-run")
+	if code != exitOK || !strings.Contains(stdout, `OldMod\Data\Rock_CO.paa -> newmod\Data\Rock_CO.paa`) || !strings.Contains(stdout, "2 of 46 paths changed") {
+		t.Fatalf("remap --dry-run = %d, stdout %q", code, stdout)
+	}
+
+	out := filepath.Join(dir, "out.bin")
+	if code, _, stderr := runCLI(t, "remap", index, "--from", "oldmod", "--to", "newmod", "--canonical", "-o", out); code != exitOK {
+		t.Fatalf("remap = %d, stderr %q", code, stderr)
+	}
+
+	f, err := texheaders.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	if f.Textures[0].PAAFile != `newmod\data\rock_co.paa` || f.Textures[1].PAAFile != `newmod\data\rock_nohq.paa` {
+		t.Fatalf("remapped paths = %q, %q", f.Textures[0].PAAFile, f.Textures[1].PAAFile)
+	}
+
+	if code, _, stderr := runCLI(t, "remap", index, "--from", `oldmod\data`, "--to", "newmod"); code != exitFailure || !strings.Contains(stderr, "already exists") {
+		t.Fatalf("remap(collision) = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, _ := runCLI(t, "remap", index, "--from", "a"); code != exitUsage {
+		t.Fatalf("remap(unpaired) = %d, want %d", code, exitUsage)
+	}
+
+	if code, _, _ := runCLI(t, "remap", index); code != exitUsage {
+		t.Fatalf("remap(no-op) = %d, want %d", code, exitUsage)
+	}
+}
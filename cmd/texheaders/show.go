@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runShow prints all decoded fields of one entry found by path.
+func runShow(e *env, args []string) int {
+	fs := e.newFlagSet("show", "<texHeaders.bin> <entry path>")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 2, 2)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("show", err)
+	}
+
+	entry, found := f.FindByPath(pos[1])
+	if !found {
+		return e.fail("show", fmt.Errorf("%w: %q", texheaders.ErrEntryNotFound, pos[1]))
+	}
+
+	fields := entryFields(entry)
+	rows := append([][]string{{"field", "value"}}, fields...)
+	err = e.render(view{
+		data: entry,
+		rows: rows,
+		text: func(w io.Writer) error { return writeEntry(w, entry, fields) },
+	})
+	if err != nil {
+		return e.fail("show", err)
+	}
+
+	return exitOK
+}
+
+// entryFields returns field name and formatted value pairs of entry.
+func entryFields(entry *texheaders.TextureEntry) [][]string {
+	fields := []struct {
+		name  string
+		value any
+	}{
+		{"paa_file", entry.PAAFile},
+		{"pax_format", entry.PaxFormat},
+		{"pax_suffix_type", entry.PaxSuffixType},
+		{"pax_file_size", entry.PaxFileSize},
+		{"is_paa", entry.IsPAA},
+		{"little_endian", entry.LittleEndian},
+		{"mipmap_count", entry.MipMapCount},
+		{"mipmap_count_copy", entry.MipMapCountCopy},
+		{"color_palette_count", entry.ColorPaletteCount},
+		{"palette_ptr", entry.PalettePtr},
+		{"average_color_f", fmt.Sprintf("%g", entry.AverageColorF)},
+		{"average_color", fmt.Sprintf("% X", entry.AverageColor[:])},
+		{"max_color", fmt.Sprintf("% X", entry.MaxColor[:])},
+		{"has_max_ctagg", entry.HasMaxCtagg},
+		{"is_alpha", entry.IsAlpha},
+		{"is_transparent", entry.IsTransparent},
+		{"is_alpha_non_opaque", entry.IsAlphaNonOpaque},
+		{"clamp_flags", fmt.Sprintf("0x%08X", entry.ClampFlags)},
+		{"transparent_color", fmt.Sprintf("0x%08X", entry.TransparentColor)},
+	}
+
+	out := make([][]string, 0, len(fields))
+	for _, fld := range fields {
+		out = append(out, []string{fld.name, fmt.Sprint(fld.value)})
+	}
+
+	return out
+}
+
+// writeEntry prints entry fields and mip table.
+func writeEntry(w io.Writer, entry *texheaders.TextureEntry, fields [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, fld := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", fld[0], fld[1])
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "MIP\tWIDTH\tHEIGHT\tFORMAT\tALWAYS_ZERO\tALWAYS_THREE\tDATA_OFFSET")
+	for i, m := range entry.MipMaps {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%d\t%d\t%d\n",
+			i, m.Width, m.Height, texheaders.PaxFormat(m.PaxFormat), m.AlwaysZero, m.AlwaysThree, m.DataOffset)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunShow(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "show", fixturePath, "TEST_NOHQ.paa")
+	if code != exitOK {
+		t.Fatalf("show = %d, stderr %q", code, stderr)
+	}
+
+	for _, want := range []string{"paa_file:", "test_nohq.paa", "pax_suffix_type:", "normal_map", "transparent_color:", "MIP", "DATA_OFFSET"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("show output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, "show", "--json", fixturePath, "test_nohq.paa")
+	if code != exitOK {
+		t.Fatalf("show --json = %d", code)
+	}
+
+	var entry texheaders.TextureEntry
+	if err := json.Unmarshal([]byte(stdout), &entry); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if entry.PAAFile != "test_nohq.paa" || len(entry.MipMaps) == 0 {
+		t.Fatalf("show --json = %+v", entry)
+	}
+
+	if code, _, stderr := runCLI(t, "show", fixturePath, "missing.paa"); code != exitFailure || !strings.Contains(stderr, "texture entry not found") {
+		t.Fatalf("show(missing) = %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runStat prints aggregate statistics of index file.
+func runStat(e *env, args []string) int {
+	fs := e.newFlagSet("stat", "<texHeaders.bin>")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("stat", err)
+	}
+
+	st := f.Stats()
+	err = e.render(view{
+		data: st,
+		rows: statRows(&st),
+		text: func(w io.Writer) error { return writeStats(w, &st) },
+	})
+	if err != nil {
+		return e.fail("stat", err)
+	}
+
+	return exitOK
+}
+
+// writeStats prints totals, ranges and per-key counts as aligned tables.
+func writeStats(w io.Writer, st *texheaders.FileStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Entries:\t%d\n", st.Entries)
+	fmt.Fprintf(tw, "Total pax bytes:\t%d\n", st.TotalFileSize)
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "METRIC\tMIN\tMAX\tAVG")
+	for _, m := range []struct {
+		name string
+		r    texheaders.RangeStats
+	}{{"width", st.Width}, {"height", st.Height}, {"mipmaps", st.MipMaps}} {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\n", m.name, m.r.Min, m.r.Max, m.r.Avg)
+	}
+
+	writeCounts(tw, "FORMAT", st.ByPaxFormat)
+	writeCounts(tw, "SUFFIX", st.BySuffixType)
+	writeCounts(tw, "DIMENSIONS", st.ByDimensions)
+	return tw.Flush()
+}
+
+// statRows returns statistics as section, key and value records.
+func statRows(st *texheaders.FileStats) [][]string {
+	rows := [][]string{
+		{"section", "key", "value"},
+		{"total", "entries", strconv.Itoa(st.Entries)},
+		{"total", "pax_bytes", fmt.Sprint(st.TotalFileSize)},
+	}
+
+	for _, m := range []struct {
+		name string
+		r    texheaders.RangeStats
+	}{{"width", st.Width}, {"height", st.Height}, {"mipmaps", st.MipMaps}} {
+		rows = append(rows,
+			[]string{m.name, "min", strconv.Itoa(m.r.Min)},
+			[]string{m.name, "max", strconv.Itoa(m.r.Max)},
+			[]string{m.name, "avg", strconv.FormatFloat(m.r.Avg, 'f', 1, 64)},
+		)
+	}
+
+	rows = appendCountRows(rows, "format", st.ByPaxFormat)
+	rows = appendCountRows(rows, "suffix", st.BySuffixType)
+	return appendCountRows(rows, "dimensions", st.ByDimensions)
+}
+
+// appendCountRows appends one record per count in writeCounts order.
+func appendCountRows[K comparable](rows [][]string, section string, counts map[K]int) [][]string {
+	for _, k := range sortedCountKeys(counts) {
+		rows = append(rows, []string{section, fmt.Sprint(k), strconv.Itoa(counts[k])})
+	}
+
+	return rows
+}
+
+// writeCounts prints count table sorted by count descending, then key.
+func writeCounts[K comparable](w io.Writer, title string, counts map[K]int) {
+	fmt.Fprintf(w, "\n%s\tCOUNT\n", title)
+	for _, k := range sortedCountKeys(counts) {
+		fmt.Fprintf(w, "%v\t%d\n", k, counts[k])
+	}
+}
+
+// sortedCountKeys returns keys sorted by count descending, then key.
+func sortedCountKeys[K comparable](counts map[K]int) []K {
+	return slices.SortedFunc(maps.Keys(counts), func(a, b K) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunStat(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "stat", fixturePath)
+	if code != exitOK {
+		t.Fatalf("stat = %d, stderr %q", code, stderr)
+	}
+
+	for _, want := range []string{"Entries:", "Total pax bytes:", "METRIC", "FORMAT", "DXT5", "SUFFIX", "normal_map", "DIMENSIONS", "128x128"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("stat output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, "stat", "--json", fixturePath)
+	if code != exitOK {
+		t.Fatalf("stat --json = %d", code)
+	}
+
+	var st texheaders.FileStats
+	if err := json.Unmarshal([]byte(stdout), &st); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if st.Entries == 0 || st.ByPaxFormat[texheaders.PaxFormatDXT5] == 0 || len(st.ByDimensions) == 0 {
+		t.Fatalf("stat --json = %+v", st)
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runSuffix dispatches suffix subcommands.
+func runSuffix(e *env, args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "guess":
+			return runSuffixGuess(e, args[1:])
+		case "audit":
+			return runSuffixAudit(e, args[1:])
+		case "-h", "-help", "--help":
+			e.suffixUsage()
+			return exitOK
+		}
+	}
+
+	e.suffixUsage()
+	return exitUsage
+}
+
+// suffixUsage prints suffix subcommands.
+func (e *env) suffixUsage() {
+	fmt.Fprintln(e.stderr, "Usage: texheaders suffix <guess|audit> [flags] [args]")
+	fmt.Fprintln(e.stderr)
+	fmt.Fprintln(e.stderr, "  guess <path>...          infer suffix type of texture paths")
+	fmt.Fprintln(e.stderr, "  audit <texHeaders.bin>   list entries whose suffix type disagrees with inference")
+}
+
+// runSuffixGuess prints inferred suffix type and matched rule per path.
+func runSuffixGuess(e *env, args []string) int {
+	fs := e.newFlagSet("suffix guess", "<path>...")
+	rulesPath := suffixRulesFlag(fs)
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, -1)
+	if !ok {
+		return code
+	}
+
+	rs, err := loadSuffixRules(*rulesPath)
+	if err != nil {
+		return e.fail("suffix guess", err)
+	}
+
+	type guess struct {
+		Path string `json:"path"`
+		texheaders.SuffixMatch
+	}
+	out := make([]guess, 0, len(pos))
+	for _, p := range pos {
+		out = append(out, guess{Path: p, SuffixMatch: rs.Match(p)})
+	}
+
+	rows := [][]string{{"path", "suffix", "rule"}}
+	for _, g := range out {
+		rows = append(rows, []string{g.Path, g.Type.String(), describeMatch(g.Rule, g.DirRule)})
+	}
+
+	if err := e.render(view{data: out, rows: rows}); err != nil {
+		return e.fail("suffix guess", err)
+	}
+
+	return exitOK
+}
+
+// runSuffixAudit lists index entries whose stored suffix type differs from
+// inference and exits 1 when any is found.
+func runSuffixAudit(e *env, args []string) int {
+	fs := e.newFlagSet("suffix audit", "<texHeaders.bin>")
+	rulesPath := suffixRulesFlag(fs)
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	rs, err := loadSuffixRules(*rulesPath)
+	if err != nil {
+		return e.fail("suffix audit", err)
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("suffix audit", err)
+	}
+
+	mismatches := texheaders.AuditSuffixes(f, rs)
+	if mismatches == nil {
+		mismatches = []texheaders.SuffixMismatch{}
+	}
+
+	rows := [][]string{{"entry", "path", "stored", "guessed", "rule"}}
+	for _, m := range mismatches {
+		rows = append(rows, []string{
+			strconv.Itoa(m.Entry), m.Path, m.Stored.String(), m.Guessed.String(), describeMatch(m.Rule, m.DirRule),
+		})
+	}
+
+	err = e.render(view{
+		data: mismatches,
+		rows: rows,
+		text: func(w io.Writer) error {
+			if err := writeRows(w, rows); err != nil {
+				return err
+			}
+			_, err := fmt.Fprintf(w, "%d of %d entries mismatched\n", len(mismatches), len(f.Textures))
+			return err
+		},
+	})
+	if err != nil {
+		return e.fail("suffix audit", err)
+	}
+
+	if len(mismatches) > 0 {
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// suffixRulesFlag registers --rules flag.
+func suffixRulesFlag(fs *flag.FlagSet) *string {
+	return fs.String("rules", "", "suffix rules config (JSON or YAML); default: built-in rules")
+}
+
+// loadSuffixRules returns rules from config path or built-in rules when empty.
+func loadSuffixRules(path string) (*texheaders.SuffixRuleset, error) {
+	if path == "" {
+		return texheaders.DefaultSuffixRuleset(), nil
+	}
+
+	return texheaders.LoadSuffixRules(path)
+}
+
+// describeMatch formats matched rule for tables.
+func describeMatch(rule *texheaders.SuffixRule, dir *texheaders.SuffixDirRule) string {
+	switch {
+	case dir != nil:
+		return "dir " + dir.Dir
+	case rule != nil:
+		return "token " + rule.Token
+	default:
+		return "fallback"
+	}
+}
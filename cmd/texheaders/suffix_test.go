@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunSuffixGuess(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "suffix", "guess", `data\rock_nohq.paa`, "plain.paa")
+	if code != exitOK {
+		t.Fatalf("suffix guess = %d, stderr %q", code, stderr)
+	}
+
+	if !strings.Contains(stdout, "normal_map") || !strings.Contains(stdout, "token _nohq") || !strings.Contains(stdout, "fallback") {
+		t.Fatalf("suffix guess output:\n%s", stdout)
+	}
+
+	rules := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(rules, []byte("dirs:\n  - dir: detail\n    type: detail_linear\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	code, stdout, _ = runCLI(t, "suffix", "guess", "--json", "--rules", rules, "detail/rock_co.paa")
+	if code != exitOK {
+		t.Fatalf("suffix guess --json = %d", code)
+	}
+
+	var got []struct {
+		texheaders.SuffixMatch
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Type != texheaders.SuffixDetailLinear || got[0].DirRule == nil {
+		t.Fatalf("suffix guess --json = %+v", got)
+	}
+
+	if code, _, _ := runCLI(t, "suffix"); code != exitUsage {
+		t.Fatalf("suffix without subcommand = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRunSuffixAudit(t *testing.T) {
+	t.Parallel()
+
+	if code, stdout, _ := runCLI(t, "suffix", "audit", fixturePath); code != exitOK || !strings.Contains(stdout, "0 of 46 entries mismatched") {
+		t.Fatalf("suffix audit(fixture) = %d, stdout %q", code, stdout)
+	}
+
+	bad := writeFixture(t, t.TempDir(), func(f *texheaders.File) {
+		f.Textures[0].PaxSuffixType = texheaders.SuffixNormalMap
+	})
+	code, stdout, _ := runCLI(t, "suffix", "audit", bad)
+	if code != exitFailure || !strings.Contains(stdout, "test_as.paa") || !strings.Contains(stdout, "token _as") {
+		t.Fatalf("suffix audit(bad) = %d, stdout %q", code, stdout)
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runUpdate rescans textures under base directory and rewrites index,
+// reusing entries whose source is unchanged.
+func runUpdate(e *env, args []string) int {
+	fs := e.newFlagSet("update", "<texHeaders.bin>")
+	baseDir := fs.String("base-dir", "", "directory scanned for textures (default: index directory)")
+	output := fs.String("o", "", "output path (default: rewrite index in place)")
+	rulesPath := suffixRulesFlag(fs)
+	skipInvalid := fs.Bool("skip-invalid", false, "skip unreadable textures instead of failing")
+	workers := fs.Int("workers", 0, "parallel scans (0: auto)")
+	dryRun := fs.Bool("dry-run", false, "print changes without writing")
+	var exts, excludes stringList
+	fs.Var(&exts, "ext", "source extensions, repeatable or comma-separated (default: .paa)")
+	fs.Var(&excludes, "exclude", "glob of paths to skip, repeatable (e.g. **/backup/**)")
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	existing, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("update", err)
+	}
+
+	rs, err := loadSuffixRules(*rulesPath)
+	if err != nil {
+		return e.fail("update", err)
+	}
+
+	dir := *baseDir
+	if dir == "" {
+		dir = filepath.Dir(pos[0])
+	}
+
+	b := texheaders.NewBuilder(texheaders.BuildOptions{
+		BaseDir:     dir,
+		SuffixRules: rs,
+		SkipInvalid: *skipInvalid,
+		Workers:     *workers,
+	})
+	if err := b.AppendDir(dir, texheaders.DirOptions{Extensions: exts, Exclude: excludes}); err != nil {
+		return e.fail("update", err)
+	}
+
+	f, err := b.Update(existing)
+	if err != nil {
+		return e.fail("update", err)
+	}
+
+	for _, is := range b.Issues() {
+		kind := "skipped"
+		if is.Warning {
+			kind = "warning"
+		}
+		fmt.Fprintf(e.stderr, "texheaders update: %s %s: %s\n", kind, is.Path, is.Error)
+	}
+
+	d := texheaders.Diff(existing, f)
+	unchanged := len(f.Textures) - len(d.Added) - len(d.Changed)
+	fmt.Fprintf(e.stdout, "added %d, changed %d, removed %d, unchanged %d\n",
+		len(d.Added), len(d.Changed), len(d.Removed), unchanged)
+
+	if *dryRun {
+		return exitOK
+	}
+
+	out := *output
+	if out == "" {
+		out = pos[0]
+	}
+
+	if err := texheaders.WriteFile(out, f); err != nil {
+		return e.fail("update", err)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunUpdate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	index := writeFixture(t, dir, nil)
+	src, err := os.ReadFile("../../testdata/test_co.paa")
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	for _, name := range []string{"test_co.paa", "rock_nohq.paa"} {
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o600); err != nil {
+			t.Fatalf("WriteFile() error: %v", err)
+		}
+	}
+
+	code, stdout, stderr := runCLI(t, "update", "--dry-run", index)
+	if code != exitOK || stdout != "added 1, changed 0, removed 45, unchanged 1\n" {
+		t.Fatalf("update --dry-run = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	if f, err := texheaders.ReadFile(index); err != nil || len(f.Textures) != 46 {
+		t.Fatalf("dry run rewrote index: %v", err)
+	}
+
+	out := filepath.Join(dir, "out.bin")
+	if code, _, stderr := runCLI(t, "update", index, "-o", out); code != exitOK {
+		t.Fatalf("update -o = %d, stderr %q", code, stderr)
+	}
+
+	f, err := texheaders.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile(out) error: %v", err)
+	}
+
+	if len(f.Textures) != 2 {
+		t.Fatalf("updated entries = %d, want 2", len(f.Textures))
+	}
+
+	if e, ok := f.FindByPath("rock_nohq.paa"); !ok || e.PaxSuffixType != texheaders.SuffixNormalMap {
+		t.Fatalf("new entry = %+v, %v", e, ok)
+	}
+
+	if code, stdout, _ := runCLI(t, "update", out, "--base-dir", dir); code != exitOK || stdout != "added 0, changed 0, removed 0, unchanged 2\n" {
+		t.Fatalf("update in place = %d, stdout %q", code, stdout)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// Exit codes of validate command.
+const (
+	// exitWarnings means validation found warnings only.
+	exitWarnings = 1
+	// exitErrors means validation found errors or could not run.
+	exitErrors = 2
+	// exitValidateUsage means invalid command line; global exitUsage would
+	// read as exitErrors.
+	exitValidateUsage = 3
+)
+
+// runValidate validates index file and exits 0/1/2/3 for
+// ok/warnings/errors/usage.
+func runValidate(e *env, args []string) int {
+	fs := e.newFlagSet("validate", "<texHeaders.bin>")
+	deep := fs.Bool("deep", false, "also verify entries against source textures")
+	baseDir := fs.String("base-dir", "", "directory entry paths resolve under for --deep (default: index directory)")
+	profile := fs.String("profile", "", "engine profile: dayz, arma3 or legacy")
+	strict := fs.Bool("strict", false, "report every issue as error")
+	workers := fs.Int("workers", 0, "parallel source checks for --deep (0: auto)")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		if code == exitUsage {
+			return exitValidateUsage
+		}
+
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		e.fail("validate", err)
+		return exitErrors
+	}
+
+	opts := texheaders.ValidateOptions{Profile: *profile}
+	if *strict {
+		opts.Strictness = texheaders.StrictnessStrict
+	}
+
+	rep := texheaders.ValidateFileWithOptions(f, opts)
+	if *deep {
+		dir := *baseDir
+		if dir == "" {
+			dir = filepath.Dir(pos[0])
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		rep, err = texheaders.ValidateDeepContext(ctx, f, texheaders.DeepValidateOptions{
+			BaseDir:  dir,
+			Validate: opts,
+			Workers:  *workers,
+		})
+		if err != nil {
+			e.fail("validate", err)
+			return exitErrors
+		}
+	}
+
+	err = e.render(view{
+		data: rep,
+		rows: validationRows(rep),
+		text: func(w io.Writer) error { return writeValidationReport(w, rep) },
+	})
+	if err != nil {
+		e.fail("validate", err)
+		return exitErrors
+	}
+
+	return validationExitCode(rep)
+}
+
+// writeValidationReport prints one line per issue and a summary line.
+func writeValidationReport(w io.Writer, rep *texheaders.ValidationReport) error {
+	for _, is := range rep.Issues {
+		where := "file"
+		if is.Entry >= 0 {
+			where = fmt.Sprintf("#%d %s", is.Entry, is.Path)
+		}
+		if is.Field != "" {
+			where += " " + is.Field
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s: %s\n", is.Severity, is.Code, where, is.Message)
+	}
+
+	errs, warns := len(rep.Errors()), len(rep.Warnings())
+	if errs == 0 && warns == 0 {
+		_, err := fmt.Fprintln(w, "ok")
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s)\n", errs, warns)
+	return err
+}
+
+// validationRows returns one record per issue; entry is empty for
+// file-level issues.
+func validationRows(rep *texheaders.ValidationReport) [][]string {
+	rows := [][]string{{"severity", "code", "entry", "path", "field", "message"}}
+	for _, is := range rep.Issues {
+		entry := ""
+		if is.Entry >= 0 {
+			entry = strconv.Itoa(is.Entry)
+		}
+
+		rows = append(rows, []string{string(is.Severity), string(is.Code), entry, is.Path, is.Field, is.Message})
+	}
+
+	return rows
+}
+
+// validationExitCode maps report to exit code.
+func validationExitCode(rep *texheaders.ValidationReport) int {
+	switch {
+	case rep.HasErrors():
+		return exitErrors
+	case len(rep.Warnings()) > 0:
+		return exitWarnings
+	default:
+		return exitOK
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunValidate(t *testing.T) {
+	t.Parallel()
+
+	if code, stdout, _ := runCLI(t, "validate", fixturePath); code != exitOK || strings.TrimSpace(stdout) != "ok" {
+		t.Fatalf("validate(fixture) = %d, stdout %q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "validate", fixturePath, "--deep"); code != exitOK {
+		t.Fatalf("validate(fixture --deep) = %d, stderr %q", code, stderr)
+	}
+
+	warn := writeFixture(t, t.TempDir(), func(f *texheaders.File) {
+		f.Textures[0].PaxSuffixType = texheaders.SuffixNormalMap
+	})
+	code, stdout, _ := runCLI(t, "validate", warn)
+	if code != exitWarnings || !strings.Contains(stdout, "suffix_mismatch") || !strings.Contains(stdout, "0 error(s), 1 warning(s)") {
+		t.Fatalf("validate(warning) = %d, stdout %q", code, stdout)
+	}
+
+	for _, args := range [][]string{{"validate"}, {"validate", "--bogus", warn}, {"validate", warn, warn}} {
+		if code, _, _ := runCLI(t, args...); code != exitValidateUsage {
+			t.Fatalf("%v = %d, want %d", args, code, exitValidateUsage)
+		}
+	}
+
+	if code, _, _ := runCLI(t, "validate", "--help"); code != exitOK {
+		t.Fatalf("validate --help = %d, want %d", code, exitOK)
+	}
+
+	if code, _, _ := runCLI(t, "validate", "--strict", warn); code != exitErrors {
+		t.Fatalf("validate(--strict) = %d, want %d", code, exitErrors)
+	}
+
+	dup := writeFixture(t, t.TempDir(), func(f *texheaders.File) {
+		f.Textures[1].PAAFile = f.Textures[0].PAAFile
+	})
+	if code, _, _ := runCLI(t, "validate", dup); code != exitWarnings {
+		t.Fatalf("validate(duplicate) = %d, want %d", code, exitWarnings)
+	}
+
+	broken := writeFixture(t, t.TempDir(), func(f *texheaders.File) {
+		f.Textures[0].MipMaps[0].AlwaysZero = 1
+	})
+	if code, _, _ := runCLI(t, "validate", broken); code != exitErrors {
+		t.Fatalf("validate(always_zero) = %d, want %d", code, exitErrors)
+	}
+
+	missing := writeFixture(t, t.TempDir(), nil)
+	code, stdout, _ = runCLI(t, "validate", "--deep", "--json", missing)
+	if code != exitErrors {
+		t.Fatalf("validate(--deep missing sources) = %d, want %d", code, exitErrors)
+	}
+
+	var rep texheaders.ValidationReport
+	if err := json.Unmarshal([]byte(stdout), &rep); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if rep.Counts()["source_missing"] == 0 {
+		t.Fatalf("report counts = %v, want source_missing", rep.Counts())
+	}
+
+	if code, _, _ := runCLI(t, "validate", "missing.bin"); code != exitErrors {
+		t.Fatalf("validate(unreadable) = %d, want %d", code, exitErrors)
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/woozymasta/texheaders"
+)
+
+// runVerify compares index entries with source textures and exits 1 when
+// any entry is missing, unreadable or stale.
+func runVerify(e *env, args []string) int {
+	fs := e.newFlagSet("verify", "<texHeaders.bin>")
+	baseDir := fs.String("base-dir", "", "directory entry paths resolve under (default: index directory)")
+	ignoreFlags := fs.Bool("ignore-flags", false, "skip alpha flag comparison")
+	ignoreColors := fs.Bool("ignore-colors", false, "skip average and max color comparison")
+	e.outputFlags(fs)
+	pos, code, ok := e.parse(fs, args, 1, 1)
+	if !ok {
+		return code
+	}
+
+	f, err := texheaders.ReadFile(pos[0])
+	if err != nil {
+		return e.fail("verify", err)
+	}
+
+	dir := *baseDir
+	if dir == "" {
+		dir = filepath.Dir(pos[0])
+	}
+
+	rep, err := texheaders.VerifyAgainstSources(f, dir, texheaders.VerifyOptions{
+		IgnoreFlags:  *ignoreFlags,
+		IgnoreColors: *ignoreColors,
+	})
+	if err != nil {
+		return e.fail("verify", err)
+	}
+
+	rows := [][]string{{"entry", "path", "detail"}}
+	for _, is := range rep.Issues {
+		detail := is.Error
+		if detail == "" {
+			detail = strings.Join(is.Diffs, "; ")
+		}
+		rows = append(rows, []string{strconv.Itoa(is.Entry), is.Path, detail})
+	}
+
+	err = e.render(view{
+		data: rep,
+		rows: rows,
+		text: func(w io.Writer) error {
+			for _, r := range rows[1:] {
+				fmt.Fprintf(w, "#%s %s: %s\n", r[0], r[1], r[2])
+			}
+			_, err := fmt.Fprintf(w, "%d checked, %d failed\n", rep.Checked, len(rep.Issues))
+			return err
+		},
+	})
+	if err != nil {
+		return e.fail("verify", err)
+	}
+
+	if rep.Stale() {
+		return exitFailure
+	}
+
+	return exitOK
+}
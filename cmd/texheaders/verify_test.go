@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/texheaders"
+)
+
+func TestRunVerify(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, stderr := runCLI(t, "verify", fixturePath)
+	if code != exitOK || !strings.Contains(stdout, "0 failed") {
+		t.Fatalf("verify(fixture) = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	stale := writeFixture(t, t.TempDir(), func(f *texheaders.File) {
+		f.Textures[0].PaxFileSize++
+	})
+	code, stdout, _ = runCLI(t, "verify", stale, "--base-dir", "../../testdata")
+	if code != exitFailure || !strings.Contains(stdout, "#0 test_as.paa: pax_file_size") || !strings.Contains(stdout, "1 failed") {
+		t.Fatalf("verify(stale) = %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "verify", "--json", stale)
+	if code != exitFailure {
+		t.Fatalf("verify(missing sources) = %d, want %d", code, exitFailure)
+	}
+
+	var rep texheaders.VerifyReport
+	if err := json.Unmarshal([]byte(stdout), &rep); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if rep.Checked == 0 || len(rep.Issues) != rep.Checked || rep.Issues[0].Error == "" {
+		t.Fatalf("verify report = %+v", rep)
+	}
+}
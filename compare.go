@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+// DirCompareOptions configures CompareWithDir.
+type DirCompareOptions struct {
+	// Build holds path options the index was built with (BaseDir, PBOPrefix,
+	// PathRemap, BackslashPaths). BaseDir defaults to compared directory;
+	// LowercasePaths is ignored, case is compared separately.
+	Build BuildOptions `json:"build" yaml:"build"`
+	// Dir controls which files are collected from directory.
+	Dir DirOptions `json:"dir" yaml:"dir"`
+}
+
+// CaseMismatch pairs index entry path with on-disk path differing only in case.
+type CaseMismatch struct {
+	// Entry is the stored entry path.
+	Entry string `json:"entry" yaml:"entry"`
+	// Disk is the on-disk path in stored form.
+	Disk string `json:"disk" yaml:"disk"`
+}
+
+// DirComparison is the result of CompareWithDir. All paths are in stored form.
+type DirComparison struct {
+	// NotIndexed lists textures on disk with no index entry.
+	NotIndexed []string `json:"not_indexed,omitempty" yaml:"not_indexed,omitempty"`
+	// Missing lists index entries with no backing file.
+	Missing []string `json:"missing,omitempty" yaml:"missing,omitempty"`
+	// CaseMismatches lists entries whose backing file differs only in case.
+	CaseMismatches []CaseMismatch `json:"case_mismatches,omitempty" yaml:"case_mismatches,omitempty"`
+}
+
+// Clean reports whether index and directory match exactly.
+func (c *DirComparison) Clean() bool {
+	return len(c.NotIndexed) == 0 && len(c.Missing) == 0 && len(c.CaseMismatches) == 0
+}
+
+// CompareWithDir scans dir the way AppendDir does and compares found
+// textures with entries of f: files on disk missing from the index, entries
+// without backing file and pairs differing only in case. Separators are
+// always ignored. Results keep disk scan and file order.
+func CompareWithDir(f *File, dir string, opts DirCompareOptions) (*DirComparison, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+
+	build := opts.Build
+	if build.BaseDir == "" {
+		build.BaseDir = dir
+	}
+	build.LowercasePaths = Bool(false)
+
+	scan, err := scanDir(dir, opts.Dir, build.SymlinkPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBuilder(build)
+	b.prefixRoots = scan.prefixes
+
+	exact := IndexOptions{CaseSensitive: true}
+	disk := make(map[string]string, len(scan.files))
+	diskExact := make(map[string]struct{}, len(scan.files))
+	diskOrder := make([]string, 0, len(scan.files))
+	for _, file := range scan.files {
+		rel := b.normalizePath(file.path)
+		key := indexKey(rel, IndexOptions{})
+		if _, ok := disk[key]; !ok {
+			disk[key] = rel
+			diskOrder = append(diskOrder, key)
+		}
+		diskExact[indexKey(rel, exact)] = struct{}{}
+	}
+
+	out := &DirComparison{}
+	indexed := make(map[string]struct{}, len(f.Textures))
+	for i := range f.Textures {
+		path := f.Textures[i].PAAFile
+		key := indexKey(path, IndexOptions{})
+		indexed[key] = struct{}{}
+
+		rel, ok := disk[key]
+		switch {
+		case !ok:
+			out.Missing = append(out.Missing, path)
+		case !hasKey(diskExact, indexKey(path, exact)):
+			out.CaseMismatches = append(out.CaseMismatches, CaseMismatch{Entry: path, Disk: rel})
+		}
+	}
+
+	for _, key := range diskOrder {
+		if !hasKey(indexed, key) {
+			out.NotIndexed = append(out.NotIndexed, disk[key])
+		}
+	}
+
+	return out, nil
+}
+
+// hasKey reports whether set contains key.
+func hasKey(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+	return ok
+}
@@ -0,0 +1,66 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCompareWithDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	data := filepath.Join(dir, "data")
+	if err := os.MkdirAll(data, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	for _, name := range []string{"a_co.paa", "B_co.paa", "extra_co.paa", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(data, name), nil, 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", name, err)
+		}
+	}
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `data\a_co.paa`},
+		{PAAFile: `data\b_co.paa`},
+		{PAAFile: "data/gone_co.paa"},
+	}}
+
+	cmp, err := CompareWithDir(f, dir, DirCompareOptions{})
+	if err != nil {
+		t.Fatalf("CompareWithDir() error: %v", err)
+	}
+
+	if cmp.Clean() {
+		t.Fatal("Clean() = true")
+	}
+
+	if !slices.Equal(cmp.NotIndexed, []string{`data\extra_co.paa`}) {
+		t.Fatalf("NotIndexed = %v", cmp.NotIndexed)
+	}
+
+	if !slices.Equal(cmp.Missing, []string{"data/gone_co.paa"}) {
+		t.Fatalf("Missing = %v", cmp.Missing)
+	}
+
+	want := []CaseMismatch{{Entry: `data\b_co.paa`, Disk: `data\B_co.paa`}}
+	if !slices.Equal(cmp.CaseMismatches, want) {
+		t.Fatalf("CaseMismatches = %v", cmp.CaseMismatches)
+	}
+
+	f.Textures = []TextureEntry{{PAAFile: `my\addon\data\a_co.paa`}}
+	cmp, err = CompareWithDir(f, data, DirCompareOptions{
+		Build: BuildOptions{PBOPrefix: `my\addon\data`},
+		Dir:   DirOptions{Exclude: []string{"b_co.paa", "extra_co.paa"}},
+	})
+	if err != nil || !cmp.Clean() {
+		t.Fatalf("CompareWithDir(prefix) = %+v, %v", cmp, err)
+	}
+
+	if _, err = CompareWithDir(nil, dir, DirCompareOptions{}); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("CompareWithDir(nil) error = %v", err)
+	}
+}
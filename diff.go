@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import "fmt"
+
+// FileDiff describes changes turning one file into another, matched by
+// normalized entry path. It is JSON/YAML serializable for delta distribution.
+type FileDiff struct {
+	// Added holds entries missing in base, in target order.
+	Added []TextureEntry `json:"added,omitempty" yaml:"added,omitempty"`
+	// Removed holds paths of base entries missing in target.
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// Changed holds target values of entries present in both files but different.
+	Changed []EntryChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+}
+
+// EntryChange is one changed entry of FileDiff.
+type EntryChange struct {
+	// Fields lists JSON names of changed fields.
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	// Entry is the new entry value.
+	Entry TextureEntry `json:"entry" yaml:"entry"`
+}
+
+// Empty reports whether diff has no changes.
+func (d *FileDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff returns changes turning base into target. Duplicate paths are matched
+// by their first occurrence; a nil file is treated as empty.
+func Diff(base, target *File) *FileDiff {
+	d := &FileDiff{}
+	if base == nil {
+		base = &File{}
+	}
+
+	if target == nil {
+		target = &File{}
+	}
+
+	baseIdx := base.Index()
+	targetIdx := target.Index()
+
+	for i := range base.Textures {
+		key := indexKey(base.Textures[i].PAAFile, IndexOptions{})
+		if baseIdx[key] == &base.Textures[i] && targetIdx[key] == nil {
+			d.Removed = append(d.Removed, base.Textures[i].PAAFile)
+		}
+	}
+
+	for i := range target.Textures {
+		e := &target.Textures[i]
+		key := indexKey(e.PAAFile, IndexOptions{})
+		if targetIdx[key] != e {
+			continue
+		}
+
+		old, ok := baseIdx[key]
+		if !ok {
+			d.Added = append(d.Added, cloneEntry(e))
+			continue
+		}
+
+		if fields := entryDiffFields(old, e, &EqualOptions{}); len(fields) > 0 {
+			d.Changed = append(d.Changed, EntryChange{Entry: cloneEntry(e), Fields: fields})
+		}
+	}
+
+	return d
+}
+
+// ApplyDiff returns copy of base with d applied.
+//
+// Removed and changed paths must exist in base (ErrEntryNotFound) and added
+// paths must not (ErrEntryExists), so a diff applies only to the index it
+// was made for. Added entries are appended; use SortByPath for engine order.
+func ApplyDiff(base *File, d *FileDiff) (*File, error) {
+	if base == nil {
+		return nil, ErrNilFile
+	}
+
+	out := base.Clone()
+	if d == nil {
+		return out, nil
+	}
+
+	for _, path := range d.Removed {
+		if !out.Remove(path) {
+			return nil, fmt.Errorf("apply diff: remove: %w: %s", ErrEntryNotFound, path)
+		}
+	}
+
+	for i := range d.Changed {
+		old, ok := out.FindByPath(d.Changed[i].Entry.PAAFile)
+		if !ok {
+			return nil, fmt.Errorf("apply diff: change: %w: %s", ErrEntryNotFound, d.Changed[i].Entry.PAAFile)
+		}
+
+		// Assign in place, keeping diff entry path as written.
+		*old = cloneEntry(&d.Changed[i].Entry)
+	}
+
+	for i := range d.Added {
+		if _, ok := out.FindByPath(d.Added[i].PAAFile); ok {
+			return nil, fmt.Errorf("apply diff: add: %w: %s", ErrEntryExists, d.Added[i].PAAFile)
+		}
+
+		out.Textures = append(out.Textures, cloneEntry(&d.Added[i]))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package texheaders
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDiffApply(t *testing.T) {
+	t.Parallel()
+
+	base := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: "a_co.paa", PaxFileSize: 1},
+		{PAAFile: "b_co.paa", PaxFileSize: 2},
+		{PAAFile: "c_co.paa", PaxFileSize: 3},
+	}}
+	target := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: "a_co.paa", PaxFileSize: 1},
+		{PAAFile: "c_co.paa", PaxFileSize: 30, PaxFormat: PaxFormatDXT5},
+		{PAAFile: "d_co.paa", PaxFileSize: 4},
+	}}
+
+	d := Diff(base, target)
+	if !slices.Equal(d.Removed, []string{"b_co.paa"}) || len(d.Added) != 1 || d.Added[0].PAAFile != "d_co.paa" {
+		t.Fatalf("Diff() = %+v", d)
+	}
+
+	if len(d.Changed) != 1 || !slices.Equal(d.Changed[0].Fields, []string{"pax_format", "pax_file_size"}) {
+		t.Fatalf("Diff().Changed = %+v", d.Changed)
+	}
+
+	// Diff survives JSON round trip.
+	raw, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+
+	var stored FileDiff
+	if err = json.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	got, err := ApplyDiff(base, &stored)
+	if err != nil {
+		t.Fatalf("ApplyDiff() error: %v", err)
+	}
+
+	if !EqualFiles(got, target, EqualOptions{}) {
+		t.Fatalf("ApplyDiff() = %+v, want %+v", got.Textures, target.Textures)
+	}
+
+	if len(base.Textures) != 3 || base.Textures[2].PaxFileSize != 3 {
+		t.Fatal("ApplyDiff() modified base")
+	}
+
+	if !Diff(got, target).Empty() {
+		t.Fatal("Diff(applied, target) is not empty")
+	}
+
+	if _, err = ApplyDiff(got, &stored); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("ApplyDiff(twice) error = %v, want ErrEntryNotFound", err)
+	}
+
+	if _, err = ApplyDiff(target, &FileDiff{Added: []TextureEntry{{PAAFile: "D_CO.paa"}}}); !errors.Is(err, ErrEntryExists) {
+		t.Fatalf("ApplyDiff(existing add) error = %v, want ErrEntryExists", err)
+	}
+}
+
+func TestDiffApply_MixedCase(t *testing.T) {
+	t.Parallel()
+
+	base := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: `data\a_co.paa`, PaxFileSize: 1},
+		{PAAFile: `Data\B_co.paa`, PaxFileSize: 2},
+	}}
+	target := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: `Data\A_co.paa`, PaxFileSize: 10},
+		{PAAFile: `Data\B_co.paa`, PaxFileSize: 2},
+		{PAAFile: `Data\C_co.paa`, PaxFileSize: 3},
+	}}
+
+	got, err := ApplyDiff(base, Diff(base, target))
+	if err != nil {
+		t.Fatalf("ApplyDiff() error: %v", err)
+	}
+
+	if !EqualFiles(got, target, EqualOptions{}) {
+		t.Fatalf("ApplyDiff() = %+v, want %+v", got.Textures, target.Textures)
+	}
+}
+
+func TestDiff_NilFiles(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{{PAAFile: "a_co.paa"}}}
+	if d := Diff(nil, f); len(d.Added) != 1 || len(d.Removed) != 0 {
+		t.Fatalf("Diff(nil, f) = %+v", d)
+	}
+
+	if d := Diff(f, nil); len(d.Removed) != 1 || len(d.Added) != 0 {
+		t.Fatalf("Diff(f, nil) = %+v", d)
+	}
+
+	if !Diff(nil, nil).Empty() {
+		t.Fatal("Diff(nil, nil) is not empty")
+	}
+}
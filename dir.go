@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SymlinkPolicy controls how directory scanning treats symbolic links and junctions.
+type SymlinkPolicy int
+
+const (
+	// SymlinkFollow follows links, skipping directories reached more than
+	// once and dangling links (default).
+	SymlinkFollow SymlinkPolicy = iota
+	// SymlinkSkip ignores links entirely.
+	SymlinkSkip
+	// SymlinkError fails the scan when a link is found.
+	SymlinkError
+)
+
+// DirOptions controls directory scanning for AppendDir.
+type DirOptions struct {
+	// Extensions lists accepted source extensions, matched case-insensitively.
+	// If empty, only ".paa" files are collected.
+	Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	// Exclude lists slash-separated glob patterns matched case-insensitively
+	// against paths relative to scanned root; "**" matches any number of
+	// directories (e.g. "**/backup/**"). Matching directories are not descended.
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	// MaxDepth limits directory levels scanned; 1 scans only root directory.
+	// Zero means unlimited.
+	MaxDepth int `json:"max_depth,omitempty" yaml:"max_depth,omitempty"`
+	// SkipHidden ignores files and directories whose name starts with a dot.
+	SkipHidden bool `json:"skip_hidden,omitempty" yaml:"skip_hidden,omitempty"`
+}
+
+// scannedFile is one source file found by directory scan.
+type scannedFile struct {
+	modTime time.Time // modTime is the last modification time.
+	path    string    // path is the file path joined with scanned root.
+	size    int64     // size is the file size in bytes.
+}
+
+// AppendDir registers all matching source textures found under dir.
+func (b *Builder) AppendDir(dir string, opts DirOptions) error {
+	if strings.TrimSpace(dir) == "" {
+		return ErrEmptyInputPath
+	}
+
+	scan, err := scanDir(dir, opts, b.opts.SymlinkPolicy)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.prefixRoots = append(b.prefixRoots, scan.prefixes...)
+	for _, file := range scan.files {
+		if err = b.appendLocked(file.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dirScan is the result of one recursive directory scan.
+type dirScan struct {
+	files    []scannedFile // files holds matched files in lexical order.
+	prefixes []prefixRoot  // prefixes holds directories with $PBOPREFIX$ file.
+	dirs     []string      // dirs holds resolved paths of walked directories.
+}
+
+// scanDir walks dir recursively and returns matching files in lexical order.
+func scanDir(dir string, opts DirOptions, policy SymlinkPolicy) (dirScan, error) {
+	exts := opts.Extensions
+	if len(exts) == 0 {
+		exts = []string{".paa"}
+	}
+
+	exclude, err := compileGlobs(opts.Exclude)
+	if err != nil {
+		return dirScan{}, err
+	}
+
+	s := dirScanner{
+		root:    dir,
+		exts:    exts,
+		exclude: exclude,
+		opts:    opts,
+		policy:  policy,
+		visited: make(map[string]struct{}),
+		files:   make([]scannedFile, 0, 64),
+	}
+
+	if err = s.walk(dir, 1); err != nil {
+		return dirScan{}, fmt.Errorf("scan dir %q: %w", dir, err)
+	}
+
+	return dirScan{files: s.files, prefixes: s.prefixes, dirs: s.dirs}, nil
+}
+
+// dirScanner holds state of one recursive directory scan.
+type dirScanner struct {
+	visited  map[string]struct{} // visited holds resolved paths of walked directories.
+	root     string              // root is the scanned root directory.
+	exts     []string            // exts is the list of accepted extensions.
+	exclude  [][]string          // exclude holds exclude patterns split into segments.
+	files    []scannedFile       // files collects matched files.
+	prefixes []prefixRoot        // prefixes collects directories with $PBOPREFIX$ file.
+	dirs     []string            // dirs collects resolved paths of walked directories.
+	opts     DirOptions          // opts is the scan options.
+	policy   SymlinkPolicy       // policy controls link handling.
+}
+
+// walk scans one directory at depth, descending into subdirectories.
+func (s *dirScanner) walk(dir string, depth int) error {
+	resolved, err := filepath.EvalSymlinks(ioPath(dir))
+	if err != nil {
+		return err
+	}
+
+	// Directory reached again through a link, either a cycle or a duplicate.
+	if _, ok := s.visited[resolved]; ok {
+		return nil
+	}
+
+	s.visited[resolved] = struct{}{}
+	s.dirs = append(s.dirs, resolved)
+
+	entries, err := os.ReadDir(ioPath(dir))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if s.skipped(path, e.Name()) {
+			continue
+		}
+
+		// Windows junctions are reported as irregular files.
+		if e.Type()&(fs.ModeSymlink|fs.ModeIrregular) != 0 {
+			switch s.policy {
+			case SymlinkSkip:
+				continue
+			case SymlinkError:
+				return fmt.Errorf("%w: %s", ErrSymlink, path)
+			}
+
+			info, statErr := os.Stat(ioPath(path))
+			if errors.Is(statErr, fs.ErrNotExist) {
+				// Dangling link: its target is gone, nothing to index.
+				continue
+			}
+
+			if statErr != nil {
+				return statErr
+			}
+
+			if info.IsDir() {
+				if err = s.descend(path, depth); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.add(path, info)
+			continue
+		}
+
+		if e.IsDir() {
+			if err = s.descend(path, depth); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if strings.EqualFold(e.Name(), PBOPrefixFile) {
+			prefix, prefixErr := readPBOPrefix(path)
+			if prefixErr != nil {
+				return prefixErr
+			}
+
+			if prefix != "" {
+				s.prefixes = append(s.prefixes, prefixRoot{dir: filepath.Clean(dir), prefix: prefix})
+			}
+
+			continue
+		}
+
+		info, infoErr := e.Info()
+		if infoErr != nil {
+			return infoErr
+		}
+
+		s.add(path, info)
+	}
+
+	return nil
+}
+
+// descend walks subdirectory of directory at depth unless MaxDepth is reached.
+func (s *dirScanner) descend(path string, depth int) error {
+	if s.opts.MaxDepth > 0 && depth >= s.opts.MaxDepth {
+		return nil
+	}
+
+	return s.walk(path, depth+1)
+}
+
+// skipped reports whether path is hidden or excluded by options.
+func (s *dirScanner) skipped(path, name string) bool {
+	if s.opts.SkipHidden && strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	if len(s.exclude) == 0 {
+		return false
+	}
+
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil {
+		return false
+	}
+
+	segs := strings.Split(strings.ToLower(filepath.ToSlash(rel)), "/")
+	for _, pat := range s.exclude {
+		if matchGlob(pat, segs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add collects one file when its extension is accepted.
+func (s *dirScanner) add(path string, info fs.FileInfo) {
+	if !hasExtension(path, s.exts) {
+		return
+	}
+
+	s.files = append(s.files, scannedFile{
+		path:    path,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	})
+}
+
+// hasExtension reports whether path ends with one of exts (case-insensitive).
+func hasExtension(path string, exts []string) bool {
+	ext := filepath.Ext(path)
+	for _, want := range exts {
+		if !strings.HasPrefix(want, ".") {
+			want = "." + want
+		}
+
+		if strings.EqualFold(ext, want) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileGlobs validates patterns and splits them into lowercase segments.
+func compileGlobs(patterns []string) ([][]string, error) {
+	out := make([][]string, 0, len(patterns))
+	for _, p := range patterns {
+		segs := strings.Split(strings.ToLower(strings.Trim(filepath.ToSlash(p), "/")), "/")
+		for _, seg := range segs {
+			if _, err := pathpkg.Match(seg, ""); err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", p, err)
+			}
+		}
+
+		out = append(out, segs)
+	}
+
+	return out, nil
+}
+
+// matchGlob reports whether path segments match pattern segments, where
+// "**" matches zero or more segments.
+func matchGlob(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			pat = pat[1:]
+			if len(pat) == 0 {
+				return true
+			}
+
+			for i := range len(segs) + 1 {
+				if matchGlob(pat, segs[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(segs) == 0 {
+			return false
+		}
+
+		if ok, _ := pathpkg.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+
+		pat, segs = pat[1:], segs[1:]
+	}
+
+	return len(segs) == 0
+}
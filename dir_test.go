@@ -0,0 +1,146 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_AppendDir(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{})
+	if err := b.AppendDir("testdata", DirOptions{}); err != nil {
+		t.Fatalf("AppendDir(testdata) error: %v", err)
+	}
+
+	if got := len(b.Inputs()); got != 46 {
+		t.Fatalf("inputs = %d, want 46", got)
+	}
+}
+
+func TestBuilder_AppendDirExtensions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a_co.PAA", "b.txt", "c.pac"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", name, err)
+		}
+	}
+
+	b := NewBuilder(BuildOptions{})
+	if err := b.AppendDir(dir, DirOptions{Extensions: []string{"paa", ".pac"}}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	if got := len(b.Inputs()); got != 2 {
+		t.Fatalf("inputs = %d, want 2", got)
+	}
+}
+
+func TestBuilder_AppendDirSymlinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	shared := t.TempDir()
+	copyFixture(t, "test_co.paa", root, "a_co.paa")
+	copyFixture(t, "test_co.paa", shared, "b_co.paa")
+
+	if err := os.Symlink(shared, filepath.Join(root, "shared")); err != nil {
+		t.Skipf("symlinks are not available: %v", err)
+	}
+
+	// Link back to root creates a cycle.
+	if err := os.Symlink(root, filepath.Join(shared, "loop")); err != nil {
+		t.Fatalf("Symlink(loop) error: %v", err)
+	}
+
+	// Dangling link is skipped when following links.
+	if err := os.Symlink(filepath.Join(root, "missing_co.paa"), filepath.Join(root, "dangling_co.paa")); err != nil {
+		t.Fatalf("Symlink(dangling) error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		policy  SymlinkPolicy
+		want    int
+		wantErr bool
+	}{
+		{name: "follow", policy: SymlinkFollow, want: 2},
+		{name: "skip", policy: SymlinkSkip, want: 1},
+		{name: "error", policy: SymlinkError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		b := NewBuilder(BuildOptions{SymlinkPolicy: tt.policy})
+		err := b.AppendDir(root, DirOptions{})
+		if tt.wantErr {
+			if !errors.Is(err, ErrSymlink) {
+				t.Fatalf("%s: AppendDir() error = %v, want %v", tt.name, err, ErrSymlink)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%s: AppendDir() error: %v", tt.name, err)
+		}
+
+		if got := len(b.Inputs()); got != tt.want {
+			t.Fatalf("%s: inputs = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_AppendDirFilters(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, rel := range []string{
+		"a_co.paa",
+		"data/b_co.paa",
+		"data/deep/c_co.paa",
+		"Backup/d_co.paa",
+		"data/backup/old/e_co.paa",
+		".git/f_co.paa",
+		"data/g_co.tmp.paa",
+	} {
+		dir := filepath.Join(root, filepath.Dir(rel))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("MkdirAll() error: %v", err)
+		}
+
+		copyFixture(t, "test_co.paa", dir, filepath.Base(rel))
+	}
+
+	tests := []struct {
+		name string
+		opts DirOptions
+		want int
+	}{
+		{name: "all", opts: DirOptions{}, want: 7},
+		{name: "exclude backup", opts: DirOptions{Exclude: []string{"**/backup/**"}}, want: 5},
+		{name: "exclude file glob", opts: DirOptions{Exclude: []string{"**/*.tmp.paa"}}, want: 6},
+		{name: "depth", opts: DirOptions{MaxDepth: 2}, want: 5},
+		{name: "root only", opts: DirOptions{MaxDepth: 1}, want: 1},
+		{name: "hidden", opts: DirOptions{SkipHidden: true}, want: 6},
+	}
+
+	for _, tt := range tests {
+		b := NewBuilder(BuildOptions{})
+		if err := b.AppendDir(root, tt.opts); err != nil {
+			t.Fatalf("%s: AppendDir() error: %v", tt.name, err)
+		}
+
+		if got := len(b.Inputs()); got != tt.want {
+			t.Fatalf("%s: inputs = %d (%v), want %d", tt.name, got, b.Inputs(), tt.want)
+		}
+	}
+
+	b := NewBuilder(BuildOptions{})
+	if err := b.AppendDir(root, DirOptions{Exclude: []string{"[bad"}}); err == nil {
+		t.Fatal("AppendDir(bad pattern) error = nil")
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DuplicateGroup lists entries whose source files are byte-identical.
+type DuplicateGroup struct {
+	// Hash is the hex-encoded SHA-256 of source content.
+	Hash string `json:"hash" yaml:"hash"`
+	// Paths lists stored entry paths sharing the content, sorted.
+	Paths []string `json:"paths" yaml:"paths"`
+	// Size is the source file size in bytes.
+	Size int64 `json:"size" yaml:"size"`
+}
+
+// contentHashes collects source content hashes from build workers.
+type contentHashes struct {
+	groups map[[sha256.Size]byte]*DuplicateGroup // groups maps content hash to its entries.
+	mu     sync.Mutex                            // mu guards groups.
+}
+
+// add records entry path under content hash.
+func (c *contentHashes) add(sum [sha256.Size]byte, size int64, path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.groups == nil {
+		c.groups = make(map[[sha256.Size]byte]*DuplicateGroup)
+	}
+
+	g, ok := c.groups[sum]
+	if !ok {
+		g = &DuplicateGroup{Hash: hex.EncodeToString(sum[:]), Size: size}
+		c.groups[sum] = g
+	}
+
+	g.Paths = append(g.Paths, path)
+}
+
+// recordHash hashes source file of built entry when DetectDuplicates is set.
+func (b *Builder) recordHash(path, rel string) error {
+	fh, err := b.openSource(path)
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+
+	defer b.closeSource(fh)
+
+	h := sha256.New()
+	size, err := io.Copy(h, fh)
+	if err != nil {
+		return fmt.Errorf("hash source: %w", err)
+	}
+
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	b.hashes.add(sum, size, rel)
+	return nil
+}
+
+// Duplicates returns groups of byte-identical sources found by the last build
+// with BuildOptions.DetectDuplicates, ordered by first path.
+func (b *Builder) Duplicates() []DuplicateGroup {
+	return b.hashes.duplicates()
+}
+
+// duplicates returns groups with more than one path, ordered by first path.
+func (c *contentHashes) duplicates() []DuplicateGroup {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var out []DuplicateGroup
+	for _, g := range c.groups {
+		if len(g.Paths) < 2 {
+			continue
+		}
+
+		dup := *g
+		dup.Paths = slices.Clone(g.Paths)
+		slices.Sort(dup.Paths)
+		out = append(out, dup)
+	}
+
+	slices.SortFunc(out, func(x, y DuplicateGroup) int {
+		return strings.Compare(x.Paths[0], y.Paths[0])
+	})
+
+	return out
+}
+
+// ContentDuplicates hashes source files of f entries resolved under baseDir
+// and returns groups of byte-identical textures stored under different
+// paths, ordered by first path. Entries whose source is missing or escapes
+// baseDir are skipped; VerifyAgainstSources reports them.
+func ContentDuplicates(f *File, baseDir string) ([]DuplicateGroup, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+
+	var hashes contentHashes
+	for i := range f.Textures {
+		rel := f.Textures[i].PAAFile
+		if checkStoredPath(rel) != nil {
+			continue
+		}
+
+		path := filepath.Join(baseDir, filepath.FromSlash(strings.ReplaceAll(rel, "\\", "/")))
+		sum, size, err := hashFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("hash %q: %w", path, err)
+		}
+
+		hashes.add(sum, size, rel)
+	}
+
+	return hashes.duplicates(), nil
+}
+
+// hashFile returns SHA-256 and size of file content.
+func hashFile(path string) ([sha256.Size]byte, int64, error) {
+	var sum [sha256.Size]byte
+	fh, err := os.Open(ioPath(path))
+	if err != nil {
+		return sum, 0, err
+	}
+
+	defer func() {
+		_ = fh.Close()
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(h, fh)
+	if err != nil {
+		return sum, 0, err
+	}
+
+	copy(sum[:], h.Sum(nil))
+	return sum, size, nil
+}
+
+// PathDuplicate lists entries whose paths match after default normalization.
+type PathDuplicate struct {
+	// Paths lists stored paths of the entries in file order.
+	Paths []string `json:"paths" yaml:"paths"`
+	// Entries lists positions of the entries in File.Textures.
+	Entries []int `json:"entries" yaml:"entries"`
+}
+
+// PathDuplicates returns groups of entries sharing a normalized path, ordered
+// by first entry. Dedupe removes all but one entry of every group.
+func (f *File) PathDuplicates() []PathDuplicate {
+	slot := make(map[string]int, len(f.Textures))
+	var groups []PathDuplicate
+	for i := range f.Textures {
+		key := indexKey(f.Textures[i].PAAFile, IndexOptions{})
+		g, seen := slot[key]
+		if !seen {
+			slot[key] = len(groups)
+			groups = append(groups, PathDuplicate{})
+			g = len(groups) - 1
+		}
+
+		groups[g].Paths = append(groups[g].Paths, f.Textures[i].PAAFile)
+		groups[g].Entries = append(groups[g].Entries, i)
+	}
+
+	return slices.DeleteFunc(groups, func(g PathDuplicate) bool {
+		return len(g.Entries) < 2
+	})
+}
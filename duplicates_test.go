@@ -0,0 +1,116 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBuilder_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	copyFixture(t, "test_co.paa", filepath.Join(dir, "sub"), "b_co.paa")
+	copyFixture(t, "test_co.paa", dir, "c_co.paa")
+
+	for _, detect := range []bool{false, true} {
+		b := NewBuilder(BuildOptions{BaseDir: dir, DetectDuplicates: detect, Workers: 2})
+		if err := b.AppendDir(dir, DirOptions{}); err != nil {
+			t.Fatalf("AppendDir() error: %v", err)
+		}
+
+		if _, err := b.Build(); err != nil {
+			t.Fatalf("Build() error: %v", err)
+		}
+
+		groups := b.Duplicates()
+		if !detect {
+			if len(groups) != 0 {
+				t.Fatalf("Duplicates() without detection = %+v", groups)
+			}
+
+			continue
+		}
+
+		if len(groups) != 1 || len(groups[0].Paths) != 3 || len(groups[0].Hash) != 64 || groups[0].Size != 11080 {
+			t.Fatalf("Duplicates() = %+v, want one group of 3", groups)
+		}
+
+		if got := groups[0].Paths; got[0] != "a_co.paa" || got[2] != `sub\b_co.paa` {
+			t.Fatalf("group paths = %v", got)
+		}
+
+		pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+		writeTestPBO(t, pboPath, nil, []testPBOFile{{name: "a_co.paa", data: readFixture(t, "test_co.paa")}})
+		if _, err := b.BuildPBO(pboPath); err != nil {
+			t.Fatalf("BuildPBO() error: %v", err)
+		}
+
+		if groups = b.Duplicates(); len(groups) != 0 {
+			t.Fatalf("Duplicates() after BuildPBO = %+v", groups)
+		}
+	}
+}
+
+func TestContentDuplicates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	copyFixture(t, "test_co.paa", filepath.Join(dir, "sub"), "b_co.paa")
+	copyFixture(t, "test_nohq.paa", dir, "c_nohq.paa")
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: "a_co.paa"},
+		{PAAFile: `sub\b_co.paa`},
+		{PAAFile: "c_nohq.paa"},
+		{PAAFile: "missing_co.paa"},
+		{PAAFile: `..\outside_co.paa`},
+	}}
+
+	groups, err := ContentDuplicates(f, dir)
+	if err != nil {
+		t.Fatalf("ContentDuplicates() error: %v", err)
+	}
+
+	if len(groups) != 1 || !slices.Equal(groups[0].Paths, []string{"a_co.paa", `sub\b_co.paa`}) || groups[0].Size != 11080 {
+		t.Fatalf("ContentDuplicates() = %+v", groups)
+	}
+
+	if _, err := ContentDuplicates(nil, dir); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("ContentDuplicates(nil) error = %v, want %v", err, ErrNilFile)
+	}
+}
+
+func TestFile_PathDuplicates(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `data\rock_co.paa`},
+		{PAAFile: `data\grass_co.paa`},
+		{PAAFile: "DATA/Rock_CO.paa"},
+		{PAAFile: `data\sand_co.paa`},
+		{PAAFile: `data\rock_co.paa`},
+	}}
+
+	got := f.PathDuplicates()
+	if len(got) != 1 || !slices.Equal(got[0].Entries, []int{0, 2, 4}) || got[0].Paths[1] != "DATA/Rock_CO.paa" {
+		t.Fatalf("PathDuplicates() = %+v", got)
+	}
+
+	f.Dedupe(KeepFirst)
+	if got := f.PathDuplicates(); len(got) != 0 {
+		t.Fatalf("PathDuplicates() after Dedupe = %+v", got)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"image/color"
+	"math"
+)
+
+// Width returns width of the largest mip without LZO compression flag, or 0 without mips.
+func (e *TextureEntry) Width() uint16 {
+	w, _ := e.dimensions()
+	return w
+}
+
+// Height returns height of the largest mip, or 0 without mips.
+func (e *TextureEntry) Height() uint16 {
+	_, h := e.dimensions()
+	return h
+}
+
+// AspectRatio returns width divided by height of the largest mip, or 0 when
+// entry has no mips or zero height.
+func (e *TextureEntry) AspectRatio() float64 {
+	w, h := e.dimensions()
+	if h == 0 {
+		return 0
+	}
+
+	return float64(w) / float64(h)
+}
+
+// dimensions returns size of the mip with largest area.
+//
+// Mips are normally stored largest first, but entries from foreign tools
+// are not trusted to keep that order.
+func (e *TextureEntry) dimensions() (uint16, uint16) {
+	var (
+		bw, bh uint16
+		best   int
+	)
+
+	for _, m := range e.MipMaps {
+		w, h := mipDimensions(m)
+		if area := int(w) * int(h); area > best || (bw == 0 && bh == 0) {
+			bw, bh, best = w, h, area
+		}
+	}
+
+	return bw, bh
+}
+
+// Clone returns deep copy of e that does not share MipMaps.
+func (e *TextureEntry) Clone() TextureEntry {
+	return cloneEntry(e)
+}
+
+// AverageNRGBA returns AverageColor as non-premultiplied color.
+func (e *TextureEntry) AverageNRGBA() color.NRGBA {
+	return bgraToNRGBA(e.AverageColor)
+}
+
+// AverageRGBA returns AverageColor as alpha-premultiplied color.
+func (e *TextureEntry) AverageRGBA() color.RGBA {
+	return nrgbaToRGBA(e.AverageNRGBA())
+}
+
+// AverageFloatNRGBA returns AverageColorF as non-premultiplied color,
+// rounding and clamping components to 0..255.
+func (e *TextureEntry) AverageFloatNRGBA() color.NRGBA {
+	return color.NRGBA{
+		R: unitToByte(e.AverageColorF[0]),
+		G: unitToByte(e.AverageColorF[1]),
+		B: unitToByte(e.AverageColorF[2]),
+		A: unitToByte(e.AverageColorF[3]),
+	}
+}
+
+// SetAverageColor sets AverageColor and AverageColorF from c.
+func (e *TextureEntry) SetAverageColor(c color.Color) {
+	e.AverageColor = nrgbaToBGRA(toNRGBA(c))
+	e.syncAverageColorF()
+}
+
+// MaxNRGBA returns MaxColor as non-premultiplied color.
+func (e *TextureEntry) MaxNRGBA() color.NRGBA {
+	return bgraToNRGBA(e.MaxColor)
+}
+
+// MaxRGBA returns MaxColor as alpha-premultiplied color.
+func (e *TextureEntry) MaxRGBA() color.RGBA {
+	return nrgbaToRGBA(e.MaxNRGBA())
+}
+
+// SetMaxColor sets MaxColor from c and marks it as present (HasMaxCtagg).
+func (e *TextureEntry) SetMaxColor(c color.Color) {
+	e.MaxColor = nrgbaToBGRA(toNRGBA(c))
+	e.HasMaxCtagg = true
+}
+
+// syncAverageColorF derives AverageColorF from AverageColor.
+func (e *TextureEntry) syncAverageColorF() {
+	// BI stores byte color as B,G,R,A while float tuple is exposed as R,G,B,A.
+	e.AverageColorF[0] = float32(e.AverageColor[2]) / 255.0
+	e.AverageColorF[1] = float32(e.AverageColor[1]) / 255.0
+	e.AverageColorF[2] = float32(e.AverageColor[0]) / 255.0
+	e.AverageColorF[3] = float32(e.AverageColor[3]) / 255.0
+}
+
+// bgraToNRGBA converts stored B,G,R,A tuple to color.
+func bgraToNRGBA(c [4]byte) color.NRGBA {
+	return color.NRGBA{R: c[2], G: c[1], B: c[0], A: c[3]}
+}
+
+// nrgbaToBGRA converts color to stored B,G,R,A tuple.
+func nrgbaToBGRA(c color.NRGBA) [4]byte {
+	return [4]byte{c.B, c.G, c.R, c.A}
+}
+
+// toNRGBA converts any color to non-premultiplied color.
+func toNRGBA(c color.Color) color.NRGBA {
+	n, _ := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return n
+}
+
+// nrgbaToRGBA premultiplies color alpha.
+func nrgbaToRGBA(c color.NRGBA) color.RGBA {
+	r, _ := color.RGBAModel.Convert(c).(color.RGBA)
+	return r
+}
+
+// unitToByte maps 0..1 float to byte with rounding and clamping.
+func unitToByte(v float32) uint8 {
+	switch {
+	case math.IsNaN(float64(v)) || v <= 0:
+		return 0
+	case v >= 1:
+		return math.MaxUint8
+	default:
+		return uint8(math.Round(float64(v) * 255))
+	}
+}
@@ -0,0 +1,127 @@
+package texheaders
+
+import (
+	"encoding/json"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestTextureEntryDimensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mips   []MipMap
+		width  uint16
+		height uint16
+		ratio  float64
+	}{
+		{name: "empty"},
+		{
+			name:   "ordered",
+			mips:   []MipMap{{Width: 256, Height: 128}, {Width: 128, Height: 64}},
+			width:  256,
+			height: 128,
+			ratio:  2,
+		},
+		{
+			name:   "compressed flag",
+			mips:   []MipMap{{Width: 512 | mipCompressedFlag, Height: 512}},
+			width:  512,
+			height: 512,
+			ratio:  1,
+		},
+		{
+			name:   "unordered",
+			mips:   []MipMap{{Width: 32, Height: 64}, {Width: 64, Height: 128}},
+			width:  64,
+			height: 128,
+			ratio:  0.5,
+		},
+		{
+			name:  "zero height",
+			mips:  []MipMap{{Width: 16}},
+			width: 16,
+		},
+	}
+
+	for _, tt := range tests {
+		e := TextureEntry{MipMaps: tt.mips}
+		if e.Width() != tt.width || e.Height() != tt.height || e.AspectRatio() != tt.ratio {
+			t.Fatalf("%s: got %dx%d ratio %v, want %dx%d ratio %v",
+				tt.name, e.Width(), e.Height(), e.AspectRatio(), tt.width, tt.height, tt.ratio)
+		}
+	}
+}
+
+func TestTextureEntryColors(t *testing.T) {
+	t.Parallel()
+
+	var e TextureEntry
+	e.SetAverageColor(color.NRGBA{R: 200, G: 100, B: 50, A: 128})
+	if e.AverageColor != [4]byte{50, 100, 200, 128} {
+		t.Fatalf("AverageColor = %v, want B,G,R,A", e.AverageColor)
+	}
+
+	if want := [4]float32{200.0 / 255, 100.0 / 255, 50.0 / 255, 128.0 / 255}; e.AverageColorF != want {
+		t.Fatalf("AverageColorF = %v, want %v", e.AverageColorF, want)
+	}
+
+	want := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	if got := e.AverageNRGBA(); got != want {
+		t.Fatalf("AverageNRGBA() = %v, want %v", got, want)
+	}
+
+	if got := e.AverageFloatNRGBA(); got != want {
+		t.Fatalf("AverageFloatNRGBA() = %v, want %v", got, want)
+	}
+
+	if got := e.AverageRGBA(); got != (color.RGBA{R: 100, G: 50, B: 25, A: 128}) {
+		t.Fatalf("AverageRGBA() = %v, want premultiplied", got)
+	}
+
+	e.SetMaxColor(color.RGBA{R: 255, G: 0, B: 0, A: 255})
+	if e.MaxColor != [4]byte{0, 0, 255, 255} || !e.HasMaxCtagg {
+		t.Fatalf("MaxColor = %v HasMaxCtagg=%v", e.MaxColor, e.HasMaxCtagg)
+	}
+
+	if got := e.MaxNRGBA(); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Fatalf("MaxNRGBA() = %v", got)
+	}
+
+	e.AverageColorF = [4]float32{-1, 2, 0.5, 1}
+	if got := e.AverageFloatNRGBA(); got != (color.NRGBA{R: 0, G: 255, B: 128, A: 255}) {
+		t.Fatalf("AverageFloatNRGBA() = %v, want clamped", got)
+	}
+}
+
+func TestTextureEntryMarshalKeepsZeroValues(t *testing.T) {
+	t.Parallel()
+
+	in := TextureEntry{
+		PAAFile:   `data\a_co.paa`,
+		MipMaps:   []MipMap{{Width: 4, Height: 4, AlwaysThree: 3}},
+		PaxFormat: PaxFormatP8,
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	for _, key := range []string{"pax_format", "pax_suffix_type", "little_endian", "transparent_color", "is_paa", "always_zero"} {
+		if !strings.Contains(string(raw), `"`+key+`"`) {
+			t.Fatalf("json.Marshal() dropped %q: %s", key, raw)
+		}
+	}
+
+	out := TextureEntry{LittleEndian: true, TransparentColor: 0xFFFFFFFF}
+	if err = json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if !EqualEntries(&in, &out, EqualOptions{}) {
+		t.Fatalf("round trip mismatch: %+v", out)
+	}
+}
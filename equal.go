@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// EqualOptions relaxes EqualFiles and EqualEntries comparison.
+type EqualOptions struct {
+	// IgnoreFields lists fields skipped in comparison by their JSON names,
+	// e.g. "pax_file_size", "average_color_f", "magic".
+	IgnoreFields []string `json:"ignore_fields,omitempty" yaml:"ignore_fields,omitempty"`
+	// ColorEpsilon is the maximal allowed difference of AverageColorF components.
+	ColorEpsilon float32 `json:"color_epsilon,omitempty" yaml:"color_epsilon,omitempty"`
+	// IgnorePathCase compares paths case-insensitively with any separator.
+	IgnorePathCase bool `json:"ignore_path_case,omitempty" yaml:"ignore_path_case,omitempty"`
+}
+
+// entryField compares one TextureEntry field.
+type entryField struct {
+	eq   func(a, b *TextureEntry, opts *EqualOptions) bool
+	name string
+}
+
+// entryFields lists compared TextureEntry fields by JSON name.
+var entryFields = []entryField{
+	{name: "paa_file", eq: func(a, b *TextureEntry, opts *EqualOptions) bool {
+		if opts.IgnorePathCase {
+			return indexKey(a.PAAFile, IndexOptions{}) == indexKey(b.PAAFile, IndexOptions{})
+		}
+
+		return a.PAAFile == b.PAAFile
+	}},
+	{name: "mipmaps", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return slices.Equal(a.MipMaps, b.MipMaps) }},
+	{name: "color_palette_count", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.ColorPaletteCount == b.ColorPaletteCount }},
+	{name: "palette_ptr", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.PalettePtr == b.PalettePtr }},
+	{name: "average_color_f", eq: func(a, b *TextureEntry, opts *EqualOptions) bool {
+		return colorsWithin(a.AverageColorF, b.AverageColorF, opts.ColorEpsilon)
+	}},
+	{name: "average_color", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.AverageColor == b.AverageColor }},
+	{name: "max_color", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.MaxColor == b.MaxColor }},
+	{name: "clamp_flags", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.ClampFlags == b.ClampFlags }},
+	{name: "transparent_color", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.TransparentColor == b.TransparentColor }},
+	{name: "has_max_ctagg", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.HasMaxCtagg == b.HasMaxCtagg }},
+	{name: "is_alpha", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.IsAlpha == b.IsAlpha }},
+	{name: "is_transparent", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.IsTransparent == b.IsTransparent }},
+	{name: "is_alpha_non_opaque", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.IsAlphaNonOpaque == b.IsAlphaNonOpaque }},
+	{name: "mipmap_count", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.MipMapCount == b.MipMapCount }},
+	{name: "pax_format", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.PaxFormat == b.PaxFormat }},
+	{name: "little_endian", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.LittleEndian == b.LittleEndian }},
+	{name: "is_paa", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.IsPAA == b.IsPAA }},
+	{name: "pax_suffix_type", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.PaxSuffixType == b.PaxSuffixType }},
+	{name: "mipmap_count_copy", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.MipMapCountCopy == b.MipMapCountCopy }},
+	{name: "pax_file_size", eq: func(a, b *TextureEntry, _ *EqualOptions) bool { return a.PaxFileSize == b.PaxFileSize }},
+}
+
+// colorsWithin reports whether float colors differ by at most eps per
+// component. NaN component equals only NaN.
+func colorsWithin(a, b [4]float32, eps float32) bool {
+	for i := range a {
+		aNaN, bNaN := math.IsNaN(float64(a[i])), math.IsNaN(float64(b[i]))
+		if aNaN || bNaN {
+			if aNaN != bNaN {
+				return false
+			}
+
+			continue
+		}
+
+		if d := a[i] - b[i]; d > eps || -d > eps {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualEntries reports whether entries are equal under opts.
+func EqualEntries(a, b *TextureEntry, opts EqualOptions) bool {
+	return firstEntryDiff(a, b, &opts) == ""
+}
+
+// EqualFiles reports whether files have equal header fields and pairwise
+// equal entries in the same order under opts. Nil files are equal only to nil.
+func EqualFiles(a, b *File, opts EqualOptions) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if !opts.ignored("magic") && a.Magic != b.Magic {
+		return false
+	}
+
+	if !opts.ignored("version") && a.Version != b.Version {
+		return false
+	}
+
+	if len(a.Textures) != len(b.Textures) {
+		return false
+	}
+
+	for i := range a.Textures {
+		if firstEntryDiff(&a.Textures[i], &b.Textures[i], &opts) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstEntryDiff returns JSON name of the first differing field, or "" when equal.
+func firstEntryDiff(a, b *TextureEntry, opts *EqualOptions) string {
+	for _, f := range entryFields {
+		if !opts.ignored(f.name) && !f.eq(a, b, opts) {
+			return f.name
+		}
+	}
+
+	return ""
+}
+
+// entryDiffFields returns JSON names of all differing fields.
+func entryDiffFields(a, b *TextureEntry, opts *EqualOptions) []string {
+	var out []string
+	for _, f := range entryFields {
+		if !opts.ignored(f.name) && !f.eq(a, b, opts) {
+			out = append(out, f.name)
+		}
+	}
+
+	return out
+}
+
+// ignored reports whether field with JSON name is in IgnoreFields.
+func (o *EqualOptions) ignored(name string) bool {
+	return slices.ContainsFunc(o.IgnoreFields, func(f string) bool {
+		return strings.EqualFold(strings.TrimSpace(f), name)
+	})
+}
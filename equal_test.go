@@ -0,0 +1,94 @@
+package texheaders
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualEntries(t *testing.T) {
+	t.Parallel()
+
+	a := TextureEntry{
+		PAAFile:       `data\a_co.paa`,
+		AverageColorF: [4]float32{0.5, 0.5, 0.5, 1},
+		PaxFormat:     PaxFormatDXT1,
+		PaxFileSize:   100,
+		MipMaps:       []MipMap{{Width: 4, Height: 4, AlwaysThree: 3}},
+	}
+
+	b := a
+	b.MipMaps = append([]MipMap(nil), a.MipMaps...)
+	if !EqualEntries(&a, &b, EqualOptions{}) {
+		t.Fatal("EqualEntries(copy) = false")
+	}
+
+	b.AverageColorF[0] = 0.5001
+	if EqualEntries(&a, &b, EqualOptions{}) {
+		t.Fatal("EqualEntries() must be exact without epsilon")
+	}
+
+	if !EqualEntries(&a, &b, EqualOptions{ColorEpsilon: 0.001}) {
+		t.Fatal("EqualEntries() must accept color within epsilon")
+	}
+
+	b.PAAFile = "DATA/A_CO.paa"
+	if EqualEntries(&a, &b, EqualOptions{ColorEpsilon: 0.001}) {
+		t.Fatal("EqualEntries() must compare path case by default")
+	}
+
+	if !EqualEntries(&a, &b, EqualOptions{ColorEpsilon: 0.001, IgnorePathCase: true}) {
+		t.Fatal("EqualEntries(IgnorePathCase) = false")
+	}
+
+	b.PaxFileSize = 200
+	b.MipMaps[0].Width = 8
+	opts := EqualOptions{ColorEpsilon: 0.001, IgnorePathCase: true, IgnoreFields: []string{"pax_file_size"}}
+	if EqualEntries(&a, &b, opts) {
+		t.Fatal("EqualEntries() must compare mipmaps")
+	}
+
+	opts.IgnoreFields = append(opts.IgnoreFields, "MipMaps")
+	if !EqualEntries(&a, &b, opts) {
+		t.Fatal("EqualEntries() must skip ignored fields")
+	}
+}
+
+func TestEqualEntries_NaN(t *testing.T) {
+	t.Parallel()
+
+	nan := float32(math.NaN())
+	a := TextureEntry{AverageColorF: [4]float32{0.5, 0.5, 0.5, 1}}
+	b := a
+	b.AverageColorF[1] = nan
+	for _, opts := range []EqualOptions{{}, {ColorEpsilon: 1}} {
+		if EqualEntries(&a, &b, opts) || EqualEntries(&b, &a, opts) {
+			t.Fatalf("EqualEntries(NaN, number, %+v) = true", opts)
+		}
+	}
+
+	a.AverageColorF[1] = nan
+	if !EqualEntries(&a, &b, EqualOptions{}) {
+		t.Fatal("EqualEntries(NaN, NaN) = false")
+	}
+}
+
+func TestEqualFiles(t *testing.T) {
+	t.Parallel()
+
+	a := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{{PAAFile: "a_co.paa"}, {PAAFile: "b_co.paa"}}}
+	b := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{{PAAFile: "a_co.paa"}, {PAAFile: "b_co.paa"}}}
+
+	if !EqualFiles(a, b, EqualOptions{}) || !EqualFiles(nil, nil, EqualOptions{}) || EqualFiles(a, nil, EqualOptions{}) {
+		t.Fatal("EqualFiles() basic cases failed")
+	}
+
+	b.Version = 2
+	if EqualFiles(a, b, EqualOptions{}) || !EqualFiles(a, b, EqualOptions{IgnoreFields: []string{"version"}}) {
+		t.Fatal("EqualFiles() version handling failed")
+	}
+
+	b.Textures = b.Textures[:1]
+	if EqualFiles(a, b, EqualOptions{IgnoreFields: []string{"version"}}) {
+		t.Fatal("EqualFiles() must compare entry count")
+	}
+}
@@ -17,12 +17,58 @@ var (
 	ErrTooManyTextures = errors.New("too many texture entries")
 	// ErrUnsupportedInputFormat means source texture extension is not supported.
 	ErrUnsupportedInputFormat = errors.New("unsupported input texture format")
-	// ErrPACUnsupported means .pac source support is not implemented yet.
+	// ErrUnsupportedPaxFormat means source texture uses pax format unknown to builder.
+	ErrUnsupportedPaxFormat = errors.New("unsupported pax format")
+	// ErrUnknownSuffixType means suffix type name cannot be parsed.
+	ErrUnknownSuffixType = errors.New("unknown suffix type")
+	// ErrCorruptSource means source texture headers cannot be decoded.
+	ErrCorruptSource = errors.New("corrupt source texture")
+	// ErrOutOfRange means value does not fit its texheaders field.
+	ErrOutOfRange = errors.New("value out of range")
+	// ErrPACUnsupported means .pac source support is not implemented.
+	//
+	// Deprecated: .pac sources are supported; the builder no longer returns this error.
 	ErrPACUnsupported = errors.New(".pac source is not supported")
 	// ErrEmptyInputPath means builder input path is empty or whitespace.
 	ErrEmptyInputPath = errors.New("empty input path")
+	// ErrInputNotFound means builder has no registered input with given path.
+	ErrInputNotFound = errors.New("input not found")
+	// ErrPathEscapesRoot means stored entry path is absolute or escapes the base directory.
+	ErrPathEscapesRoot = errors.New("path escapes base directory")
+	// ErrSymlink means directory scan found a link while SymlinkError policy is set.
+	ErrSymlink = errors.New("symlink is not allowed")
+	// ErrInvalidPAC means palettized .pac source is malformed.
+	ErrInvalidPAC = errors.New("invalid pac source")
+	// ErrInvalidPBO means PBO archive header is malformed.
+	ErrInvalidPBO = errors.New("invalid pbo archive")
+	// ErrPBOPacked means PBO entry is compressed or encrypted and cannot be scanned.
+	ErrPBOPacked = errors.New("packed pbo entry is not supported")
+	// ErrPBOIndexNotFound means PBO archive has no texHeaders.bin at its root.
+	ErrPBOIndexNotFound = errors.New("pbo has no texHeaders.bin")
+	// ErrTooManyIssues means SkipInvalid build skipped more inputs than BuildOptions.MaxIssues.
+	ErrTooManyIssues = errors.New("too many skipped inputs")
+	// ErrEntryNotFound means file has no entry with given path.
+	ErrEntryNotFound = errors.New("texture entry not found")
+	// ErrEntryExists means file already has an entry with given path.
+	ErrEntryExists = errors.New("texture entry already exists")
+	// ErrMergeConflict means merged files have different entries with the same path.
+	ErrMergeConflict = errors.New("merge conflict")
+	// ErrStaleEntry means texture entry no longer matches its source file.
+	ErrStaleEntry = errors.New("texture entry is stale")
 	// ErrNilFile means Write received a nil file model.
 	ErrNilFile = errors.New("file is nil")
 	// ErrValidation means semantic model validation failed.
 	ErrValidation = errors.New("texheaders validation failed")
+	// ErrInvalidSuffixRules means suffix rules config is malformed.
+	ErrInvalidSuffixRules = errors.New("invalid suffix rules config")
+	// ErrInvalidBuildOptions means build options config is malformed.
+	ErrInvalidBuildOptions = errors.New("invalid build options config")
+	// ErrInvalidQuery means Query has malformed path pattern or empty range.
+	ErrInvalidQuery = errors.New("invalid query")
+	// ErrInvalidTexConvert means TexConvert.cfg could not be parsed.
+	ErrInvalidTexConvert = errors.New("invalid TexConvert.cfg")
+	// ErrInvalidYAML means YAML input is malformed or outside supported subset.
+	ErrInvalidYAML = errors.New("invalid YAML")
+	// ErrUnsupportedTextFormat means Export or Import got unknown TextFormat.
+	ErrUnsupportedTextFormat = errors.New("unsupported text format")
 )
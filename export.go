@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// TextFormat names text encoding of File for Export and Import.
+type TextFormat string
+
+// Supported text formats.
+const (
+	// TextFormatJSON is indented JSON.
+	TextFormatJSON TextFormat = "json"
+	// TextFormatYAML is block-style YAML.
+	TextFormatYAML TextFormat = "yaml"
+)
+
+// TextFormatFromPath returns text format implied by file extension
+// (.json, .yaml or .yml); ok is false for other extensions.
+func TextFormatFromPath(path string) (TextFormat, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return TextFormatJSON, true
+	case ".yaml", ".yml":
+		return TextFormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+// Export writes f in text format. The encoding keeps every binary field,
+// so Import followed by Write reproduces the original file bytes.
+func Export(w io.Writer, f *File, format TextFormat) error {
+	if f == nil {
+		return ErrNilFile
+	}
+
+	return EncodeText(w, f, format)
+}
+
+// EncodeText writes any JSON-marshalable value in text format, using the
+// same layout as Export; it lets tools print reports as JSON or YAML.
+func EncodeText(w io.Writer, v any, format TextFormat) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	switch format {
+	case TextFormatJSON:
+		data = append(data, '\n')
+	case TextFormatYAML:
+		if data, err = jsonToYAML(data); err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedTextFormat, format)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Import decodes File written by Export. Unknown fields are rejected so
+// typos in edited files are not silently dropped.
+func Import(r io.Reader, format TextFormat) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case TextFormatJSON:
+	case TextFormatYAML:
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedTextFormat, format)
+	}
+
+	f := &File{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(f); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", format, err)
+	}
+
+	return f, nil
+}
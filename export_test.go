@@ -0,0 +1,122 @@
+package texheaders
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	f, err := Read(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	f.Textures[0].PAAFile = `data\it's "quoted" # not comment.paa`
+	f.Textures[1].PAAFile = "123"
+	f.Textures[2].AverageColorF[0] = float32(math.Pi)
+	var want bytes.Buffer
+	if err := Write(&want, f); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	for _, format := range []TextFormat{TextFormatJSON, TextFormatYAML} {
+		var text bytes.Buffer
+		if err := Export(&text, f, format); err != nil {
+			t.Fatalf("Export(%s) error: %v", format, err)
+		}
+
+		got, err := Import(&text, format)
+		if err != nil {
+			t.Fatalf("Import(%s) error: %v", format, err)
+		}
+
+		var out bytes.Buffer
+		if err := Write(&out, got); err != nil {
+			t.Fatalf("Write(%s) error: %v", format, err)
+		}
+
+		if !bytes.Equal(out.Bytes(), want.Bytes()) {
+			t.Fatalf("%s round trip changed file bytes", format)
+		}
+	}
+}
+
+func TestExportYAMLLayout(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: SupportedVersion, Textures: []TextureEntry{{
+		PAAFile: `data\rock_co.paa`,
+		MipMaps: []MipMap{{Width: 4, Height: 4, AlwaysThree: 3}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, f, TextFormatYAML); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+
+	for _, want := range []string{"magic: 0DHT\n", "textures:\n  - paa_file: data\\rock_co.paa\n", "    mipmaps:\n      - width: 4\n", "    max_color: [0, 0, 0, 0]\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("Export() YAML missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	t.Parallel()
+
+	v := struct {
+		Name  string   `json:"name"`
+		Items []string `json:"items"`
+	}{Name: "report", Items: []string{}}
+
+	for format, want := range map[TextFormat]string{
+		TextFormatJSON: "{\n  \"name\": \"report\",\n  \"items\": []\n}\n",
+		TextFormatYAML: "name: report\nitems: []\n",
+	} {
+		var buf bytes.Buffer
+		if err := EncodeText(&buf, v, format); err != nil {
+			t.Fatalf("EncodeText(%s) error: %v", format, err)
+		}
+
+		if buf.String() != want {
+			t.Fatalf("EncodeText(%s) = %q, want %q", format, buf.String(), want)
+		}
+	}
+}
+
+func TestExportImportErrors(t *testing.T) {
+	t.Parallel()
+
+	if err := Export(&bytes.Buffer{}, nil, TextFormatJSON); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("Export(nil) error = %v", err)
+	}
+
+	if err := Export(&bytes.Buffer{}, &File{}, "toml"); !errors.Is(err, ErrUnsupportedTextFormat) {
+		t.Fatalf("Export(toml) error = %v", err)
+	}
+
+	if _, err := Import(strings.NewReader("{}"), "toml"); !errors.Is(err, ErrUnsupportedTextFormat) {
+		t.Fatalf("Import(toml) error = %v", err)
+	}
+
+	if _, err := Import(strings.NewReader(`{"textures":[{"paa_fiel":"x"}]}`), TextFormatJSON); err == nil {
+		t.Fatal("Import(unknown field) expected error")
+	}
+
+	for path, want := range map[string]TextFormat{"a.JSON": TextFormatJSON, "a.yml": TextFormatYAML, "a.yaml": TextFormatYAML, "a.bin": ""} {
+		if got, ok := TextFormatFromPath(path); got != want || ok != (want != "") {
+			t.Fatalf("TextFormatFromPath(%s) = %q, %v", path, got, ok)
+		}
+	}
+}
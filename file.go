@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// IndexOptions controls path normalization of File.IndexWith keys.
+//
+// By default keys are lowercase with backslash separators and without
+// leading separator or "./" prefix, matching how the engine resolves paths.
+type IndexOptions struct {
+	// CaseSensitive keeps path case in keys.
+	CaseSensitive bool `json:"case_sensitive,omitempty" yaml:"case_sensitive,omitempty"`
+	// KeepSeparators keeps forward slashes in keys.
+	KeepSeparators bool `json:"keep_separators,omitempty" yaml:"keep_separators,omitempty"`
+}
+
+// Index returns entries keyed by normalized path with default IndexOptions.
+func (f *File) Index() map[string]*TextureEntry {
+	return f.IndexWith(IndexOptions{})
+}
+
+// IndexWith returns entries keyed by path normalized with opts.
+//
+// Keys point into f.Textures and stay valid until the slice is reallocated.
+// When several entries share a key, the first one wins.
+func (f *File) IndexWith(opts IndexOptions) map[string]*TextureEntry {
+	out := make(map[string]*TextureEntry, len(f.Textures))
+	for i := range f.Textures {
+		key := indexKey(f.Textures[i].PAAFile, opts)
+		if _, ok := out[key]; !ok {
+			out[key] = &f.Textures[i]
+		}
+	}
+
+	return out
+}
+
+// FindByPath returns the first entry whose path matches path after default
+// normalization (case-insensitive, any separator).
+func (f *File) FindByPath(path string) (*TextureEntry, bool) {
+	key := indexKey(path, IndexOptions{})
+	for i := range f.Textures {
+		if indexKey(f.Textures[i].PAAFile, IndexOptions{}) == key {
+			return &f.Textures[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// indexKey normalizes entry path for lookups.
+func indexKey(path string, opts IndexOptions) string {
+	key := strings.TrimSpace(path)
+	if !opts.KeepSeparators {
+		key = strings.ReplaceAll(key, "/", "\\")
+	}
+
+	key = strings.TrimPrefix(strings.TrimPrefix(key, ".\\"), "./")
+	key = strings.TrimLeft(key, "\\/")
+	if !opts.CaseSensitive {
+		key = strings.ToLower(key)
+	}
+
+	return key
+}
+
+// KeepPolicy selects which of entries with identical normalized path Dedupe keeps.
+type KeepPolicy int
+
+// Dedupe keep policies.
+const (
+	// KeepFirst keeps the first entry in file order.
+	KeepFirst KeepPolicy = iota
+	// KeepLast keeps the last entry in file order.
+	KeepLast
+	// KeepLargest keeps the entry with largest PaxFileSize, the first one on ties.
+	KeepLargest
+)
+
+// Dedupe removes entries whose path matches another one after default
+// normalization and returns the removed entries.
+//
+// The kept entry, chosen by keep, takes the place of the first occurrence,
+// so order of distinct paths is preserved.
+func (f *File) Dedupe(keep KeepPolicy) []TextureEntry {
+	slot := make(map[string]int, len(f.Textures))
+	kept := make([]TextureEntry, 0, len(f.Textures))
+	var dropped []TextureEntry
+
+	for _, e := range f.Textures {
+		key := indexKey(e.PAAFile, IndexOptions{})
+		i, seen := slot[key]
+		if !seen {
+			slot[key] = len(kept)
+			kept = append(kept, e)
+			continue
+		}
+
+		if keep == KeepLast || (keep == KeepLargest && e.PaxFileSize > kept[i].PaxFileSize) {
+			e, kept[i] = kept[i], e
+		}
+
+		dropped = append(dropped, e)
+	}
+
+	f.Textures = kept
+	return dropped
+}
+
+// Upsert replaces all entries matching entry path after default normalization
+// with entry, or appends it when no entry matches. It reports whether an
+// existing entry was replaced. Entry path is stored normalized (lowercase,
+// backslash separators).
+func (f *File) Upsert(entry TextureEntry) bool {
+	key := indexKey(entry.PAAFile, IndexOptions{})
+	entry.PAAFile = key
+	replaced := false
+	out := f.Textures[:0]
+	for _, e := range f.Textures {
+		if indexKey(e.PAAFile, IndexOptions{}) != key {
+			out = append(out, e)
+			continue
+		}
+
+		if !replaced {
+			out = append(out, entry)
+			replaced = true
+		}
+	}
+
+	clear(f.Textures[len(out):])
+	f.Textures = out
+	if !replaced {
+		f.Textures = append(f.Textures, entry)
+	}
+
+	return replaced
+}
+
+// Remove deletes all entries matching path after default normalization and
+// reports whether any entry was removed.
+func (f *File) Remove(path string) bool {
+	key := indexKey(path, IndexOptions{})
+	n := len(f.Textures)
+	f.Textures = slices.DeleteFunc(f.Textures, func(e TextureEntry) bool {
+		return indexKey(e.PAAFile, IndexOptions{}) == key
+	})
+
+	return len(f.Textures) != n
+}
+
+// Rename sets path of entries matching oldPath to newPath, stored
+// normalized (lowercase, backslash separators).
+//
+// It fails with ErrEntryNotFound when no entry matches oldPath and with
+// ErrEntryExists when another entry already uses newPath. Renaming to a path
+// differing only in case or separators normalizes the stored path.
+func (f *File) Rename(oldPath, newPath string) error {
+	oldKey := indexKey(oldPath, IndexOptions{})
+	newKey := indexKey(newPath, IndexOptions{})
+
+	found := false
+	for i := range f.Textures {
+		key := indexKey(f.Textures[i].PAAFile, IndexOptions{})
+		if key == newKey && key != oldKey {
+			return fmt.Errorf("%w: %s", ErrEntryExists, newPath)
+		}
+
+		found = found || key == oldKey
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrEntryNotFound, oldPath)
+	}
+
+	for i := range f.Textures {
+		if indexKey(f.Textures[i].PAAFile, IndexOptions{}) == oldKey {
+			f.Textures[i].PAAFile = newKey
+		}
+	}
+
+	return nil
+}
+
+// SortByPath sorts entries by default-normalized path, the order official
+// tools produce; use it after Upsert or Rename to keep a sorted index.
+func (f *File) SortByPath() {
+	slices.SortStableFunc(f.Textures, func(a, b TextureEntry) int {
+		return strings.Compare(indexKey(a.PAAFile, IndexOptions{}), indexKey(b.PAAFile, IndexOptions{}))
+	})
+}
+
+// Filter returns a new file with copies of entries for which keep returns
+// true, in original order. Magic and version are carried over.
+func (f *File) Filter(keep func(*TextureEntry) bool) *File {
+	out := &File{Magic: f.Magic, Version: f.Version}
+	for i := range f.Textures {
+		if keep(&f.Textures[i]) {
+			out.Textures = append(out.Textures, cloneEntry(&f.Textures[i]))
+		}
+	}
+
+	return out
+}
+
+// All returns iterator over entry indexes and pointers into f.Textures.
+func (f *File) All() iter.Seq2[int, *TextureEntry] {
+	return func(yield func(int, *TextureEntry) bool) {
+		for i := range f.Textures {
+			if !yield(i, &f.Textures[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Paths returns iterator over stored entry paths in file order.
+func (f *File) Paths() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := range f.Textures {
+			if !yield(f.Textures[i].PAAFile) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns deep copy of f, including MipMaps of every entry, or nil for nil file.
+func (f *File) Clone() *File {
+	if f == nil {
+		return nil
+	}
+
+	out := &File{Magic: f.Magic, Version: f.Version}
+	if f.Textures != nil {
+		out.Textures = make([]TextureEntry, len(f.Textures))
+		for i := range f.Textures {
+			out.Textures[i] = cloneEntry(&f.Textures[i])
+		}
+	}
+
+	return out
+}
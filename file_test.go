@@ -0,0 +1,231 @@
+package texheaders
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFileIndex(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `MyMod\Data\A_co.paa`, PaxFormat: PaxFormatDXT1},
+		{PAAFile: "mymod/data/b_nohq.paa"},
+		{PAAFile: `mymod\data\a_co.paa`, PaxFormat: PaxFormatDXT5},
+	}}
+
+	idx := f.Index()
+	if len(idx) != 2 {
+		t.Fatalf("Index() len = %d, want 2", len(idx))
+	}
+
+	if e := idx[`mymod\data\a_co.paa`]; e != &f.Textures[0] {
+		t.Fatalf("Index() duplicate key must keep first entry, got %+v", e)
+	}
+
+	if _, ok := idx[`mymod\data\b_nohq.paa`]; !ok {
+		t.Fatal("Index() must normalize forward slashes")
+	}
+
+	exact := f.IndexWith(IndexOptions{CaseSensitive: true, KeepSeparators: true})
+	if len(exact) != 3 || exact["mymod/data/b_nohq.paa"] != &f.Textures[1] {
+		t.Fatalf("IndexWith(exact) = %v", exact)
+	}
+}
+
+func TestFileFindByPath(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `mymod\data\a_co.paa`},
+		{PAAFile: `mymod\data\b_nohq.paa`},
+	}}
+
+	for _, path := range []string{`mymod\data\b_nohq.paa`, "MyMod/Data/B_NOHQ.paa", `\mymod\data\b_nohq.paa`, "./mymod/data/b_nohq.paa"} {
+		e, ok := f.FindByPath(path)
+		if !ok || e != &f.Textures[1] {
+			t.Fatalf("FindByPath(%q) = %v, %v", path, e, ok)
+		}
+	}
+
+	if _, ok := f.FindByPath("missing_co.paa"); ok {
+		t.Fatal("FindByPath(missing) = true, want false")
+	}
+}
+
+func TestFileDedupe(t *testing.T) {
+	t.Parallel()
+
+	entries := []TextureEntry{
+		{PAAFile: `a_co.paa`, PaxFileSize: 10},
+		{PAAFile: `b_co.paa`, PaxFileSize: 5},
+		{PAAFile: `A_CO.paa`, PaxFileSize: 30},
+		{PAAFile: `a_co.paa`, PaxFileSize: 20},
+	}
+
+	tests := []struct {
+		keep     KeepPolicy
+		wantSize uint32
+		dropped  []uint32
+	}{
+		{keep: KeepFirst, wantSize: 10, dropped: []uint32{30, 20}},
+		{keep: KeepLast, wantSize: 20, dropped: []uint32{10, 30}},
+		{keep: KeepLargest, wantSize: 30, dropped: []uint32{10, 20}},
+	}
+
+	for _, tt := range tests {
+		f := &File{Textures: append([]TextureEntry(nil), entries...)}
+		dropped := f.Dedupe(tt.keep)
+
+		if len(f.Textures) != 2 || f.Textures[0].PaxFileSize != tt.wantSize || f.Textures[1].PAAFile != "b_co.paa" {
+			t.Fatalf("Dedupe(%d) textures = %+v", tt.keep, f.Textures)
+		}
+
+		if len(dropped) != len(tt.dropped) {
+			t.Fatalf("Dedupe(%d) dropped = %d, want %d", tt.keep, len(dropped), len(tt.dropped))
+		}
+
+		for i, size := range tt.dropped {
+			if dropped[i].PaxFileSize != size {
+				t.Fatalf("Dedupe(%d) dropped[%d] size = %d, want %d", tt.keep, i, dropped[i].PaxFileSize, size)
+			}
+		}
+	}
+}
+
+func TestFileUpsertRemoveRename(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `c_co.paa`},
+		{PAAFile: `a_co.paa`, PaxFileSize: 1},
+		{PAAFile: `A_CO.paa`, PaxFileSize: 2},
+	}}
+
+	if !f.Upsert(TextureEntry{PAAFile: "a_co.paa", PaxFileSize: 3}) {
+		t.Fatal("Upsert(existing) = false, want true")
+	}
+
+	if len(f.Textures) != 2 || f.Textures[1].PaxFileSize != 3 {
+		t.Fatalf("after Upsert(existing) textures = %+v", f.Textures)
+	}
+
+	if f.Upsert(TextureEntry{PAAFile: "B_CO.paa"}) || len(f.Textures) != 3 || f.Textures[2].PAAFile != "b_co.paa" {
+		t.Fatalf("Upsert(new) must append normalized, textures = %+v", f.Textures)
+	}
+
+	if err := f.Rename("B_CO.paa", "a_co.paa"); !errors.Is(err, ErrEntryExists) {
+		t.Fatalf("Rename(to existing) error = %v, want ErrEntryExists", err)
+	}
+
+	if err := f.Rename("missing.paa", "x.paa"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("Rename(missing) error = %v, want ErrEntryNotFound", err)
+	}
+
+	if err := f.Rename("c_co.paa", "D/C_co.paa"); err != nil {
+		t.Fatalf("Rename() error: %v", err)
+	}
+
+	f.Textures[2].PAAFile = "B_CO.paa"
+	if err := f.Rename("b_co.paa", "B_CO.paa"); err != nil {
+		t.Fatalf("Rename(case only) error: %v", err)
+	}
+
+	f.SortByPath()
+	want := []string{"a_co.paa", "b_co.paa", `d\c_co.paa`}
+	for i, path := range want {
+		if f.Textures[i].PAAFile != path {
+			t.Fatalf("texture[%d] = %q, want %q", i, f.Textures[i].PAAFile, path)
+		}
+	}
+
+	if !f.Remove("D/C_CO.PAA") || f.Remove("D/C_CO.PAA") || len(f.Textures) != 2 {
+		t.Fatalf("Remove() textures = %+v", f.Textures)
+	}
+}
+
+func TestFileFilter(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: SupportedVersion, Textures: []TextureEntry{
+		{PAAFile: "a_co.paa", PaxFormat: PaxFormatDXT5, MipMaps: []MipMap{{Width: 4, Height: 4}}},
+		{PAAFile: "b_nohq.paa", PaxFormat: PaxFormatDXT5},
+		{PAAFile: "c_co.paa", PaxFormat: PaxFormatDXT1},
+	}}
+
+	got := f.Filter(func(e *TextureEntry) bool { return e.PaxFormat == PaxFormatDXT5 })
+	if got.Magic != FileMagic || got.Version != SupportedVersion || len(got.Textures) != 2 {
+		t.Fatalf("Filter() = %+v", got)
+	}
+
+	if got.Textures[0].PAAFile != "a_co.paa" || got.Textures[1].PAAFile != "b_nohq.paa" {
+		t.Fatalf("Filter() order = %q, %q", got.Textures[0].PAAFile, got.Textures[1].PAAFile)
+	}
+
+	got.Textures[0].MipMaps[0].Width = 8
+	if f.Textures[0].MipMaps[0].Width != 4 {
+		t.Fatal("Filter() result shares mipmaps with source")
+	}
+
+	if empty := f.Filter(func(*TextureEntry) bool { return false }); len(empty.Textures) != 0 {
+		t.Fatalf("Filter(none) = %d entries", len(empty.Textures))
+	}
+}
+
+func TestFileIterators(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{{PAAFile: "a_co.paa"}, {PAAFile: "b_co.paa"}, {PAAFile: "c_co.paa"}}}
+
+	for i, e := range f.All() {
+		e.PaxFileSize = uint32(i + 1)
+	}
+
+	for i := range f.Textures {
+		if f.Textures[i].PaxFileSize != uint32(i+1) {
+			t.Fatalf("All() must yield pointers into Textures, texture[%d] = %+v", i, f.Textures[i])
+		}
+	}
+
+	paths := slices.Collect(f.Paths())
+	if !slices.Equal(paths, []string{"a_co.paa", "b_co.paa", "c_co.paa"}) {
+		t.Fatalf("Paths() = %v", paths)
+	}
+
+	for i := range f.All() {
+		if i == 1 {
+			break
+		}
+	}
+}
+
+func TestFileClone(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: "a_co.paa", MipMaps: []MipMap{{Width: 4, Height: 4}}},
+		{PAAFile: "b_co.paa"},
+	}}
+
+	c := f.Clone()
+	if !EqualFiles(f, c, EqualOptions{}) {
+		t.Fatalf("Clone() = %+v, want equal", c)
+	}
+
+	c.Textures[0].MipMaps[0].Width = 8
+	c.Textures[1].PAAFile = "x.paa"
+	if f.Textures[0].MipMaps[0].Width != 4 || f.Textures[1].PAAFile != "b_co.paa" {
+		t.Fatal("Clone() shares data with source")
+	}
+
+	e := f.Textures[0].Clone()
+	e.MipMaps[0].Height = 1
+	if f.Textures[0].MipMaps[0].Height != 4 {
+		t.Fatal("TextureEntry.Clone() shares mipmaps with source")
+	}
+
+	if (*File)(nil).Clone() != nil {
+		t.Fatal("Clone(nil) != nil")
+	}
+}
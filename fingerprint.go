@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fingerprintVersion is mixed into every fingerprint; bump it when the
+// canonical form changes.
+const fingerprintVersion = "texheaders-fingerprint-v1"
+
+// fingerprintRecord is canonical form of one entry with its sort key.
+type fingerprintRecord struct {
+	key  string
+	data []byte
+}
+
+// Fingerprint returns SHA-256 of canonical form of f.
+//
+// Entries are hashed in normalized path order, so reordering entries does
+// not change the result, while any change of a stored field does. Empty
+// magic and zero version hash as their defaults, and float colors hash by
+// bits with -0 folded to 0 and every NaN folded to one value. A nil file
+// hashes as an empty one.
+func Fingerprint(f *File) [32]byte {
+	if f == nil {
+		f = &File{}
+	}
+
+	records := make([]fingerprintRecord, len(f.Textures))
+	for i := range f.Textures {
+		records[i] = fingerprintRecord{
+			key:  indexKey(f.Textures[i].PAAFile, IndexOptions{}),
+			data: appendEntryFingerprint(nil, &f.Textures[i]),
+		}
+	}
+
+	slices.SortFunc(records, func(a, b fingerprintRecord) int {
+		if c := strings.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(a.data, b.data)
+	})
+
+	magic := f.Magic
+	if magic == "" {
+		magic = FileMagic
+	}
+
+	version := f.Version
+	if version == 0 {
+		version = SupportedVersion
+	}
+
+	h := sha256.New()
+	buf := appendFingerprintString(nil, fingerprintVersion)
+	buf = appendFingerprintString(buf, magic)
+	buf = binary.LittleEndian.AppendUint32(buf, version)
+	buf = strconv.AppendInt(buf, int64(len(records)), 10)
+	_, _ = h.Write(buf)
+
+	for i := range records {
+		_, _ = h.Write(records[i].data)
+	}
+
+	var sum [32]byte
+	h.Sum(sum[:0])
+	return sum
+}
+
+// appendEntryFingerprint appends canonical bytes of entry to buf.
+func appendEntryFingerprint(buf []byte, entry *TextureEntry) []byte {
+	buf = appendFingerprintString(buf, entry.PAAFile)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.ColorPaletteCount)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.PalettePtr)
+	for _, v := range entry.AverageColorF {
+		buf = binary.LittleEndian.AppendUint32(buf, canonicalFloatBits(v))
+	}
+
+	buf = append(buf, entry.AverageColor[:]...)
+	buf = append(buf, entry.MaxColor[:]...)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.ClampFlags)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.TransparentColor)
+	buf = appendFingerprintBools(buf, entry.HasMaxCtagg, entry.IsAlpha, entry.IsTransparent,
+		entry.IsAlphaNonOpaque, entry.LittleEndian, entry.IsPAA)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.MipMapCount)
+	buf = binary.LittleEndian.AppendUint32(buf, entry.MipMapCountCopy)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(entry.PaxFormat))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(entry.PaxSuffixType))
+	buf = binary.LittleEndian.AppendUint32(buf, entry.PaxFileSize)
+
+	buf = strconv.AppendInt(buf, int64(len(entry.MipMaps)), 10)
+	buf = append(buf, ':')
+	for i := range entry.MipMaps {
+		m := &entry.MipMaps[i]
+		buf = binary.LittleEndian.AppendUint16(buf, m.Width)
+		buf = binary.LittleEndian.AppendUint16(buf, m.Height)
+		buf = binary.LittleEndian.AppendUint16(buf, m.AlwaysZero)
+		buf = append(buf, m.PaxFormat, m.AlwaysThree)
+		buf = binary.LittleEndian.AppendUint32(buf, m.DataOffset)
+	}
+
+	return buf
+}
+
+// appendFingerprintString appends length-prefixed string to buf.
+func appendFingerprintString(buf []byte, s string) []byte {
+	buf = strconv.AppendInt(buf, int64(len(s)), 10)
+	buf = append(buf, ':')
+	return append(buf, s...)
+}
+
+// appendFingerprintBools appends flags packed into one byte.
+func appendFingerprintBools(buf []byte, flags ...bool) []byte {
+	var b byte
+	for i, v := range flags {
+		if v {
+			b |= 1 << i
+		}
+	}
+
+	return append(buf, b)
+}
+
+// canonicalFloatBits returns float bits with -0 and NaN payloads folded.
+func canonicalFloatBits(v float32) uint32 {
+	switch {
+	case v == 0:
+		return 0
+	case math.IsNaN(float64(v)):
+		return 0x7FC00000
+	default:
+		return math.Float32bits(v)
+	}
+}
@@ -0,0 +1,48 @@
+package texheaders
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: `data\a_co.paa`, MipMaps: []MipMap{{Width: 4, Height: 4, AlwaysThree: 3}}},
+		{PAAFile: `data\b_co.paa`, AverageColorF: [4]float32{0, 0.5, 0, 1}},
+	}}
+	sum := Fingerprint(base)
+
+	reordered := base.Clone()
+	reordered.Textures[0], reordered.Textures[1] = reordered.Textures[1], reordered.Textures[0]
+	reordered.Magic, reordered.Version = "", 0
+	reordered.Textures[0].AverageColorF[0] = float32(math.Copysign(0, -1))
+	if Fingerprint(reordered) != sum {
+		t.Fatal("Fingerprint() depends on entry order, defaults or zero sign")
+	}
+
+	changed := base.Clone()
+	changed.Textures[0].MipMaps[0].DataOffset = 1
+	if Fingerprint(changed) == sum {
+		t.Fatal("Fingerprint() ignores mip change")
+	}
+
+	renamed := base.Clone()
+	renamed.Textures[1].PAAFile = `data\B_co.paa`
+	if Fingerprint(renamed) == sum {
+		t.Fatal("Fingerprint() ignores path case change")
+	}
+
+	nan := base.Clone()
+	nan.Textures[1].AverageColorF[0] = float32(math.NaN())
+	other := nan.Clone()
+	other.Textures[1].AverageColorF[0] = math.Float32frombits(0x7FC00001)
+	if Fingerprint(nan) != Fingerprint(other) {
+		t.Fatal("Fingerprint() depends on NaN payload")
+	}
+
+	if Fingerprint(nil) != Fingerprint(&File{}) {
+		t.Fatal("Fingerprint(nil) differs from empty file")
+	}
+}
@@ -2,10 +2,12 @@ module github.com/woozymasta/texheaders
 
 go 1.25.5
 
-require github.com/woozymasta/paa v0.2.2
+require (
+	github.com/woozymasta/bcn v0.1.5
+	github.com/woozymasta/paa v0.2.2
+)
 
 require (
-	github.com/woozymasta/bcn v0.1.5 // indirect
 	github.com/woozymasta/lzo v0.2.0 // indirect
 	github.com/woozymasta/lzss v0.1.5 // indirect
 )
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"io/fs"
+	"os"
+)
+
+// newFileLimit returns semaphore for BuildOptions.MaxOpenFiles, nil when unlimited.
+func newFileLimit(maxOpen int) chan struct{} {
+	if maxOpen <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, maxOpen)
+}
+
+// openSource opens source file within BuildOptions.MaxOpenFiles limit.
+// The file must be closed with closeSource to release its slot.
+func (b *Builder) openSource(path string) (*os.File, error) {
+	b.acquireFile()
+	fh, err := os.Open(ioPath(path))
+	if err != nil {
+		b.releaseFile()
+		return nil, err
+	}
+
+	return fh, nil
+}
+
+// closeSource closes file opened by openSource and releases its slot.
+func (b *Builder) closeSource(fh *os.File) {
+	_ = fh.Close()
+	b.releaseFile()
+}
+
+// statSource stats source file within BuildOptions.MaxOpenFiles limit.
+func (b *Builder) statSource(path string) (os.FileInfo, error) {
+	b.acquireFile()
+	defer b.releaseFile()
+
+	return os.Stat(ioPath(path))
+}
+
+// inputInfo returns caller-supplied file info of input or stats its source.
+func (b *Builder) inputInfo(path string) (fs.FileInfo, error) {
+	if info, ok := b.infos[path]; ok {
+		return info, nil
+	}
+
+	return b.statSource(path)
+}
+
+// acquireFile blocks until file slot is available.
+func (b *Builder) acquireFile() {
+	if b.files != nil {
+		b.files <- struct{}{}
+	}
+}
+
+// releaseFile returns file slot.
+func (b *Builder) releaseFile() {
+	if b.files != nil {
+		<-b.files
+	}
+}
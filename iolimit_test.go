@@ -0,0 +1,58 @@
+package texheaders
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_MaxOpenFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	paths := make([]string, 0, 12)
+	for i := range 12 {
+		paths = append(paths, copyFixture(t, "test_co.paa", dir, fmt.Sprintf("t%02d_co.paa", i)))
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, Workers: 8, MaxOpenFiles: 2, DetectDuplicates: true})
+	if err := b.AppendMany(paths...); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(got.Textures) != len(paths) {
+		t.Fatalf("textures = %d, want %d", len(got.Textures), len(paths))
+	}
+
+	if cap(b.files) != 2 || len(b.files) != 0 {
+		t.Fatalf("file limit cap=%d len=%d, want cap 2 and all slots released", cap(b.files), len(b.files))
+	}
+
+	if c := b.Clone(); cap(c.files) != 2 || c.files == b.files {
+		t.Fatal("Clone() must create its own file limit")
+	}
+}
+
+func TestBuilder_OpenSourceReleasesOnError(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{MaxOpenFiles: 1})
+	for range 3 {
+		if _, err := b.openSource(filepath.Join(t.TempDir(), "missing.paa")); err == nil {
+			t.Fatal("openSource() expected error")
+		}
+	}
+
+	if len(b.files) != 0 {
+		t.Fatalf("file slots in use = %d, want 0", len(b.files))
+	}
+
+	if NewBuilder(BuildOptions{}).files != nil {
+		t.Fatal("zero MaxOpenFiles must not limit files")
+	}
+}
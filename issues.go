@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// IssueKind is the category of BuildIssue.
+type IssueKind string
+
+// Failure categories of skipped inputs.
+const (
+	// IssueNotFound means source file does not exist.
+	IssueNotFound IssueKind = "not_found"
+	// IssueUnsupportedFormat means source extension, packing or pax format is not supported.
+	IssueUnsupportedFormat IssueKind = "unsupported_format"
+	// IssueCorruptPAA means source texture headers are malformed or truncated.
+	IssueCorruptPAA IssueKind = "corrupt_paa"
+	// IssueOutOfRange means source value does not fit texheaders field.
+	IssueOutOfRange IssueKind = "out_of_range"
+	// IssueIO means source file could not be opened or read.
+	IssueIO IssueKind = "io"
+	// IssuePathEscape means stored path escapes the base directory in StrictPaths mode.
+	IssuePathEscape IssueKind = "path_escape"
+	// IssueOther means error does not match any known category.
+	IssueOther IssueKind = "other"
+)
+
+// Warning categories of built entries.
+const (
+	// IssueNoMipmaps means entry has no mipmaps.
+	IssueNoMipmaps IssueKind = "no_mipmaps"
+	// IssueNonPowerOfTwo means top mip dimensions are not power of two.
+	IssueNonPowerOfTwo IssueKind = "non_power_of_two"
+	// IssueOversized means top mip exceeds BuildOptions.MaxTextureSize.
+	IssueOversized IssueKind = "oversized"
+	// IssueBrokenMipChain means mip level is not half of previous one.
+	IssueBrokenMipChain IssueKind = "broken_mip_chain"
+	// IssuePlaceholder means missing source was replaced by placeholder entry.
+	IssuePlaceholder IssueKind = "placeholder"
+)
+
+// newBuildIssue creates issue for skipped input with categorized error.
+func newBuildIssue(path string, err error) BuildIssue {
+	return BuildIssue{
+		Path:  path,
+		Error: err.Error(),
+		Err:   err,
+		Kind:  classifyIssue(err),
+	}
+}
+
+// classifyIssue maps build error to issue category.
+func classifyIssue(err error) IssueKind {
+	var pathErr *fs.PathError
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return IssueNotFound
+	case errors.Is(err, ErrUnsupportedInputFormat),
+		errors.Is(err, ErrUnsupportedPaxFormat),
+		errors.Is(err, ErrPBOPacked):
+		return IssueUnsupportedFormat
+	case errors.Is(err, ErrCorruptSource),
+		errors.Is(err, ErrInvalidPAC),
+		errors.Is(err, ErrInvalidPBO),
+		errors.Is(err, errInvalidSourceImage),
+		errors.Is(err, image.ErrFormat),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return IssueCorruptPAA
+	case errors.Is(err, ErrOutOfRange):
+		return IssueOutOfRange
+	case errors.Is(err, ErrPathEscapesRoot):
+		return IssuePathEscape
+	case errors.As(err, &pathErr):
+		return IssueIO
+	default:
+		return IssueOther
+	}
+}
+
+// BuildError aggregates all failed inputs of a CollectErrors build.
+type BuildError struct {
+	// Failures holds failed inputs in build order.
+	Failures []BuildIssue `json:"failures,omitempty" yaml:"failures,omitempty"`
+	// Truncated reports that build stopped at BuildOptions.MaxErrors.
+	Truncated bool `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// Error implements error, listing one failure per line.
+func (e *BuildError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "build failed for %d inputs", len(e.Failures))
+	if e.Truncated {
+		sb.WriteString(" (stopped at error limit)")
+	}
+
+	for _, f := range e.Failures {
+		fmt.Fprintf(&sb, "\n%s: %s", f.Path, f.Error)
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns errors of all failures for errors.Is and errors.As.
+func (e *BuildError) Unwrap() []error {
+	out := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		if f.Err != nil {
+			out = append(out, f.Err)
+		}
+	}
+
+	return out
+}
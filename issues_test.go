@@ -0,0 +1,143 @@
+package texheaders
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyIssue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want IssueKind
+	}{
+		{err: fmt.Errorf("open source: %w", &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}), want: IssueNotFound},
+		{err: fmt.Errorf("open source: %w", &os.PathError{Op: "open", Path: "x", Err: os.ErrPermission}), want: IssueIO},
+		{err: fmt.Errorf("%w: x.txt", ErrUnsupportedInputFormat), want: IssueUnsupportedFormat},
+		{err: fmt.Errorf("%w: 2", ErrUnsupportedPaxFormat), want: IssueUnsupportedFormat},
+		{err: fmt.Errorf("%w: scan: %w", ErrCorruptSource, io.EOF), want: IssueCorruptPAA},
+		{err: fmt.Errorf("%w: empty palette", ErrInvalidPAC), want: IssueCorruptPAA},
+		{err: fmt.Errorf("%w: uint32: -1", ErrOutOfRange), want: IssueOutOfRange},
+		{err: fmt.Errorf("%w: ..\\x_co.paa", ErrPathEscapesRoot), want: IssuePathEscape},
+		{err: errors.New("boom"), want: IssueOther},
+	}
+
+	for _, tt := range tests {
+		if got := classifyIssue(tt.err); got != tt.want {
+			t.Fatalf("classifyIssue(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_IssueKinds(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad_co.paa")
+	if err := os.WriteFile(bad, []byte{0x01, 0xFF, 'G'}, 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	missing := filepath.Join(dir, "missing_co.paa")
+	text := filepath.Join(dir, "notes.txt")
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, SkipInvalid: true})
+	if err := b.AppendMany(bad, missing, text); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	if _, err := b.Build(); err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	want := map[string]IssueKind{bad: IssueCorruptPAA, missing: IssueNotFound, text: IssueUnsupportedFormat}
+	issues := b.Issues()
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %+v, want %d", issues, len(want))
+	}
+
+	for _, issue := range issues {
+		if issue.Kind != want[issue.Path] || issue.Err == nil || issue.Warning {
+			t.Fatalf("issue %+v, want kind %q with error", issue, want[issue.Path])
+		}
+	}
+}
+
+func TestBuilder_CollectErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "ok_co.paa")
+	missing := []string{filepath.Join(dir, "a_co.paa"), filepath.Join(dir, "b_co.paa"), filepath.Join(dir, "c_co.paa")}
+
+	for _, workers := range []int{1, 2} {
+		for _, tt := range []struct {
+			maxErrors int
+			want      int
+			truncated bool
+		}{
+			{maxErrors: 0, want: 3},
+			{maxErrors: 2, want: 2, truncated: true},
+		} {
+			b := NewBuilder(BuildOptions{BaseDir: dir, CollectErrors: true, MaxErrors: tt.maxErrors, Workers: workers})
+			if err := b.AppendMany(append([]string{filepath.Join(dir, "ok_co.paa")}, missing...)...); err != nil {
+				t.Fatalf("AppendMany() error: %v", err)
+			}
+
+			_, err := b.Build()
+			var buildErr *BuildError
+			if !errors.As(err, &buildErr) {
+				t.Fatalf("Build() error = %v, want *BuildError", err)
+			}
+
+			if len(buildErr.Failures) != tt.want || buildErr.Truncated != tt.truncated {
+				t.Fatalf("workers=%d max=%d: failures=%d truncated=%v, want %d %v",
+					workers, tt.maxErrors, len(buildErr.Failures), buildErr.Truncated, tt.want, tt.truncated)
+			}
+
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("Build() error does not wrap %v", os.ErrNotExist)
+			}
+		}
+	}
+}
+
+func TestBuilder_MaxIssues(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "ok_co.paa")
+	inputs := []string{
+		filepath.Join(dir, "a_co.paa"),
+		filepath.Join(dir, "b_co.paa"),
+		filepath.Join(dir, "ok_co.paa"),
+	}
+
+	for _, tt := range []struct {
+		maxIssues int
+		fail      bool
+	}{
+		{maxIssues: 0},
+		{maxIssues: 2},
+		{maxIssues: 1, fail: true},
+	} {
+		b := NewBuilder(BuildOptions{BaseDir: dir, SkipInvalid: true, MaxIssues: tt.maxIssues})
+		if err := b.AppendMany(inputs...); err != nil {
+			t.Fatalf("AppendMany() error: %v", err)
+		}
+
+		_, err := b.Build()
+		if tt.fail != errors.Is(err, ErrTooManyIssues) {
+			t.Fatalf("MaxIssues=%d: Build() error = %v, want fail=%v", tt.maxIssues, err, tt.fail)
+		}
+
+		if tt.fail && !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Build() error %v does not wrap last failure", err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+//go:build !windows
+
+package texheaders
+
+// ioPath returns path usable for open and stat calls, unchanged outside Windows.
+func ioPath(path string) string {
+	return path
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+//go:build windows
+
+package texheaders
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// longPathPrefix marks Win32 extended-length path.
+	longPathPrefix = `\\?\`
+	// devicePathPrefix marks Win32 device namespace path.
+	devicePathPrefix = `\\.\`
+	// maxShortPath is the longest path safe for all Win32 file calls
+	// (MAX_PATH minus room for 8.3 file name).
+	maxShortPath = 248
+)
+
+// ioPath returns path usable for open and stat calls. Paths longer than
+// MAX_PATH are converted to absolute `\\?\` extended-length form.
+func ioPath(path string) string {
+	if strings.HasPrefix(path, longPathPrefix) || strings.HasPrefix(path, devicePathPrefix) {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil || len(abs) < maxShortPath {
+		return path
+	}
+
+	if strings.HasPrefix(abs, `\\`) {
+		return longPathPrefix + `UNC\` + abs[2:]
+	}
+
+	return longPathPrefix + abs
+}
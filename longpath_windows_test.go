@@ -0,0 +1,30 @@
+//go:build windows
+
+package texheaders
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIOPath(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("d", 250)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `C:\mod\a_co.paa`, want: `C:\mod\a_co.paa`},
+		{in: `C:\` + long + `\a_co.paa`, want: `\\?\C:\` + long + `\a_co.paa`},
+		{in: `C:/` + long + `/x/../a_co.paa`, want: `\\?\C:\` + long + `\a_co.paa`},
+		{in: `\\server\share\` + long + `\a_co.paa`, want: `\\?\UNC\server\share\` + long + `\a_co.paa`},
+		{in: `\\?\C:\` + long, want: `\\?\C:\` + long},
+	}
+
+	for _, tt := range tests {
+		if got := ioPath(tt.in); got != tt.want {
+			t.Fatalf("ioPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import "fmt"
+
+// MergeStrategy resolves entries with the same normalized path in Merge.
+type MergeStrategy int
+
+// Merge conflict strategies.
+const (
+	// MergeKeepFirst keeps the entry from the earliest file.
+	MergeKeepFirst MergeStrategy = iota
+	// MergeKeepLast keeps the entry from the latest file.
+	MergeKeepLast
+	// MergePreferLarger keeps the entry with largest PaxFileSize, the earliest on ties.
+	MergePreferLarger
+	// MergeError fails with ErrMergeConflict when entries with the same path differ.
+	MergeError
+)
+
+// Merge combines entries of files into a new file, resolving same-path
+// entries by strategy. Entries keep order of first appearance of their path.
+//
+// Identical entries never conflict. Input files are not modified.
+func Merge(strategy MergeStrategy, files ...*File) (*File, error) {
+	out := &File{Magic: FileMagic, Version: SupportedVersion}
+	for i, f := range files {
+		if f == nil {
+			return nil, fmt.Errorf("merge file %d: %w", i, ErrNilFile)
+		}
+
+		for j := range f.Textures {
+			out.Textures = append(out.Textures, cloneEntry(&f.Textures[j]))
+		}
+	}
+
+	keep := KeepFirst
+	switch strategy {
+	case MergeKeepLast:
+		keep = KeepLast
+	case MergePreferLarger:
+		keep = KeepLargest
+	case MergeError:
+		if err := checkMergeConflicts(out); err != nil {
+			return nil, err
+		}
+	}
+
+	out.Dedupe(keep)
+	return out, nil
+}
+
+// checkMergeConflicts returns ErrMergeConflict for the first path with differing entries.
+func checkMergeConflicts(f *File) error {
+	first := make(map[string]*TextureEntry, len(f.Textures))
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		key := indexKey(e.PAAFile, IndexOptions{})
+		prev, ok := first[key]
+		if !ok {
+			first[key] = e
+			continue
+		}
+
+		if field := firstEntryDiff(prev, e, &EqualOptions{IgnorePathCase: true}); field != "" {
+			return fmt.Errorf("%w: %s differs in %s", ErrMergeConflict, e.PAAFile, field)
+		}
+	}
+
+	return nil
+}
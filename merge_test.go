@@ -0,0 +1,55 @@
+package texheaders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	a := &File{Textures: []TextureEntry{{PAAFile: "a_co.paa", PaxFileSize: 10}, {PAAFile: "b_co.paa", PaxFileSize: 1}}}
+	b := &File{Textures: []TextureEntry{{PAAFile: "c_co.paa"}, {PAAFile: "A_CO.paa", PaxFileSize: 30}}}
+	c := &File{Textures: []TextureEntry{{PAAFile: "a_co.paa", PaxFileSize: 20}}}
+
+	tests := []struct {
+		strategy MergeStrategy
+		wantSize uint32
+	}{
+		{strategy: MergeKeepFirst, wantSize: 10},
+		{strategy: MergeKeepLast, wantSize: 20},
+		{strategy: MergePreferLarger, wantSize: 30},
+	}
+
+	for _, tt := range tests {
+		got, err := Merge(tt.strategy, a, b, c)
+		if err != nil {
+			t.Fatalf("Merge(%d) error: %v", tt.strategy, err)
+		}
+
+		if got.Magic != FileMagic || got.Version != SupportedVersion || len(got.Textures) != 3 {
+			t.Fatalf("Merge(%d) = %+v", tt.strategy, got)
+		}
+
+		if got.Textures[0].PaxFileSize != tt.wantSize || got.Textures[1].PAAFile != "b_co.paa" || got.Textures[2].PAAFile != "c_co.paa" {
+			t.Fatalf("Merge(%d) textures = %+v", tt.strategy, got.Textures)
+		}
+	}
+
+	if len(a.Textures) != 2 || a.Textures[0].PaxFileSize != 10 {
+		t.Fatal("Merge() modified input file")
+	}
+
+	if _, err := Merge(MergeError, a, b); !errors.Is(err, ErrMergeConflict) {
+		t.Fatalf("Merge(MergeError) error = %v, want ErrMergeConflict", err)
+	}
+
+	same := &File{Textures: []TextureEntry{{PAAFile: `B_CO.paa`, PaxFileSize: 1}}}
+	if got, err := Merge(MergeError, a, same); err != nil || len(got.Textures) != 2 {
+		t.Fatalf("Merge(MergeError, identical) = %v, %v", got, err)
+	}
+
+	if _, err := Merge(MergeKeepFirst, a, nil); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("Merge(nil) error = %v, want ErrNilFile", err)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// NormalizeOptions controls Normalize.
+type NormalizeOptions struct {
+	// KeepPathCase keeps entry path case instead of lowercasing it.
+	KeepPathCase bool `json:"keep_path_case,omitempty" yaml:"keep_path_case,omitempty"`
+	// KeepSeparators keeps forward slashes in entry paths.
+	KeepSeparators bool `json:"keep_separators,omitempty" yaml:"keep_separators,omitempty"`
+}
+
+// Normalize canonicalizes f in place so hand-made or imported models are
+// ready for Write:
+//   - fills empty magic and version;
+//   - lowercases paths and uses backslash separators (see opts);
+//   - sets MipMapCount and MipMapCountCopy from len(MipMaps);
+//   - derives AverageColorF from AverageColor;
+//   - fills default constants: LittleEndian, IsPAA by extension, palette
+//     count of non-palettized textures, transparent and missing max color,
+//     mip AlwaysZero, AlwaysThree and PaxFormat.
+func Normalize(f *File, opts NormalizeOptions) error {
+	if f == nil {
+		return ErrNilFile
+	}
+
+	if f.Magic == "" {
+		f.Magic = FileMagic
+	}
+
+	if f.Version == 0 {
+		f.Version = SupportedVersion
+	}
+
+	keyOpts := IndexOptions{CaseSensitive: opts.KeepPathCase, KeepSeparators: opts.KeepSeparators}
+	for i := range f.Textures {
+		if err := normalizeEntry(&f.Textures[i], keyOpts); err != nil {
+			return fmt.Errorf("normalize texture[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeEntry canonicalizes one entry.
+func normalizeEntry(e *TextureEntry, keyOpts IndexOptions) error {
+	mipCount, err := intToU32Strict(len(e.MipMaps))
+	if err != nil {
+		return err
+	}
+
+	e.PAAFile = indexKey(e.PAAFile, keyOpts)
+	e.MipMapCount = mipCount
+	e.MipMapCountCopy = mipCount
+	e.LittleEndian = true
+	e.IsPAA = strings.EqualFold(filepath.Ext(e.PAAFile), ".paa")
+	e.syncAverageColorF()
+
+	if e.PaxFormat != PaxFormatP8 && e.ColorPaletteCount == 0 {
+		e.ColorPaletteCount = 1
+	}
+
+	if e.TransparentColor == 0 {
+		e.TransparentColor = 0xFFFFFFFF
+	}
+
+	if !e.HasMaxCtagg && e.MaxColor == [4]byte{} {
+		e.MaxColor = [4]byte{0xFF, 0xFF, 0xFF, 0xFF}
+	}
+
+	mipFormat, fits := e.PaxFormat.mipFormat()
+	for j := range e.MipMaps {
+		m := &e.MipMaps[j]
+		m.AlwaysZero = 0
+		m.AlwaysThree = 3
+		if fits {
+			m.PaxFormat = mipFormat
+		}
+	}
+
+	return nil
+}
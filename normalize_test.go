@@ -0,0 +1,63 @@
+package texheaders
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{{
+		PAAFile:      "MyMod/Data/Gun_CO.paa",
+		PaxFormat:    PaxFormatDXT5,
+		AverageColor: [4]byte{0, 0, 255, 255},
+		MipMaps:      []MipMap{{Width: 4, Height: 4}, {Width: 2, Height: 2}},
+	}}}
+
+	if err := Normalize(f, NormalizeOptions{}); err != nil {
+		t.Fatalf("Normalize() error: %v", err)
+	}
+
+	e := f.Textures[0]
+	if f.Magic != FileMagic || f.Version != SupportedVersion || e.PAAFile != `mymod\data\gun_co.paa` {
+		t.Fatalf("Normalize() header/path = %q %d %q", f.Magic, f.Version, e.PAAFile)
+	}
+
+	if e.MipMapCount != 2 || e.MipMapCountCopy != 2 || !e.LittleEndian || !e.IsPAA || e.ColorPaletteCount != 1 {
+		t.Fatalf("Normalize() entry = %+v", e)
+	}
+
+	if e.AverageColorF != [4]float32{1, 0, 0, 1} || e.MaxColor != [4]byte{0xFF, 0xFF, 0xFF, 0xFF} || e.TransparentColor != 0xFFFFFFFF {
+		t.Fatalf("Normalize() colors = %v %v %x", e.AverageColorF, e.MaxColor, e.TransparentColor)
+	}
+
+	for i, m := range e.MipMaps {
+		if m.AlwaysThree != 3 || PaxFormat(m.PaxFormat) != PaxFormatDXT5 {
+			t.Fatalf("Normalize() mip[%d] = %+v", i, m)
+		}
+	}
+
+	if err := ValidateFile(f); err != nil {
+		t.Fatalf("ValidateFile(normalized) error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, f); err != nil {
+		t.Fatalf("Write(normalized) error: %v", err)
+	}
+
+	keep := &File{Textures: []TextureEntry{{PAAFile: "MyMod/Gun_co.paa"}}}
+	if err := Normalize(keep, NormalizeOptions{KeepPathCase: true, KeepSeparators: true}); err != nil {
+		t.Fatalf("Normalize(keep) error: %v", err)
+	}
+
+	if keep.Textures[0].PAAFile != "MyMod/Gun_co.paa" {
+		t.Fatalf("Normalize(keep) path = %q", keep.Textures[0].PAAFile)
+	}
+
+	if err := Normalize(nil, NormalizeOptions{}); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("Normalize(nil) error = %v, want ErrNilFile", err)
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/woozymasta/paa"
+)
+
+// PAC tagg names, stored reversed in file.
+const (
+	pacTaggMagic = "GGAT"
+	pacTaggAVGC  = "CGVA"
+	pacTaggMAXC  = "CXAM"
+	pacTaggFLAG  = "GALF"
+)
+
+// pacMaxTaggSize caps data length of known taggs, which hold 4 bytes.
+const pacMaxTaggSize = 16
+
+// pacHeaders is the metadata of one palettized .pac texture.
+type pacHeaders struct {
+	meta          paa.MetadataHeaders // meta holds colors, flags and mip headers.
+	mips          []pacMip            // mips holds mip records in file order.
+	paletteCount  uint32              // paletteCount is the number of palette colors.
+	paletteOffset uint32              // paletteOffset is the file offset of palette data.
+}
+
+// pacMip is one mip record of .pac stream.
+type pacMip struct {
+	header paa.MipHeader // header holds record offset and mip dimensions.
+	size   uint32        // size is the length of P8 payload after record header.
+}
+
+// decodePACHeaders reads taggs, palette block and mip headers of .pac stream.
+//
+// Unlike .paa, .pac files carry no leading type word; the stream starts with
+// optional taggs followed by palette and P8 mip data.
+func decodePACHeaders(r io.Reader) (*pacHeaders, error) {
+	cr := &countingReader{r: bufio.NewReader(r)}
+	d := decoder{r: cr, byteR: cr}
+	pac := &pacHeaders{}
+
+	for {
+		magic, err := cr.r.Peek(4)
+		if err != nil {
+			return nil, fmt.Errorf("%w: read tagg: %w", ErrInvalidPAC, err)
+		}
+
+		if string(magic) != pacTaggMagic {
+			break
+		}
+
+		if err = pac.readTagg(cr, &d); err != nil {
+			return nil, err
+		}
+	}
+
+	count, err := d.readU16()
+	if err != nil {
+		return nil, fmt.Errorf("%w: read palette count: %w", ErrInvalidPAC, err)
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("%w: empty palette", ErrInvalidPAC)
+	}
+
+	pac.paletteCount = uint32(count)
+	pac.paletteOffset = uint32(cr.n)
+	if _, err = io.CopyN(io.Discard, cr, int64(count)*3); err != nil {
+		return nil, fmt.Errorf("%w: read palette: %w", ErrInvalidPAC, err)
+	}
+
+	for {
+		offset := uint32(cr.n)
+		width, err := d.readU16()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read mip width: %w", ErrInvalidPAC, err)
+		}
+
+		height, err := d.readU16()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read mip height: %w", ErrInvalidPAC, err)
+		}
+
+		if width == 0 && height == 0 {
+			break
+		}
+
+		var size [3]byte
+		if _, err = io.ReadFull(cr, size[:]); err != nil {
+			return nil, fmt.Errorf("%w: read mip size: %w", ErrInvalidPAC, err)
+		}
+
+		dataSize := int64(size[0]) | int64(size[1])<<8 | int64(size[2])<<16
+		if _, err = io.CopyN(io.Discard, cr, dataSize); err != nil {
+			return nil, fmt.Errorf("%w: read mip %dx%d data: %w", ErrInvalidPAC, width, height, err)
+		}
+
+		mip := pacMip{
+			header: paa.MipHeader{Width: width, Height: height, Offset: offset},
+			size:   uint32(dataSize),
+		}
+		pac.mips = append(pac.mips, mip)
+		pac.meta.MipHeaders = append(pac.meta.MipHeaders, mip.header)
+	}
+
+	if len(pac.meta.MipHeaders) == 0 {
+		return nil, fmt.Errorf("%w: no mipmaps", ErrInvalidPAC)
+	}
+
+	return pac, nil
+}
+
+// readTagg reads one tagg and stores known color and flag values.
+func (pac *pacHeaders) readTagg(cr *countingReader, d *decoder) error {
+	var head [8]byte
+	if _, err := io.ReadFull(cr, head[:]); err != nil {
+		return fmt.Errorf("%w: read tagg name: %w", ErrInvalidPAC, err)
+	}
+
+	size, err := d.readU32()
+	if err != nil {
+		return fmt.Errorf("%w: read tagg size: %w", ErrInvalidPAC, err)
+	}
+
+	name := string(head[4:])
+	if name != pacTaggAVGC && name != pacTaggMAXC && name != pacTaggFLAG {
+		// Unknown tagg data is skipped without buffering.
+		if _, err = io.CopyN(io.Discard, cr, int64(size)); err != nil {
+			return fmt.Errorf("%w: skip tagg %q data: %w", ErrInvalidPAC, name, err)
+		}
+
+		return nil
+	}
+
+	if size > pacMaxTaggSize {
+		return fmt.Errorf("%w: tagg %q size %d exceeds %d", ErrInvalidPAC, name, size, pacMaxTaggSize)
+	}
+
+	data := make([]byte, size)
+	if _, err = io.ReadFull(cr, data); err != nil {
+		return fmt.Errorf("%w: read tagg %q data: %w", ErrInvalidPAC, name, err)
+	}
+
+	switch name {
+	case pacTaggAVGC:
+		if len(data) >= 4 {
+			copy(pac.meta.AverageColor[:], data)
+			pac.meta.HasAverageColor = true
+		}
+
+	case pacTaggMAXC:
+		if len(data) >= 4 {
+			copy(pac.meta.MaxColor[:], data)
+			pac.meta.HasMaxColor = true
+		}
+
+	case pacTaggFLAG:
+		if len(data) >= 4 {
+			pac.meta.GALF = uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+			pac.meta.HasGALF = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package texheaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestPAC writes minimal palettized texture with AVGC tagg and square mip chain.
+func writeTestPAC(t *testing.T, path string, colors int, size uint16) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteString("GGATCGVA")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4))
+	buf.Write([]byte{0x10, 0x20, 0x30, 0xFF})
+
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(colors))
+	buf.Write(make([]byte, colors*3))
+
+	for s := size; s >= 1; s /= 2 {
+		_ = binary.Write(&buf, binary.LittleEndian, s)
+		_ = binary.Write(&buf, binary.LittleEndian, s)
+		n := int(s) * int(s)
+		buf.Write([]byte{byte(n), byte(n >> 8), byte(n >> 16)})
+		buf.Write(make([]byte, n))
+	}
+
+	buf.Write(make([]byte, 6))
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile(%s) error: %v", path, err)
+	}
+}
+
+func TestBuilder_BuildPAC(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ui_co.pac")
+	writeTestPAC(t, path, 16, 8)
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err := b.Append(path); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	e := f.Textures[0]
+	if e.PAAFile != "ui_co.pac" || e.IsPAA {
+		t.Fatalf("entry path=%q is_paa=%v, want ui_co.pac false", e.PAAFile, e.IsPAA)
+	}
+
+	if e.PaxFormat != 0 || e.ColorPaletteCount != 16 {
+		t.Fatalf("entry pax_format=%d palette=%d, want 0 16", e.PaxFormat, e.ColorPaletteCount)
+	}
+
+	// tagg (16 bytes) + palette count (2 bytes).
+	if e.PalettePtr != 18 {
+		t.Fatalf("entry palette_ptr=%d, want 18", e.PalettePtr)
+	}
+
+	if e.MipMapCount != 4 || e.MipMaps[0].DataOffset != 18+16*3 || e.MipMaps[3].Width != 1 {
+		t.Fatalf("mipmaps = %+v, want 4 levels from offset %d", e.MipMaps, 18+16*3)
+	}
+
+	if e.AverageColor != [4]byte{0x10, 0x20, 0x30, 0xFF} {
+		t.Fatalf("average color = %v", e.AverageColor)
+	}
+
+	if err = ValidateFile(f); err != nil {
+		t.Fatalf("ValidateFile() error: %v", err)
+	}
+}
+
+func TestDecodePACHeaders_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, data := range map[string][]byte{
+		"empty":         nil,
+		"empty palette": {0, 0},
+		"no mipmaps":    {1, 0, 0, 0, 0, 0, 0, 0, 0},
+		"truncated mip": {1, 0, 0, 0, 0, 4, 0, 4, 0, 16, 0, 0},
+		"huge tagg":     append([]byte("GGATCGVA"), 0xFF, 0xFF, 0xFF, 0xFF),
+		"huge unknown":  append([]byte("GGATXXXX"), 0xFF, 0xFF, 0xFF, 0xFF),
+	} {
+		if _, err := decodePACHeaders(bytes.NewReader(data)); !errors.Is(err, ErrInvalidPAC) {
+			t.Fatalf("decodePACHeaders(%s) error = %v, want %v", name, err, ErrInvalidPAC)
+		}
+	}
+}
+
+func TestValidateEntry_Palette(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	e := f.Textures[0]
+	e.PalettePtr = 64
+	if err = ValidateEntry(&e, 0); err != nil {
+		t.Fatalf("ValidateEntry(palette_ptr on regular) error: %v", err)
+	}
+
+	rep := ValidateFileReport(&File{Textures: []TextureEntry{e}})
+	if len(rep.Issues) != 1 || rep.Issues[0].Code != CodePalette || rep.Issues[0].Severity != SeverityWarning {
+		t.Fatalf("ValidateFileReport(palette_ptr on regular) = %+v", rep.Issues)
+	}
+
+	e = f.Textures[0]
+	e.PaxFormat = 0
+	for i := range e.MipMaps {
+		e.MipMaps[i].PaxFormat = 0
+	}
+
+	if err = ValidateEntry(&e, 0); !errors.Is(err, ErrValidation) {
+		t.Fatalf("ValidateEntry(p8 without palette) error = %v, want %v", err, ErrValidation)
+	}
+}
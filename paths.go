@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PBOPrefixFile is the addon prefix marker file name used by PBO packers.
+const PBOPrefixFile = "$PBOPREFIX$"
+
+// prefixRoot maps a directory containing $PBOPREFIX$ to its addon prefix.
+type prefixRoot struct {
+	dir    string
+	prefix string
+}
+
+// PathRemap replaces one source path prefix when computing stored entry paths.
+type PathRemap struct {
+	// From is the source path prefix, e.g. "P:\mymod" or "/work/mymod".
+	From string `json:"from" yaml:"from"`
+	// To replaces From in stored path, e.g. "mymod". May be empty.
+	To string `json:"to" yaml:"to"`
+}
+
+// remapPath applies the first matching rule to path.
+// Prefixes are matched case-insensitively on whole path segments with any separator.
+func remapPath(path string, rules []PathRemap) (string, bool) {
+	if len(rules) == 0 {
+		return "", false
+	}
+
+	p := strings.ReplaceAll(path, "\\", "/")
+	for _, rule := range rules {
+		from := strings.TrimRight(strings.ReplaceAll(rule.From, "\\", "/"), "/")
+		if from == "" || len(p) < len(from) || !strings.EqualFold(p[:len(from)], from) {
+			continue
+		}
+
+		rest := p[len(from):]
+		if rest != "" && rest[0] != '/' {
+			continue
+		}
+
+		rest = strings.TrimLeft(rest, "/")
+		to := strings.Trim(strings.ReplaceAll(rule.To, "\\", "/"), "/")
+		switch {
+		case to == "":
+			return rest, true
+		case rest == "":
+			return to, true
+		default:
+			return to + "/" + rest, true
+		}
+	}
+
+	return "", false
+}
+
+// matchPrefixRoot returns path stored under the deepest prefix root containing path.
+func matchPrefixRoot(path string, roots []prefixRoot) (string, bool) {
+	best := -1
+	var bestRel string
+	for i, root := range roots {
+		rel, err := filepath.Rel(root.dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		if best < 0 || len(root.dir) > len(roots[best].dir) {
+			best = i
+			bestRel = rel
+		}
+	}
+
+	if best < 0 {
+		return "", false
+	}
+
+	return joinPrefix(roots[best].prefix, bestRel), true
+}
+
+// joinPrefix joins addon prefix and relative path with a forward slash.
+func joinPrefix(prefix, rel string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "\\/")
+	if prefix == "" {
+		return rel
+	}
+
+	return prefix + "/" + rel
+}
+
+// checkStoredPath reports whether stored path is absolute or has ".." segments.
+func checkStoredPath(rel string) error {
+	p := strings.ReplaceAll(rel, "\\", "/")
+	if strings.HasPrefix(p, "/") || filepath.VolumeName(rel) != "" || (len(p) > 1 && p[1] == ':') {
+		return fmt.Errorf("%w: %s", ErrPathEscapesRoot, rel)
+	}
+
+	for seg := range strings.SplitSeq(p, "/") {
+		if seg == ".." {
+			return fmt.Errorf("%w: %s", ErrPathEscapesRoot, rel)
+		}
+	}
+
+	return nil
+}
+
+// readPBOPrefix reads addon prefix from a $PBOPREFIX$ file.
+//
+// Both plain ("my\addon") and key-value ("prefix=my\addon") forms are accepted.
+func readPBOPrefix(path string) (string, error) {
+	f, err := os.Open(ioPath(path))
+	if err != nil {
+		return "", fmt.Errorf("open %q: %w", path, err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return strings.Trim(line, "\\/"), nil
+		}
+
+		if strings.EqualFold(strings.TrimSpace(key), "prefix") {
+			return strings.Trim(strings.TrimSpace(value), "\\/"), nil
+		}
+	}
+
+	if err = sc.Err(); err != nil {
+		return "", fmt.Errorf("read %q: %w", path, err)
+	}
+
+	return "", nil
+}
@@ -0,0 +1,163 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_NormalizePathRemap(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{
+		BaseDir: "/unused",
+		PathRemap: []PathRemap{
+			{From: `P:\mymod`, To: "mymod"},
+			{From: "/work/shared/", To: `core\shared`},
+			{From: "/flat", To: ""},
+		},
+	})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `p:\MyMod\Data\Gun_co.paa`, want: `mymod\data\gun_co.paa`},
+		{in: "/work/shared/tex/a_nohq.paa", want: `core\shared\tex\a_nohq.paa`},
+		{in: "/flat/x_co.paa", want: "x_co.paa"},
+		{in: `P:\mymodextra\x_co.paa`, want: `p:\mymodextra\x_co.paa`},
+	}
+
+	for _, tt := range tests {
+		if got := b.normalizePath(tt.in); got != tt.want {
+			t.Fatalf("normalizePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_AppendDirPBOPrefix(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	addon := filepath.Join(root, "addon")
+	data := filepath.Join(addon, "data")
+	if err := os.MkdirAll(data, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(addon, PBOPrefixFile), []byte("prefix=MyOrg\\MyAddon\\\r\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile($PBOPREFIX$) error: %v", err)
+	}
+
+	copyFixture(t, "test_co.paa", data, "gun_co.paa")
+	copyFixture(t, "test_co.paa", root, "loose_co.paa")
+
+	b := NewBuilder(BuildOptions{BaseDir: root, PBOPrefix: "fallback"})
+	if err := b.AppendDir(root, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	want := []string{`myorg\myaddon\data\gun_co.paa`, `fallback\loose_co.paa`}
+	if len(got.Textures) != len(want) {
+		t.Fatalf("textures = %d, want %d", len(got.Textures), len(want))
+	}
+
+	for i, path := range want {
+		if got.Textures[i].PAAFile != path {
+			t.Fatalf("texture[%d] = %q, want %q", i, got.Textures[i].PAAFile, path)
+		}
+	}
+}
+
+func TestCheckStoredPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: `mymod\data\gun_co.paa`},
+		{in: `data\..gun_co.paa`},
+		{in: `..\..\foo_co.paa`, wantErr: true},
+		{in: `data\..\..\foo_co.paa`, wantErr: true},
+		{in: `/abs/foo_co.paa`, wantErr: true},
+		{in: `p:\mymod\foo_co.paa`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := checkStoredPath(tt.in)
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrPathEscapesRoot)) {
+			t.Fatalf("checkStoredPath(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+	}
+}
+
+func TestBuilder_StrictPaths(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	base := filepath.Join(root, "base")
+	if err := os.MkdirAll(base, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	inside := copyFixture(t, "test_co.paa", base, "in_co.paa")
+	outside := copyFixture(t, "test_co.paa", root, "out_co.paa")
+
+	b := NewBuilder(BuildOptions{BaseDir: base, StrictPaths: true, SkipInvalid: true})
+	if err := b.AppendMany(inside, outside); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(got.Textures) != 1 || got.Textures[0].PAAFile != "in_co.paa" {
+		t.Fatalf("textures = %+v, want only in_co.paa", got.Textures)
+	}
+
+	issues := b.Issues()
+	if len(issues) != 1 || issues[0].Kind != IssuePathEscape || issues[0].Path != outside {
+		t.Fatalf("Issues() = %+v, want path_escape for %s", issues, outside)
+	}
+
+	b = NewBuilder(BuildOptions{BaseDir: base, StrictPaths: true})
+	if err = b.Append(outside); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if _, err = b.Build(); !errors.Is(err, ErrPathEscapesRoot) {
+		t.Fatalf("Build() error = %v, want ErrPathEscapesRoot", err)
+	}
+}
+
+func TestBuilder_PathFormatOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lower     *bool
+		backslash *bool
+		want      string
+	}{
+		{want: `mymod\data\gun_co.paa`},
+		{lower: Bool(true), backslash: Bool(true), want: `mymod\data\gun_co.paa`},
+		{lower: Bool(false), want: `MyMod\Data\Gun_co.paa`},
+		{backslash: Bool(false), want: "mymod/data/gun_co.paa"},
+		{lower: Bool(false), backslash: Bool(false), want: "MyMod/Data/Gun_co.paa"},
+	}
+
+	for _, tt := range tests {
+		b := NewBuilder(BuildOptions{BaseDir: "/work", LowercasePaths: tt.lower, BackslashPaths: tt.backslash})
+		if got := b.normalizePath("/work/MyMod/Data/Gun_co.paa"); got != tt.want {
+			t.Fatalf("normalizePath() = %q, want %q", got, tt.want)
+		}
+	}
+}
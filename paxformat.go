@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/woozymasta/paa"
+)
+
+// PaxFormat is the texture storage format code of texheaders entry.
+//
+// It marshals to text as format name (e.g. "DXT5") and unknown codes as
+// decimal numbers, so JSON and YAML stay readable and lossless.
+type PaxFormat uint32
+
+// Known pax formats.
+const (
+	// PaxFormatP8 is palettized 8-bit (.pac).
+	PaxFormatP8 PaxFormat = 0
+	// PaxFormatGRAYA is 8-bit gray with 8-bit alpha (AI88).
+	PaxFormatGRAYA PaxFormat = 1
+	// PaxFormatRGB565 is 16-bit RGB 5:6:5.
+	PaxFormatRGB565 PaxFormat = 2
+	// PaxFormatARGBA5 is 16-bit ARGB 1:5:5:5.
+	PaxFormatARGBA5 PaxFormat = 3
+	// PaxFormatARGB4 is 16-bit ARGB 4:4:4:4.
+	PaxFormatARGB4 PaxFormat = 4
+	// PaxFormatARGB8 is 32-bit ARGB 8:8:8:8.
+	PaxFormatARGB8 PaxFormat = 5
+	// PaxFormatDXT1 is BC1 block compression.
+	PaxFormatDXT1 PaxFormat = 6
+	// PaxFormatDXT2 is BC2 block compression with premultiplied alpha.
+	PaxFormatDXT2 PaxFormat = 7
+	// PaxFormatDXT3 is BC2 block compression.
+	PaxFormatDXT3 PaxFormat = 8
+	// PaxFormatDXT4 is BC3 block compression with premultiplied alpha.
+	PaxFormatDXT4 PaxFormat = 9
+	// PaxFormatDXT5 is BC3 block compression.
+	PaxFormatDXT5 PaxFormat = 10
+)
+
+// paxFormatNames maps known pax formats to their names.
+var paxFormatNames = [...]string{
+	PaxFormatP8:     "P8",
+	PaxFormatGRAYA:  "GRAYA",
+	PaxFormatRGB565: "RGB565",
+	PaxFormatARGBA5: "ARGBA5",
+	PaxFormatARGB4:  "ARGB4",
+	PaxFormatARGB8:  "ARGB8",
+	PaxFormatDXT1:   "DXT1",
+	PaxFormatDXT2:   "DXT2",
+	PaxFormatDXT3:   "DXT3",
+	PaxFormatDXT4:   "DXT4",
+	PaxFormatDXT5:   "DXT5",
+}
+
+// Known reports whether f is one of named pax formats.
+func (f PaxFormat) Known() bool {
+	return int(f) < len(paxFormatNames)
+}
+
+// String returns format name, or "PaxFormat(N)" for unknown codes.
+func (f PaxFormat) String() string {
+	if f.Known() {
+		return paxFormatNames[f]
+	}
+
+	return "PaxFormat(" + strconv.FormatUint(uint64(f), 10) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f PaxFormat) MarshalText() ([]byte, error) {
+	if f.Known() {
+		return []byte(paxFormatNames[f]), nil
+	}
+
+	return strconv.AppendUint(nil, uint64(f), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *PaxFormat) UnmarshalText(text []byte) error {
+	v, err := ParsePaxFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = v
+	return nil
+}
+
+// ParsePaxFormat parses format name (case-insensitive) or decimal code.
+func ParsePaxFormat(s string) (PaxFormat, error) {
+	s = strings.TrimSpace(s)
+	for i, name := range paxFormatNames {
+		if strings.EqualFold(s, name) {
+			return PaxFormat(i), nil
+		}
+	}
+
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedPaxFormat, s)
+	}
+
+	return PaxFormat(v), nil
+}
+
+// mipFormat returns format as mip descriptor byte; ok is false above uint8 range.
+func (f PaxFormat) mipFormat() (uint8, bool) {
+	if f > math.MaxUint8 {
+		return 0, false
+	}
+
+	return uint8(f), true
+}
+
+// paxTypeFormats maps paa package pax types to texheaders formats.
+var paxTypeFormats = map[paa.PaxType]PaxFormat{
+	paa.PaxGRAYA:  PaxFormatGRAYA,
+	paa.PaxARGBA5: PaxFormatARGBA5,
+	paa.PaxARGB4:  PaxFormatARGB4,
+	paa.PaxARGB8:  PaxFormatARGB8,
+	paa.PaxDXT1:   PaxFormatDXT1,
+	paa.PaxDXT2:   PaxFormatDXT2,
+	paa.PaxDXT3:   PaxFormatDXT3,
+	paa.PaxDXT4:   PaxFormatDXT4,
+	paa.PaxDXT5:   PaxFormatDXT5,
+}
+
+// PaxTypeToFormat maps paa package pax type to texheaders format.
+// Types without texheaders counterpart return ErrUnsupportedPaxFormat.
+func PaxTypeToFormat(t paa.PaxType) (PaxFormat, error) {
+	format, ok := paxTypeFormats[t]
+	if !ok {
+		return 0, fmt.Errorf("%w: paa type %d", ErrUnsupportedPaxFormat, t)
+	}
+
+	return format, nil
+}
+
+// FormatToPaxType maps texheaders format to paa package pax type.
+// Formats paa cannot encode (P8, RGB565) return ErrUnsupportedPaxFormat.
+func FormatToPaxType(f PaxFormat) (paa.PaxType, error) {
+	for t, format := range paxTypeFormats {
+		if format == f {
+			return t, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnsupportedPaxFormat, f)
+}
@@ -0,0 +1,119 @@
+package texheaders
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/paa"
+)
+
+func TestPaxFormatString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		f    PaxFormat
+		want string
+	}{
+		{f: PaxFormatP8, want: "P8"},
+		{f: PaxFormatGRAYA, want: "GRAYA"},
+		{f: PaxFormatARGB8, want: "ARGB8"},
+		{f: PaxFormatDXT5, want: "DXT5"},
+		{f: 42, want: "PaxFormat(42)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Fatalf("PaxFormat(%d).String() = %q, want %q", uint32(tt.f), got, tt.want)
+		}
+	}
+}
+
+func TestParsePaxFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want PaxFormat
+	}{
+		{in: "DXT1", want: PaxFormatDXT1},
+		{in: " dxt5 ", want: PaxFormatDXT5},
+		{in: "argba5", want: PaxFormatARGBA5},
+		{in: "10", want: PaxFormatDXT5},
+		{in: "300", want: 300},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePaxFormat(tt.in)
+		if err != nil || got != tt.want {
+			t.Fatalf("ParsePaxFormat(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParsePaxFormat("BC7"); !errors.Is(err, ErrUnsupportedPaxFormat) {
+		t.Fatalf("ParsePaxFormat(BC7) error = %v, want ErrUnsupportedPaxFormat", err)
+	}
+}
+
+func TestPaxFormatJSON(t *testing.T) {
+	t.Parallel()
+
+	in := TextureEntry{PAAFile: "a_co.paa", PaxFormat: PaxFormatDXT5}
+	raw, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+
+	if !strings.Contains(string(raw), `"pax_format":"DXT5"`) {
+		t.Fatalf("Marshal() = %s, want DXT5 pax format", raw)
+	}
+
+	var out TextureEntry
+	if err = json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	if out.PaxFormat != PaxFormatDXT5 {
+		t.Fatalf("PaxFormat = %v, want DXT5", out.PaxFormat)
+	}
+
+	stats := BuildStats{ByPaxFormat: map[PaxFormat]int{PaxFormatDXT1: 2, 99: 1}}
+	if raw, err = json.Marshal(stats); err != nil {
+		t.Fatalf("Marshal(stats) error: %v", err)
+	}
+
+	if !strings.Contains(string(raw), `"DXT1":2`) || !strings.Contains(string(raw), `"99":1`) {
+		t.Fatalf("Marshal(stats) = %s", raw)
+	}
+}
+
+func TestPaxTypeConversions(t *testing.T) {
+	t.Parallel()
+
+	for _, pt := range []paa.PaxType{paa.PaxGRAYA, paa.PaxARGBA5, paa.PaxARGB4, paa.PaxARGB8,
+		paa.PaxDXT1, paa.PaxDXT2, paa.PaxDXT3, paa.PaxDXT4, paa.PaxDXT5} {
+		f, err := PaxTypeToFormat(pt)
+		if err != nil {
+			t.Fatalf("PaxTypeToFormat(%d) error: %v", pt, err)
+		}
+
+		if back, err := FormatToPaxType(f); err != nil || back != pt {
+			t.Fatalf("FormatToPaxType(%s) = %d, %v; want %d", f, back, err, pt)
+		}
+	}
+
+	if f, _ := PaxTypeToFormat(paa.PaxDXT5); f != PaxFormatDXT5 {
+		t.Fatalf("PaxTypeToFormat(DXT5) = %s", f)
+	}
+
+	if _, err := PaxTypeToFormat(paa.PaxType(200)); !errors.Is(err, ErrUnsupportedPaxFormat) {
+		t.Fatalf("PaxTypeToFormat(200) error = %v", err)
+	}
+
+	for _, f := range []PaxFormat{PaxFormatP8, PaxFormatRGB565, PaxFormat(77)} {
+		if _, err := FormatToPaxType(f); !errors.Is(err, ErrUnsupportedPaxFormat) {
+			t.Fatalf("FormatToPaxType(%s) error = %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PBO header packing methods.
+const (
+	pboMethodNone uint32 = 0
+	pboMethodVers uint32 = 0x56657273 // "Vers"
+)
+
+// pboEntry describes one file stored in PBO archive.
+type pboEntry struct {
+	name      string // name is the stored path with backslash separators.
+	offset    int64  // offset is the data position from archive start.
+	method    uint32 // method is the packing method, zero for stored data.
+	origSize  uint32 // origSize is the unpacked size for packed entries.
+	timestamp uint32 // timestamp is the unix modification time.
+	dataSize  uint32 // dataSize is the stored data size.
+}
+
+// pboArchive holds parsed PBO header.
+type pboArchive struct {
+	props   []string   // props holds header properties as key, value pairs.
+	entries []pboEntry // entries holds files in stored order.
+}
+
+// BuildFromPBO builds texheaders model from .paa files stored in PBO archive.
+func BuildFromPBO(pboPath string, opts BuildOptions) (*File, error) {
+	return NewBuilder(opts).BuildPBO(pboPath)
+}
+
+// BuildPBO builds texheaders model from .paa files stored in PBO archive.
+//
+// Appended inputs are ignored. Stored paths are placed under BuildOptions.PBOPrefix
+// or, when it is empty, under the archive "prefix" header property.
+func (b *Builder) BuildPBO(pboPath string) (*File, error) {
+	fh, err := b.openSource(pboPath)
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", pboPath, err)
+	}
+
+	defer b.closeSource(fh)
+
+	arc, err := readPBOHeader(fh)
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", pboPath, err)
+	}
+
+	prefix := b.opts.PBOPrefix
+	if strings.TrimSpace(prefix) == "" {
+		prefix = arc.property("prefix")
+	}
+
+	sources := make([]pboEntry, 0, len(arc.entries))
+	for _, e := range arc.entries {
+		if hasExtension(e.name, []string{".paa", ".pac"}) {
+			sources = append(sources, e)
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return strings.ToLower(sources[i].name) < strings.ToLower(sources[j].name)
+	})
+
+	b.issues = b.issues[:0]
+	b.failures = nil
+	b.lastInputs = make([]string, 0, len(sources))
+	b.reportEntries = nil
+	b.hashes.groups = nil
+	for _, e := range sources {
+		b.lastInputs = append(b.lastInputs, e.name)
+	}
+
+	start := b.resetStats()
+	defer func() {
+		b.stats.Duration = time.Since(start)
+	}()
+
+	file := &File{
+		Magic:    FileMagic,
+		Version:  SupportedVersion,
+		Textures: make([]TextureEntry, 0, len(sources)),
+	}
+
+	emit := func(entry *TextureEntry) error {
+		file.Textures = append(file.Textures, *entry)
+		return nil
+	}
+
+	for _, e := range sources {
+		entry, err := b.scanPBOEntry(fh, e, prefix)
+		if err = b.collectEntry(e.name, &entry, err, emit); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.failures) > 0 {
+		return nil, &BuildError{Failures: b.failures}
+	}
+
+	return file, nil
+}
+
+// scanPBOEntry builds one texture entry from archive data.
+func (b *Builder) scanPBOEntry(r io.ReaderAt, e pboEntry, prefix string) (TextureEntry, error) {
+	ext, err := b.sourceExt(e.name)
+	if err != nil {
+		return TextureEntry{}, err
+	}
+
+	if isSourceImageExt(ext) {
+		return TextureEntry{}, fmt.Errorf("%w: %s", ErrUnsupportedInputFormat, e.name)
+	}
+
+	if e.method != pboMethodNone {
+		return TextureEntry{}, fmt.Errorf("%w: method 0x%08x", ErrPBOPacked, e.method)
+	}
+
+	rel := b.formatPath(joinPrefix(prefix, strings.ReplaceAll(e.name, "\\", "/")))
+	size := int64(e.dataSize)
+	return b.scanEntry(io.NewSectionReader(r, e.offset, size), size, rel, ext)
+}
+
+// property returns header property value by case-insensitive key.
+func (a *pboArchive) property(key string) string {
+	for i := 0; i+1 < len(a.props); i += 2 {
+		if strings.EqualFold(a.props[i], key) {
+			return a.props[i+1]
+		}
+	}
+
+	return ""
+}
+
+// readPBOHeader parses PBO header and resolves entry data offsets.
+func readPBOHeader(r io.Reader) (*pboArchive, error) {
+	cr := &countingReader{r: bufio.NewReader(r)}
+	d := decoder{r: cr, byteR: cr}
+	arc := &pboArchive{}
+
+	for {
+		name, err := d.readASCIIZ()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read entry name: %w", ErrInvalidPBO, err)
+		}
+
+		var fields [5]uint32
+		for i := range fields {
+			if fields[i], err = d.readU32(); err != nil {
+				return nil, fmt.Errorf("%w: read entry %q header: %w", ErrInvalidPBO, name, err)
+			}
+		}
+
+		if name == "" && fields[0] == pboMethodVers {
+			if err = arc.readProperties(&d); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if name == "" {
+			break
+		}
+
+		arc.entries = append(arc.entries, pboEntry{
+			name:      name,
+			method:    fields[0],
+			origSize:  fields[1],
+			timestamp: fields[3],
+			dataSize:  fields[4],
+		})
+	}
+
+	offset := cr.n
+	for i := range arc.entries {
+		arc.entries[i].offset = offset
+		offset += int64(arc.entries[i].dataSize)
+	}
+
+	return arc, nil
+}
+
+// readProperties reads zero-terminated key/value list after version entry.
+func (a *pboArchive) readProperties(d *decoder) error {
+	for {
+		key, err := d.readASCIIZ()
+		if err != nil {
+			return fmt.Errorf("%w: read property: %w", ErrInvalidPBO, err)
+		}
+
+		if key == "" {
+			return nil
+		}
+
+		value, err := d.readASCIIZ()
+		if err != nil {
+			return fmt.Errorf("%w: read property %q: %w", ErrInvalidPBO, key, err)
+		}
+
+		a.props = append(a.props, key, value)
+	}
+}
+
+// countingReader counts bytes consumed from buffered reader.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ReadByte implements io.ByteReader.
+func (c *countingReader) ReadByte() (byte, error) {
+	v, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+
+	return v, err
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // PBO checksum trailer is SHA-1 by format.
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ReadPBOIndex reads texHeaders.bin stored at PBO archive root.
+func ReadPBOIndex(pboPath string) (*File, error) {
+	fh, err := os.Open(pboPath)
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", pboPath, err)
+	}
+
+	defer func() {
+		_ = fh.Close()
+	}()
+
+	arc, err := readPBOHeader(fh)
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", pboPath, err)
+	}
+
+	i := arc.indexEntry()
+	if i < 0 {
+		return nil, fmt.Errorf("read %q: %w", pboPath, ErrPBOIndexNotFound)
+	}
+
+	e := arc.entries[i]
+	if e.method != pboMethodNone {
+		return nil, fmt.Errorf("read %q: %w: %s method 0x%08x", pboPath, ErrPBOPacked, e.name, e.method)
+	}
+
+	f, err := Read(io.NewSectionReader(fh, e.offset, int64(e.dataSize)))
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %s: %w", pboPath, e.name, err)
+	}
+
+	return f, nil
+}
+
+// InjectPBOIndex writes copy of PBO archive with root texHeaders.bin replaced
+// by f, or appended when archive has none, into outPath.
+//
+// Other entries are copied verbatim, including packed ones, and the SHA-1
+// trailer is recomputed. outPath may equal pboPath: the archive is written to
+// a temporary file next to outPath and renamed over it on success. Output
+// gets permissions of the source archive.
+func InjectPBOIndex(pboPath, outPath string, f *File) error {
+	var index strings.Builder
+	if err := Write(&index, f); err != nil {
+		return err
+	}
+
+	src, err := os.Open(pboPath)
+	if err != nil {
+		return fmt.Errorf("open %q: %w", pboPath, err)
+	}
+
+	defer func() {
+		_ = src.Close()
+	}()
+
+	info, err := src.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %q: %w", pboPath, err)
+	}
+
+	arc, err := readPBOHeader(src)
+	if err != nil {
+		return fmt.Errorf("read %q: %w", pboPath, err)
+	}
+
+	tmp, err := createTemp(outPath, ".texheaders-*.pbo", info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("create %q: %w", outPath, err)
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err = arc.writeWithIndex(tmp, src, index.String()); err != nil {
+		return fmt.Errorf("write %q: %w", outPath, err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write %q: %w", outPath, err)
+	}
+
+	if err = os.Rename(tmp.Name(), outPath); err != nil {
+		return fmt.Errorf("rename %q: %w", outPath, err)
+	}
+
+	return nil
+}
+
+// indexEntry returns position of root texHeaders.bin entry or -1.
+func (a *pboArchive) indexEntry() int {
+	for i, e := range a.entries {
+		if strings.EqualFold(e.name, DefaultOutputName) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// writeWithIndex writes archive header, data and checksum trailer into w,
+// taking entry data from src and replacing or appending index payload.
+func (a *pboArchive) writeWithIndex(w io.Writer, src io.ReaderAt, index string) error {
+	size, err := intToU32Strict(len(index))
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := int64ToU32Strict(time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	entries := make([]pboEntry, len(a.entries), len(a.entries)+1)
+	copy(entries, a.entries)
+
+	indexEntry := pboEntry{
+		name:      DefaultOutputName,
+		timestamp: timestamp,
+		dataSize:  size,
+		offset:    -1,
+	}
+
+	if i := a.indexEntry(); i >= 0 {
+		indexEntry.name = entries[i].name
+		entries[i] = indexEntry
+	} else {
+		entries = append(entries, indexEntry)
+	}
+
+	sum := sha1.New() //nolint:gosec // PBO checksum trailer is SHA-1 by format.
+	bw := bufio.NewWriter(io.MultiWriter(w, sum))
+	e := encoder{w: bw, strW: bw}
+
+	if err = e.writePBOHeader("", pboMethodVers, 0, 0, 0); err != nil {
+		return err
+	}
+
+	for _, p := range a.props {
+		if err := e.writeASCIIZ(p); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeU8(0); err != nil {
+		return err
+	}
+
+	for _, en := range entries {
+		if err := e.writePBOHeader(en.name, en.method, en.origSize, en.timestamp, en.dataSize); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writePBOHeader("", pboMethodNone, 0, 0, 0); err != nil {
+		return err
+	}
+
+	for _, en := range entries {
+		if en.offset < 0 {
+			_, err = bw.WriteString(index)
+		} else {
+			_, err = io.Copy(bw, io.NewSectionReader(src, en.offset, int64(en.dataSize)))
+		}
+
+		if err != nil {
+			return fmt.Errorf("copy %q: %w", en.name, err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	trailer := append([]byte{0}, sum.Sum(nil)...)
+	_, err = w.Write(trailer)
+	return err
+}
+
+// writePBOHeader writes one PBO entry header with zero reserved field.
+func (e *encoder) writePBOHeader(name string, method, origSize, timestamp, dataSize uint32) error {
+	if err := e.writeASCIIZ(name); err != nil {
+		return err
+	}
+
+	for _, v := range [...]uint32{method, origSize, 0, timestamp, dataSize} {
+		if err := e.writeU32(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
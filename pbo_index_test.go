@@ -0,0 +1,139 @@
+package texheaders
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestInjectPBOIndex(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	config := []byte("class CfgPatches {};")
+	texture := readFixture(t, "test_co.paa")
+	writeTestPBO(t, pboPath, []string{"prefix", `MyAddon`}, []testPBOFile{
+		{name: "config.cpp", data: config},
+		{name: `data\test_co.paa`, data: texture},
+	})
+
+	if _, err := ReadPBOIndex(pboPath); !errors.Is(err, ErrPBOIndexNotFound) {
+		t.Fatalf("ReadPBOIndex(no index) error = %v, want %v", err, ErrPBOIndexNotFound)
+	}
+
+	index, err := BuildFromPBO(pboPath, BuildOptions{})
+	if err != nil {
+		t.Fatalf("BuildFromPBO() error: %v", err)
+	}
+
+	if err = InjectPBOIndex(pboPath, pboPath, index); err != nil {
+		t.Fatalf("InjectPBOIndex() error: %v", err)
+	}
+
+	got, err := ReadPBOIndex(pboPath)
+	if err != nil {
+		t.Fatalf("ReadPBOIndex() error: %v", err)
+	}
+
+	if !EqualFiles(index, got, EqualOptions{}) {
+		t.Fatalf("ReadPBOIndex() = %+v, want %+v", got, index)
+	}
+
+	rebuilt, err := BuildFromPBO(pboPath, BuildOptions{})
+	if err != nil || len(rebuilt.Textures) != 1 || rebuilt.Textures[0].PAAFile != `myaddon\data\test_co.paa` {
+		t.Fatalf("BuildFromPBO(injected) = %+v, %v", rebuilt, err)
+	}
+
+	raw, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	body, trailer := raw[:len(raw)-21], raw[len(raw)-21:]
+	sum := sha1.Sum(body)
+	if trailer[0] != 0 || !bytes.Equal(trailer[1:], sum[:]) {
+		t.Fatalf("checksum trailer = %x, want 00%x", trailer, sum)
+	}
+
+	if !bytes.Contains(body, config) {
+		t.Fatalf("injected archive lost config.cpp data")
+	}
+
+	index.Textures = index.Textures[:0]
+	out := filepath.Join(dir, "replaced.pbo")
+	if err = InjectPBOIndex(pboPath, out, index); err != nil {
+		t.Fatalf("InjectPBOIndex(replace) error: %v", err)
+	}
+
+	got, err = ReadPBOIndex(out)
+	if err != nil || len(got.Textures) != 0 {
+		t.Fatalf("ReadPBOIndex(replaced) = %+v, %v", got, err)
+	}
+
+	arc := mustReadPBOHeader(t, out)
+	if len(arc.entries) != 3 || arc.property("prefix") != "MyAddon" {
+		t.Fatalf("replaced archive entries = %+v, props %q", arc.entries, arc.props)
+	}
+}
+
+func TestInjectPBOIndex_KeepsMode(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not kept on windows")
+	}
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	writeTestPBO(t, pboPath, []string{"prefix", "MyAddon"}, []testPBOFile{
+		{name: "config.cpp", data: []byte("class CfgPatches {};")},
+	})
+
+	if err := os.Chmod(pboPath, 0o644); err != nil {
+		t.Fatalf("Chmod() error: %v", err)
+	}
+
+	if err := InjectPBOIndex(pboPath, pboPath, &File{}); err != nil {
+		t.Fatalf("InjectPBOIndex() error: %v", err)
+	}
+
+	if info, err := os.Stat(pboPath); err != nil || info.Mode().Perm() != 0o644 {
+		t.Fatalf("InjectPBOIndex() mode = %v, %v; want 0644", info.Mode(), err)
+	}
+}
+
+func TestReadPBOIndex_Packed(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	writeTestPBO(t, pboPath, nil, []testPBOFile{
+		{name: "texHeaders.bin", data: readFixture(t, "texHeaders.bin"), method: 0x43707273},
+	})
+
+	if _, err := ReadPBOIndex(pboPath); !errors.Is(err, ErrPBOPacked) {
+		t.Fatalf("ReadPBOIndex(packed) error = %v, want %v", err, ErrPBOPacked)
+	}
+}
+
+// mustReadPBOHeader parses PBO header of file at path.
+func mustReadPBOHeader(t *testing.T, path string) *pboArchive {
+	t.Helper()
+
+	fh, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s) error: %v", path, err)
+	}
+	defer func() { _ = fh.Close() }()
+
+	arc, err := readPBOHeader(fh)
+	if err != nil {
+		t.Fatalf("readPBOHeader() error: %v", err)
+	}
+
+	return arc
+}
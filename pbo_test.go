@@ -0,0 +1,117 @@
+package texheaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPBOFile is one file stored into test PBO archive.
+type testPBOFile struct {
+	name   string
+	data   []byte
+	method uint32
+}
+
+// writeTestPBO writes minimal PBO archive with version header and properties.
+func writeTestPBO(t *testing.T, path string, props []string, files []testPBOFile) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writeHeader := func(name string, method, size uint32) {
+		buf.WriteString(name)
+		buf.WriteByte(0)
+		for _, v := range []uint32{method, 0, 0, 0, size} {
+			_ = binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+
+	writeHeader("", pboMethodVers, 0)
+	for _, p := range props {
+		buf.WriteString(p)
+		buf.WriteByte(0)
+	}
+	buf.WriteByte(0)
+
+	for _, f := range files {
+		writeHeader(f.name, f.method, uint32(len(f.data)))
+	}
+	writeHeader("", 0, 0)
+
+	for _, f := range files {
+		buf.Write(f.data)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile(%s) error: %v", path, err)
+	}
+}
+
+// readFixture reads one testdata file.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error: %v", name, err)
+	}
+
+	return raw
+}
+
+func TestBuildFromPBO(t *testing.T) {
+	t.Parallel()
+
+	want, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	writeTestPBO(t, pboPath, []string{"prefix", `MyAddon`}, []testPBOFile{
+		{name: `data\test_nohq.paa`, data: readFixture(t, "test_nohq.paa")},
+		{name: "config.cpp", data: []byte("class CfgPatches {};")},
+		{name: `data\test_co.paa`, data: readFixture(t, "test_co.paa")},
+	})
+
+	got, err := BuildFromPBO(pboPath, BuildOptions{})
+	if err != nil {
+		t.Fatalf("BuildFromPBO() error: %v", err)
+	}
+
+	if len(got.Textures) != 2 {
+		t.Fatalf("textures = %d, want 2", len(got.Textures))
+	}
+
+	wantIndex := want.Index()
+	for _, tex := range got.Textures {
+		name := filepath.Base(stringsFromBackslashes(tex.PAAFile))
+		wantEntry := *wantIndex[name]
+		wantEntry.PAAFile = `myaddon\data\` + name
+		if err = assertEntryEqual(name, wantEntry, tex); err != nil {
+			t.Fatalf("entry mismatch: %v", err)
+		}
+	}
+}
+
+func TestBuildFromPBO_PackedEntry(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	writeTestPBO(t, pboPath, nil, []testPBOFile{
+		{name: "a_co.paa", data: []byte("packed"), method: 0x43707273},
+		{name: "b_co.paa", data: readFixture(t, "test_co.paa")},
+	})
+
+	b := NewBuilder(BuildOptions{SkipInvalid: true})
+	got, err := b.BuildPBO(pboPath)
+	if err != nil {
+		t.Fatalf("BuildPBO() error: %v", err)
+	}
+
+	if len(got.Textures) != 1 || len(b.Issues()) != 1 {
+		t.Fatalf("textures = %d issues = %d, want 1 and 1", len(got.Textures), len(b.Issues()))
+	}
+}
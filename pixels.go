@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"io"
+
+	"github.com/woozymasta/paa"
+)
+
+// errNoMipmaps means paa stream has no mip to decode pixels from.
+var errNoMipmaps = errors.New("no mipmaps")
+
+// GALF flag bits.
+const (
+	galfAlpha       uint32 = 1 // galfAlpha marks interpolated alpha.
+	galfTransparent uint32 = 2 // galfTransparent marks binary (alpha-test) transparency.
+)
+
+// applyPixelMetadata updates meta with values derived from smallest mip pixels,
+// as requested by ComputeMissingColors and RecomputeFlags options.
+//
+// Source stream of size bytes is decoded through paa; decode failures keep
+// meta unchanged as pixel-derived values are best-effort.
+func (b *Builder) applyPixelMetadata(ra io.ReaderAt, size int64, meta *paa.MetadataHeaders) {
+	needColors := b.opts.ComputeMissingColors && (!meta.HasAverageColor || !meta.HasMaxColor)
+	if !needColors && !b.opts.RecomputeFlags {
+		return
+	}
+
+	img, err := decodeSmallestMip(io.NewSectionReader(ra, 0, size))
+	if err != nil {
+		return
+	}
+
+	if needColors {
+		computed := imageMetadata(img)
+		if !meta.HasAverageColor {
+			meta.AverageColor = computed.AverageColor
+			meta.HasAverageColor = true
+		}
+
+		if !meta.HasMaxColor {
+			meta.MaxColor = computed.MaxColor
+			meta.HasMaxColor = true
+		}
+	}
+
+	if b.opts.RecomputeFlags {
+		meta.GALF = alphaFlags(img)
+		meta.HasGALF = meta.GALF != 0
+	}
+}
+
+// alphaFlags derives GALF bits from image alpha channel.
+//
+// Opaque images get no flags, images with only fully transparent or fully
+// opaque pixels get transparent flag, anything else gets alpha flag.
+func alphaFlags(img image.Image) uint32 {
+	bounds := img.Bounds()
+	flags := uint32(0)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			a := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA).A
+			switch a {
+			case 0xFF:
+			case 0:
+				flags = galfTransparent
+			default:
+				return galfAlpha
+			}
+		}
+	}
+
+	return flags
+}
+
+// decodeSmallestMip decodes pixels of the last mip of paa stream.
+func decodeSmallestMip(r io.Reader) (image.Image, error) {
+	p, err := paa.DecodePAA(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.MipMaps) == 0 {
+		return nil, errNoMipmaps
+	}
+
+	return p.MipMaps[len(p.MipMaps)-1].Image()
+}
+
+// imageMetadata computes color and flag tags the converter stores for img.
+func imageMetadata(img image.Image) *paa.MetadataHeaders {
+	bounds := img.Bounds()
+	var sum [4]uint64
+	var maxC [4]uint8
+	pixels := uint64(bounds.Dx()) * uint64(bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			// Tags store channels as B,G,R,A.
+			px := [4]uint8{c.B, c.G, c.R, c.A}
+			for i, v := range px {
+				sum[i] += uint64(v)
+				maxC[i] = max(maxC[i], v)
+			}
+		}
+	}
+
+	meta := &paa.MetadataHeaders{
+		HasAverageColor: true,
+		HasMaxColor:     true,
+		MaxColor:        maxC,
+	}
+
+	if pixels > 0 {
+		for i := range sum {
+			meta.AverageColor[i] = uint8(sum[i] / pixels)
+		}
+	}
+
+	if maxC[3] < 0xFF || meta.AverageColor[3] < 0xFF {
+		meta.HasGALF = true
+		meta.GALF = 1
+	}
+
+	return meta
+}
@@ -0,0 +1,237 @@
+package texheaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/woozymasta/paa"
+)
+
+// testRed is opaque red exactly representable by DXT1 endpoints.
+var testRed = color.NRGBA{R: 0xFF, A: 0xFF}
+
+// writeTestPAA encodes img with paa encoder into path and drops taggs named
+// in drop (stored form, e.g. "CGVA"), shifting SFFO offsets accordingly.
+func writeTestPAA(t *testing.T, path string, img image.Image, opts *paa.EncodeOptions, drop ...string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := paa.EncodeWithOptions(&buf, img, opts); err != nil {
+		t.Fatalf("EncodeWithOptions(%s) error: %v", path, err)
+	}
+
+	data := buf.Bytes()
+	out := append([]byte(nil), data[:2]...)
+	pos, sffo, removed := 2, -1, uint32(0)
+	for string(data[pos:pos+4]) == "GGAT" {
+		name := string(data[pos+4 : pos+8])
+		end := pos + 12 + int(binary.LittleEndian.Uint32(data[pos+8:]))
+		if slices.Contains(drop, name) {
+			removed += uint32(end - pos)
+		} else {
+			if name == "SFFO" {
+				sffo = len(out) + 12
+			}
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	out = append(out, data[pos:]...)
+
+	if sffo < 0 {
+		t.Fatalf("encoded %s has no SFFO tagg", path)
+	}
+
+	for i := sffo; i < sffo+64; i += 4 {
+		if v := binary.LittleEndian.Uint32(out[i:]); v != 0 {
+			binary.LittleEndian.PutUint32(out[i:], v-removed)
+		}
+	}
+
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		t.Fatalf("WriteFile(%s) error: %v", path, err)
+	}
+}
+
+// solidImage returns w x h image filled with c.
+func solidImage(w, h int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+
+	return img
+}
+
+func TestBuilder_ComputeMissingColors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "red_co.paa")
+	writeTestPAA(t, path, solidImage(4, 4, testRed), &paa.EncodeOptions{Type: paa.PaxDXT1}, "CGVA", "CXAM")
+
+	for _, compute := range []bool{false, true} {
+		b := NewBuilder(BuildOptions{BaseDir: dir, ComputeMissingColors: compute})
+		if err := b.Append(path); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+
+		f, err := b.Build()
+		if err != nil {
+			t.Fatalf("Build() error: %v", err)
+		}
+
+		e := f.Textures[0]
+		want := [4]byte{}
+		if compute {
+			want = [4]byte{0x00, 0x00, 0xFF, 0xFF}
+		}
+
+		if e.AverageColor != want {
+			t.Fatalf("compute=%v average color = %v, want %v", compute, e.AverageColor, want)
+		}
+
+		if compute && (!e.HasMaxCtagg || e.MaxColor != want) {
+			t.Fatalf("compute=%v max color = %v (tagg=%v), want %v", compute, e.MaxColor, e.HasMaxCtagg, want)
+		}
+	}
+}
+
+func TestBuilder_ComputeMissingColorsKeepsTags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "red_co.paa")
+	writeTestPAA(t, path, solidImage(4, 4, testRed), &paa.EncodeOptions{Type: paa.PaxDXT1}, "CXAM")
+
+	// Tagged average differs from pixels so kept and computed values differ.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	avg := [4]byte{1, 2, 3, 4}
+	copy(data[bytes.Index(data, []byte("GGATCGVA"))+12:], avg[:])
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, ComputeMissingColors: true})
+	if err = b.Append(path); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if e := f.Textures[0]; e.AverageColor != avg || e.MaxColor != [4]byte{0x00, 0x00, 0xFF, 0xFF} {
+		t.Fatalf("colors avg=%v max=%v, want tagged avg and computed max", e.AverageColor, e.MaxColor)
+	}
+}
+
+func TestDecodeSmallestMip(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"test_8888.paa", "test_4444.paa", "test_1555.paa", "test_88.paa", "test_dxt1.paa", "test_dxt5.paa"} {
+		fh, err := os.Open(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("Open(%s) error: %v", name, err)
+		}
+
+		meta, err := paa.DecodeMetadataHeaders(fh)
+		if err != nil {
+			_ = fh.Close()
+			t.Fatalf("DecodeMetadataHeaders(%s) error: %v", name, err)
+		}
+
+		if _, err = fh.Seek(0, 0); err != nil {
+			_ = fh.Close()
+			t.Fatalf("Seek(%s) error: %v", name, err)
+		}
+
+		img, err := decodeSmallestMip(fh)
+		_ = fh.Close()
+		if err != nil {
+			t.Fatalf("decodeSmallestMip(%s) error: %v", name, err)
+		}
+
+		last := meta.MipHeaders[len(meta.MipHeaders)-1]
+		if b := img.Bounds(); b.Dx() != int(last.Width) || b.Dy() != int(last.Height) {
+			t.Fatalf("decodeSmallestMip(%s) bounds = %v, want %dx%d", name, b, last.Width, last.Height)
+		}
+	}
+}
+
+func TestBuilder_RecomputeFlags(t *testing.T) {
+	t.Parallel()
+
+	cutout := solidImage(4, 4, testRed)
+	for i := 3; i < len(cutout.Pix); i += 8 {
+		cutout.Pix[i] = 0
+	}
+
+	noMips := false
+	tests := []struct {
+		img         image.Image
+		name        string
+		alpha       bool
+		transparent bool
+	}{
+		// Opaque red with stale alpha flag.
+		{name: "opaque", img: solidImage(4, 4, testRed)},
+		// Every other pixel fully transparent, the rest fully opaque.
+		{name: "cutout", img: cutout, transparent: true},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, tt.name+"_ca.paa")
+		writeTestPAA(t, path, tt.img, &paa.EncodeOptions{
+			Type:            paa.PaxARGB8,
+			GenerateMipmaps: &noMips,
+			WriteGALF:       true,
+			GALFValue:       byte(galfAlpha),
+		})
+
+		for _, recompute := range []bool{false, true} {
+			b := NewBuilder(BuildOptions{BaseDir: dir, RecomputeFlags: recompute})
+			if err := b.Append(path); err != nil {
+				t.Fatalf("Append() error: %v", err)
+			}
+
+			f, err := b.Build()
+			if err != nil {
+				t.Fatalf("Build() error: %v", err)
+			}
+
+			// Without recompute the stale GALF tag is kept as is.
+			alpha, transparent := true, false
+			if recompute {
+				alpha, transparent = tt.alpha, tt.transparent
+			}
+
+			if e := f.Textures[0]; e.IsAlpha != alpha || e.IsTransparent != transparent {
+				t.Fatalf("%s recompute=%v: alpha=%v transparent=%v, want %v %v",
+					tt.name, recompute, e.IsAlpha, e.IsTransparent, alpha, transparent)
+			}
+		}
+	}
+}
+
+func TestAlphaFlags(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Pix = []byte{0, 0, 0, 0xFF, 0, 0, 0, 0x80}
+	if got := alphaFlags(img); got != galfAlpha {
+		t.Fatalf("alphaFlags(translucent) = %d, want %d", got, galfAlpha)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Placeholder defaults.
+const (
+	// DefaultPlaceholderFormat is the pax format of placeholder entries.
+	DefaultPlaceholderFormat = PaxFormatDXT1
+	// DefaultPlaceholderSize is the mip width and height of placeholder entries.
+	DefaultPlaceholderSize = 4
+)
+
+// PlaceholderOptions configures entries emitted for missing source textures.
+type PlaceholderOptions struct {
+	// PaxFormat is the entry pax format; zero or unknown format means
+	// DefaultPlaceholderFormat.
+	PaxFormat PaxFormat `json:"pax_format,omitempty" yaml:"pax_format,omitempty"`
+	// Width is the single mip width; zero means DefaultPlaceholderSize.
+	Width uint16 `json:"width,omitempty" yaml:"width,omitempty"`
+	// Height is the single mip height; zero means DefaultPlaceholderSize.
+	Height uint16 `json:"height,omitempty" yaml:"height,omitempty"`
+}
+
+// placeholderEntry builds single-mip entry standing in for missing source path.
+func (b *Builder) placeholderEntry(path string) TextureEntry {
+	opts := *b.opts.Placeholder
+	if opts.PaxFormat == 0 || !opts.PaxFormat.Known() {
+		opts.PaxFormat = DefaultPlaceholderFormat
+	}
+
+	mipFormat, _ := opts.PaxFormat.mipFormat()
+
+	if opts.Width == 0 {
+		opts.Width = DefaultPlaceholderSize
+	}
+
+	if opts.Height == 0 {
+		opts.Height = DefaultPlaceholderSize
+	}
+
+	rel := b.normalizePath(path)
+	return TextureEntry{
+		ColorPaletteCount: 1,
+		TransparentColor:  0xFFFFFFFF,
+		LittleEndian:      true,
+		IsPAA:             strings.EqualFold(filepath.Ext(path), ".paa"),
+		PAAFile:           rel,
+		PaxFormat:         opts.PaxFormat,
+		PaxSuffixType:     b.resolveSuffixType(rel),
+		MaxColor:          [4]byte{0xFF, 0xFF, 0xFF, 0xFF},
+		MipMapCount:       1,
+		MipMapCountCopy:   1,
+		MipMaps: []MipMap{{
+			Width:       opts.Width,
+			Height:      opts.Height,
+			PaxFormat:   mipFormat,
+			AlwaysThree: 3,
+		}},
+	}
+}
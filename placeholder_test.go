@@ -0,0 +1,67 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_Placeholder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	missing := filepath.Join(dir, "b_co.paa")
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, Placeholder: &PlaceholderOptions{Width: 8}})
+	if err := b.AppendMany(filepath.Join(dir, "a_co.paa"), missing); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(f.Textures) != 2 {
+		t.Fatalf("textures = %d, want 2", len(f.Textures))
+	}
+
+	e := f.Textures[1]
+	if e.PAAFile != "b_co.paa" || e.PaxFormat != DefaultPlaceholderFormat || e.MipMapCount != 1 {
+		t.Fatalf("placeholder = %+v", e)
+	}
+
+	if m := e.MipMaps[0]; m.Width != 8 || m.Height != DefaultPlaceholderSize || PaxFormat(m.PaxFormat) != DefaultPlaceholderFormat {
+		t.Fatalf("placeholder mip = %+v, want 8x%d", m, DefaultPlaceholderSize)
+	}
+
+	issues := b.Issues()
+	if len(issues) != 1 || issues[0].Kind != IssuePlaceholder || !issues[0].Warning || !errors.Is(issues[0].Err, os.ErrNotExist) {
+		t.Fatalf("issues = %+v, want one placeholder warning", issues)
+	}
+
+	if err = ValidateFile(f); err != nil {
+		t.Fatalf("ValidateFile() error: %v", err)
+	}
+}
+
+func TestBuilder_PlaceholderOnlyForMissing(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad_co.paa")
+	if err := os.WriteFile(bad, []byte("bad"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, Placeholder: &PlaceholderOptions{}})
+	if err := b.Append(bad); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if _, err := b.Build(); err == nil {
+		t.Fatal("Build(corrupt input) error = nil")
+	}
+}
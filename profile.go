@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Built-in engine profile names for ValidateOptions.Profile.
+const (
+	// ProfileDayZ targets DayZ: no palettized textures and no thermal suffix.
+	ProfileDayZ = "dayz"
+	// ProfileArma3 targets Arma 3: no palettized textures.
+	ProfileArma3 = "arma3"
+	// ProfileLegacy targets older engines with 2048 texture limit and .pac support.
+	ProfileLegacy = "legacy"
+)
+
+// Profile checks reported when ValidateOptions.Profile is set.
+const (
+	// CodeUnknownProfile means ValidateOptions.Profile names no built-in profile.
+	CodeUnknownProfile ValidationCode = "unknown_profile"
+	// CodeTextureSize means top mip exceeds profile MaxTextureSize.
+	CodeTextureSize ValidationCode = "texture_size"
+	// CodeFormatNotAllowed means pax format is not allowed by profile.
+	CodeFormatNotAllowed ValidationCode = "format_not_allowed"
+	// CodeSuffixNotAllowed means suffix type is not allowed by profile.
+	CodeSuffixNotAllowed ValidationCode = "suffix_not_allowed"
+)
+
+// EngineProfile describes constraints of one target game engine.
+type EngineProfile struct {
+	// Name is the profile name.
+	Name string `json:"name" yaml:"name"`
+	// Formats lists allowed pax formats.
+	Formats []PaxFormat `json:"formats" yaml:"formats"`
+	// Suffixes lists allowed suffix types.
+	Suffixes []SuffixType `json:"suffixes" yaml:"suffixes"`
+	// MaxTextureSize is the largest allowed top mip dimension.
+	MaxTextureSize int `json:"max_texture_size" yaml:"max_texture_size"`
+	// MaxEntries is the default of ValidateOptions.MaxEntries.
+	MaxEntries int `json:"max_entries" yaml:"max_entries"`
+	// MaxPathLength is the default of ValidateOptions.MaxPathLength.
+	MaxPathLength int `json:"max_path_length" yaml:"max_path_length"`
+	// MaxMipMaps is the default of ValidateOptions.MaxMipMaps.
+	MaxMipMaps int `json:"max_mipmaps" yaml:"max_mipmaps"`
+}
+
+// modernFormats are pax formats accepted by current engines.
+var modernFormats = []PaxFormat{
+	PaxFormatGRAYA, PaxFormatRGB565, PaxFormatARGBA5, PaxFormatARGB4, PaxFormatARGB8,
+	PaxFormatDXT1, PaxFormatDXT2, PaxFormatDXT3, PaxFormatDXT4, PaxFormatDXT5,
+}
+
+// engineProfiles holds built-in profiles by name.
+var engineProfiles = map[string]EngineProfile{
+	ProfileDayZ: {
+		Name:           ProfileDayZ,
+		MaxEntries:     DefaultMaxEntries,
+		MaxPathLength:  DefaultMaxPathLength,
+		MaxMipMaps:     DefaultMaxMipMaps,
+		MaxTextureSize: DefaultMaxTextureSize,
+		Formats:        modernFormats,
+		Suffixes:       knownSuffixTypes(SuffixThermalImageTextureCA),
+	},
+	ProfileArma3: {
+		Name:           ProfileArma3,
+		MaxEntries:     DefaultMaxEntries,
+		MaxPathLength:  DefaultMaxPathLength,
+		MaxMipMaps:     DefaultMaxMipMaps,
+		MaxTextureSize: DefaultMaxTextureSize,
+		Formats:        modernFormats,
+		Suffixes:       knownSuffixTypes(),
+	},
+	ProfileLegacy: {
+		Name:           ProfileLegacy,
+		MaxEntries:     DefaultMaxEntries,
+		MaxPathLength:  DefaultMaxPathLength,
+		MaxMipMaps:     DefaultMaxMipMaps,
+		MaxTextureSize: 2048,
+		Formats:        append([]PaxFormat{PaxFormatP8}, modernFormats...),
+		Suffixes:       knownSuffixTypes(),
+	},
+}
+
+// LookupProfile returns a copy of built-in profile by case-insensitive name.
+func LookupProfile(name string) (EngineProfile, bool) {
+	p, ok := engineProfiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return EngineProfile{}, false
+	}
+
+	p.Formats = slices.Clone(p.Formats)
+	p.Suffixes = slices.Clone(p.Suffixes)
+	return p, true
+}
+
+// knownSuffixTypes returns all named suffix types except excluded ones.
+func knownSuffixTypes(exclude ...SuffixType) []SuffixType {
+	out := make([]SuffixType, 0, len(suffixTypeNames))
+	for i := range suffixTypeNames {
+		if s := SuffixType(i); !slices.Contains(exclude, s) {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// profileFile resolves opts.Profile and checks every entry against it.
+func (v *validator) profileFile(f *File) {
+	if v.opts.Profile == "" {
+		return
+	}
+
+	if v.profile == nil {
+		v.add(CodeUnknownProfile, SeverityError, -1, "", "", "unknown engine profile %q", v.opts.Profile)
+		return
+	}
+
+	for i := range f.Textures {
+		v.profileEntry(v.profile, &f.Textures[i], i)
+	}
+}
+
+// profileEntry checks one entry against engine profile p.
+func (v *validator) profileEntry(p *EngineProfile, entry *TextureEntry, entryIndex int) {
+	prefix := fmt.Sprintf("texture[%d]", entryIndex)
+	if len(entry.MipMaps) > 0 {
+		w, h := mipDimensions(entry.MipMaps[0])
+		if int(w) > p.MaxTextureSize || int(h) > p.MaxTextureSize {
+			v.add(CodeTextureSize, SeverityError, entryIndex, entry.PAAFile, "mipmaps[0]",
+				"%s top mip %dx%d exceeds %s limit %d", prefix, w, h, p.Name, p.MaxTextureSize)
+		}
+	}
+
+	if !slices.Contains(p.Formats, entry.PaxFormat) {
+		v.add(CodeFormatNotAllowed, SeverityError, entryIndex, entry.PAAFile, "pax_format",
+			"%s.pax_format=%s is not allowed by %s", prefix, entry.PaxFormat, p.Name)
+	}
+
+	if !slices.Contains(p.Suffixes, entry.PaxSuffixType) {
+		v.add(CodeSuffixNotAllowed, SeverityError, entryIndex, entry.PAAFile, "pax_suffix_type",
+			"%s.pax_suffix_type=%s is not allowed by %s", prefix, entry.PaxSuffixType, p.Name)
+	}
+}
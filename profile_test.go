@@ -0,0 +1,69 @@
+package texheaders
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookupProfile(t *testing.T) {
+	t.Parallel()
+
+	p, ok := LookupProfile(" DayZ ")
+	if !ok || p.Name != ProfileDayZ || slices.Contains(p.Suffixes, SuffixThermalImageTextureCA) {
+		t.Fatalf("LookupProfile(DayZ) = %+v, %v", p, ok)
+	}
+
+	p.Formats[0] = PaxFormat(99)
+	if again, _ := LookupProfile(ProfileDayZ); again.Formats[0] == PaxFormat(99) {
+		t.Fatal("LookupProfile() shares profile slices")
+	}
+
+	if _, ok = LookupProfile("quake"); ok {
+		t.Fatal("LookupProfile(quake) = true")
+	}
+}
+
+func TestValidateFileWithOptions_Profile(t *testing.T) {
+	t.Parallel()
+
+	entry := func(path string, size uint16, format PaxFormat, suffix SuffixType) TextureEntry {
+		e := TextureEntry{PAAFile: path, PaxFormat: format, PaxSuffixType: suffix, ColorPaletteCount: 1}
+		e.MipMaps = []MipMap{{Width: size, Height: size, AlwaysThree: 3, PaxFormat: uint8(format)}}
+		e.MipMapCount, e.MipMapCountCopy = 1, 1
+		if format == PaxFormatP8 {
+			e.PalettePtr = 16
+		}
+		return e
+	}
+
+	f := &File{Textures: []TextureEntry{
+		entry("big_co.paa", 4096, PaxFormatDXT1, SuffixDiffuseSRGB),
+		entry("pal_co.pac", 256, PaxFormatP8, SuffixDiffuseSRGB),
+		entry("heat_ti_ca.paa", 512, PaxFormatDXT5, SuffixThermalImageTextureCA),
+	}}
+
+	codes := func(profile string) []ValidationCode {
+		var out []ValidationCode
+		for _, is := range ValidateFileWithOptions(f, ValidateOptions{Profile: profile}).Issues {
+			out = append(out, is.Code)
+		}
+		return out
+	}
+
+	tests := []struct {
+		profile string
+		want    []ValidationCode
+	}{
+		{profile: ""},
+		{profile: ProfileArma3, want: []ValidationCode{CodeFormatNotAllowed}},
+		{profile: ProfileDayZ, want: []ValidationCode{CodeFormatNotAllowed, CodeSuffixNotAllowed}},
+		{profile: ProfileLegacy, want: []ValidationCode{CodeTextureSize}},
+		{profile: "quake", want: []ValidationCode{CodeUnknownProfile}},
+	}
+
+	for _, tt := range tests {
+		if got := codes(tt.profile); !slices.Equal(got, tt.want) {
+			t.Fatalf("profile %q codes = %v, want %v", tt.profile, got, tt.want)
+		}
+	}
+}
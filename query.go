@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Query selects texture entries by field predicates. Set fields are combined
+// with AND, list fields accept any of their values and zero fields match
+// every entry.
+type Query struct {
+	// Paths lists slash-separated glob patterns matched case-insensitively
+	// against entry paths with any separator; "**" matches any number of
+	// directories (e.g. "**/*_co.paa").
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	// Suffixes lists accepted suffix types.
+	Suffixes []SuffixType `json:"suffixes,omitempty" yaml:"suffixes,omitempty"`
+	// Formats lists accepted pax formats.
+	Formats []PaxFormat `json:"formats,omitempty" yaml:"formats,omitempty"`
+	// Alpha, if set, matches entries whose IsAlpha flag equals it.
+	Alpha *bool `json:"alpha,omitempty" yaml:"alpha,omitempty"`
+	// MinWidth is the smallest accepted top mip width; zero means no bound.
+	MinWidth int `json:"min_width,omitempty" yaml:"min_width,omitempty"`
+	// MaxWidth is the largest accepted top mip width; zero means no bound.
+	MaxWidth int `json:"max_width,omitempty" yaml:"max_width,omitempty"`
+	// MinHeight is the smallest accepted top mip height; zero means no bound.
+	MinHeight int `json:"min_height,omitempty" yaml:"min_height,omitempty"`
+	// MaxHeight is the largest accepted top mip height; zero means no bound.
+	MaxHeight int `json:"max_height,omitempty" yaml:"max_height,omitempty"`
+}
+
+// Matcher checks q and returns predicate reporting whether entry matches it,
+// usable with File.Filter.
+func (q Query) Matcher() (func(*TextureEntry) bool, error) {
+	globs, err := compileGlobs(q.Paths)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	for _, r := range []struct {
+		name     string
+		min, max int
+	}{{"width", q.MinWidth, q.MaxWidth}, {"height", q.MinHeight, q.MaxHeight}} {
+		if r.min < 0 || r.max < 0 || (r.max > 0 && r.min > r.max) {
+			return nil, fmt.Errorf("%w: %s range [%d, %d]", ErrInvalidQuery, r.name, r.min, r.max)
+		}
+	}
+
+	return func(e *TextureEntry) bool {
+		if len(q.Suffixes) > 0 && !slices.Contains(q.Suffixes, e.PaxSuffixType) {
+			return false
+		}
+
+		if len(q.Formats) > 0 && !slices.Contains(q.Formats, e.PaxFormat) {
+			return false
+		}
+
+		if q.Alpha != nil && e.IsAlpha != *q.Alpha {
+			return false
+		}
+
+		if !inRange(int(e.Width()), q.MinWidth, q.MaxWidth) || !inRange(int(e.Height()), q.MinHeight, q.MaxHeight) {
+			return false
+		}
+
+		if len(globs) == 0 {
+			return true
+		}
+
+		segs := strings.Split(strings.ToLower(strings.ReplaceAll(e.PAAFile, "\\", "/")), "/")
+		return slices.ContainsFunc(globs, func(pat []string) bool {
+			return matchGlob(pat, segs)
+		})
+	}, nil
+}
+
+// Query returns a new file with copies of entries matching q, in original
+// order, as Filter does.
+func (f *File) Query(q Query) (*File, error) {
+	match, err := q.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Filter(match), nil
+}
+
+// inRange reports whether v is within [lo, hi], zero bounds being open.
+func inRange(v, lo, hi int) bool {
+	return v >= lo && (hi == 0 || v <= hi)
+}
@@ -0,0 +1,59 @@
+package texheaders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFile_Query(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: SupportedVersion, Textures: []TextureEntry{
+		{PAAFile: `data\rock_nohq.paa`, PaxFormat: PaxFormatDXT5, PaxSuffixType: SuffixNormalMap, MipMaps: []MipMap{{Width: 2048, Height: 2048}}},
+		{PAAFile: `data\rock_co.paa`, PaxFormat: PaxFormatDXT1, PaxSuffixType: SuffixDiffuseSRGB, MipMaps: []MipMap{{Width: 2048, Height: 2048}}},
+		{PAAFile: `Data\Small_NOHQ.paa`, PaxFormat: PaxFormatDXT5, PaxSuffixType: SuffixNormalMap, MipMaps: []MipMap{{Width: 512, Height: 512}}},
+		{PAAFile: `ui\icon_ca.paa`, PaxFormat: PaxFormatDXT5, PaxSuffixType: SuffixDiffuseSRGB, IsAlpha: true, MipMaps: []MipMap{{Width: 64, Height: 128}}},
+	}}
+
+	alpha := true
+	cases := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"all", Query{}, []string{`data\rock_nohq.paa`, `data\rock_co.paa`, `Data\Small_NOHQ.paa`, `ui\icon_ca.paa`}},
+		{"suffix format width", Query{Suffixes: []SuffixType{SuffixNormalMap}, Formats: []PaxFormat{PaxFormatDXT5}, MinWidth: 2048}, []string{`data\rock_nohq.paa`}},
+		{"any suffix", Query{Suffixes: []SuffixType{SuffixNormalMap, SuffixDiffuseSRGB}, MaxWidth: 512}, []string{`Data\Small_NOHQ.paa`, `ui\icon_ca.paa`}},
+		{"glob", Query{Paths: []string{"data/*_nohq.paa"}}, []string{`data\rock_nohq.paa`, `Data\Small_NOHQ.paa`}},
+		{"deep glob", Query{Paths: []string{"**/*_ca.paa"}}, []string{`ui\icon_ca.paa`}},
+		{"alpha height", Query{Alpha: &alpha, MinHeight: 128, MaxHeight: 128}, []string{`ui\icon_ca.paa`}},
+	}
+
+	for _, tc := range cases {
+		got, err := f.Query(tc.q)
+		if err != nil {
+			t.Fatalf("Query(%s) error: %v", tc.name, err)
+		}
+
+		var paths []string
+		for p := range got.Paths() {
+			paths = append(paths, p)
+		}
+
+		if len(paths) != len(tc.want) {
+			t.Fatalf("Query(%s) = %q, want %q", tc.name, paths, tc.want)
+		}
+
+		for i := range paths {
+			if paths[i] != tc.want[i] {
+				t.Fatalf("Query(%s) = %q, want %q", tc.name, paths, tc.want)
+			}
+		}
+	}
+
+	for _, q := range []Query{{Paths: []string{"data/[x"}}, {MinWidth: 1024, MaxWidth: 512}, {MinHeight: -1}} {
+		if _, err := f.Query(q); !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("Query(%+v) error = %v, want %v", q, err, ErrInvalidQuery)
+		}
+	}
+}
@@ -163,7 +163,7 @@ func (d *decoder) readTextureEntry() (TextureEntry, error) {
 		return entry, fmt.Errorf("read pax format: %w", err)
 	}
 
-	entry.PaxFormat = paxFormat
+	entry.PaxFormat = PaxFormat(paxFormat)
 
 	if entry.LittleEndian, err = d.readBool8(); err != nil {
 		return entry, fmt.Errorf("read little_endian: %w", err)
@@ -185,7 +185,7 @@ func (d *decoder) readTextureEntry() (TextureEntry, error) {
 		return entry, fmt.Errorf("read pax suffix type: %w", err)
 	}
 
-	entry.PaxSuffixType = paxSuffixType
+	entry.PaxSuffixType = SuffixType(paxSuffixType)
 
 	mipCountCopy, err := d.readU32()
 	if err != nil {
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RepairReport lists changes made by Repair.
+type RepairReport struct {
+	// Fixes holds applied fixes in file order.
+	Fixes []RepairFix `json:"fixes,omitempty" yaml:"fixes,omitempty"`
+}
+
+// RepairFix is one corrected field value.
+type RepairFix struct {
+	// Path is the entry path.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Field is the corrected field, e.g. "mipmap_count" or "mipmaps[2].always_three".
+	Field string `json:"field" yaml:"field"`
+	// Old is the previous value.
+	Old string `json:"old" yaml:"old"`
+	// New is the corrected value.
+	New string `json:"new" yaml:"new"`
+	// Index is the entry index.
+	Index int `json:"index" yaml:"index"`
+}
+
+// Repair fixes mechanically correctable inconsistencies of f in place:
+// mip count fields disagreeing with len(MipMaps), mip AlwaysZero and
+// AlwaysThree constants and mip PaxFormat disagreeing with the entry.
+//
+// The report lists every change. Remaining problems are returned as
+// validation error of the repaired file.
+func Repair(f *File) (*RepairReport, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+
+	return repairFile(f), ValidateFile(f)
+}
+
+// repairFile applies Repair fixes to f in place and reports them.
+func repairFile(f *File) *RepairReport {
+	r := &RepairReport{}
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		fix := func(field string, old, v uint64) {
+			r.Fixes = append(r.Fixes, RepairFix{
+				Index: i,
+				Path:  e.PAAFile,
+				Field: field,
+				Old:   strconv.FormatUint(old, 10),
+				New:   strconv.FormatUint(v, 10),
+			})
+		}
+
+		if mipCount, err := intToU32Strict(len(e.MipMaps)); err == nil {
+			if e.MipMapCount != mipCount {
+				fix("mipmap_count", uint64(e.MipMapCount), uint64(mipCount))
+				e.MipMapCount = mipCount
+			}
+
+			if e.MipMapCountCopy != mipCount {
+				fix("mipmap_count_copy", uint64(e.MipMapCountCopy), uint64(mipCount))
+				e.MipMapCountCopy = mipCount
+			}
+		}
+
+		mipFormat, fits := e.PaxFormat.mipFormat()
+		for j := range e.MipMaps {
+			m := &e.MipMaps[j]
+			if m.AlwaysZero != 0 {
+				fix(fmt.Sprintf("mipmaps[%d].always_zero", j), uint64(m.AlwaysZero), 0)
+				m.AlwaysZero = 0
+			}
+
+			if m.AlwaysThree != 3 {
+				fix(fmt.Sprintf("mipmaps[%d].always_three", j), uint64(m.AlwaysThree), 3)
+				m.AlwaysThree = 3
+			}
+
+			if fits && m.PaxFormat != mipFormat {
+				fix(fmt.Sprintf("mipmaps[%d].pax_format", j), uint64(m.PaxFormat), uint64(mipFormat))
+				m.PaxFormat = mipFormat
+			}
+		}
+	}
+
+	return r
+}
+
+// ValidateAndFix returns a corrected copy of f and validation report of the
+// copy. Issues marked Fixable are corrected: mip counts, mip constant fields
+// and mip pax format (as Repair does) and AverageColorF diverging from
+// AverageColor beyond opts.ColorEpsilon, recomputed from the bytes. Other
+// problems are ambiguous and stay in the report; the error joins its
+// error-severity issues. f itself is not modified.
+func ValidateAndFix(f *File, opts ValidateOptions) (*File, *ValidationReport, error) {
+	if f == nil {
+		rep := ValidateFileWithOptions(nil, opts)
+		return nil, rep, rep.Err()
+	}
+
+	out := f.Clone()
+	repairFile(out)
+	for _, is := range ValidateFileWithOptions(out, opts).Issues {
+		if is.Code == CodeColorMismatch {
+			out.Textures[is.Entry].syncAverageColorF()
+		}
+	}
+
+	rep := ValidateFileWithOptions(out, opts)
+	return out, rep, rep.Err()
+}
@@ -0,0 +1,96 @@
+package texheaders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepair(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: SupportedVersion, Textures: []TextureEntry{{
+		PAAFile:           "a_co.paa",
+		ColorPaletteCount: 1,
+		PaxFormat:         PaxFormatDXT5,
+		MipMapCount:       3,
+		MipMapCountCopy:   2,
+		MipMaps: []MipMap{
+			{Width: 4, Height: 4, PaxFormat: 10, AlwaysThree: 3},
+			{Width: 2, Height: 2, PaxFormat: 6, AlwaysThree: 0, AlwaysZero: 1},
+		},
+	}}}
+
+	r, err := Repair(f)
+	if err != nil {
+		t.Fatalf("Repair() error: %v", err)
+	}
+
+	want := []RepairFix{
+		{Path: "a_co.paa", Field: "mipmap_count", Old: "3", New: "2"},
+		{Path: "a_co.paa", Field: "mipmaps[1].always_zero", Old: "1", New: "0"},
+		{Path: "a_co.paa", Field: "mipmaps[1].always_three", Old: "0", New: "3"},
+		{Path: "a_co.paa", Field: "mipmaps[1].pax_format", Old: "6", New: "10"},
+	}
+	if len(r.Fixes) != len(want) {
+		t.Fatalf("Repair() fixes = %+v", r.Fixes)
+	}
+
+	for i := range want {
+		if r.Fixes[i] != want[i] {
+			t.Fatalf("fix[%d] = %+v, want %+v", i, r.Fixes[i], want[i])
+		}
+	}
+
+	if r, err = Repair(f); err != nil || len(r.Fixes) != 0 {
+		t.Fatalf("Repair(repaired) = %+v, %v", r, err)
+	}
+
+	f.Textures[0].PAAFile = ""
+	if _, err = Repair(f); !errors.Is(err, ErrValidation) {
+		t.Fatalf("Repair(unfixable) error = %v, want ErrValidation", err)
+	}
+
+	if _, err = Repair(nil); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("Repair(nil) error = %v, want ErrNilFile", err)
+	}
+}
+
+func TestValidateAndFix(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	f.Textures[0].MipMapCount++
+	f.Textures[0].MipMaps[0].AlwaysThree = 0
+	f.Textures[1].AverageColorF = [4]float32{1, 1, 1, 1}
+	f.Textures[1].AverageColor = [4]byte{0, 0, 0, 0xFF}
+
+	before := ValidateFileReport(f)
+	for _, is := range before.Issues {
+		if !is.Fixable {
+			t.Fatalf("issue not fixable: %+v", is)
+		}
+	}
+
+	fixed, rep, err := ValidateAndFix(f, ValidateOptions{})
+	if err != nil || len(rep.Issues) != 0 {
+		t.Fatalf("ValidateAndFix() = %+v, %v", rep.Issues, err)
+	}
+
+	if fixed.Textures[1].AverageColorF != [4]float32{0, 0, 0, 1} || fixed.Textures[0].MipMaps[0].AlwaysThree != 3 {
+		t.Fatalf("ValidateAndFix() did not fix entries: %+v", fixed.Textures[:2])
+	}
+
+	if f.Textures[0].MipMaps[0].AlwaysThree != 0 {
+		t.Fatal("ValidateAndFix() modified input")
+	}
+
+	f.Textures[2].PAAFile = ""
+	if _, rep, err = ValidateAndFix(f, ValidateOptions{}); !errors.Is(err, ErrValidation) ||
+		len(rep.Issues) != 1 || rep.Issues[0].Code != CodeEmptyPath || rep.Issues[0].Fixable {
+		t.Fatalf("ValidateAndFix(ambiguous) = %+v, %v", rep.Issues, err)
+	}
+}
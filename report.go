@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// ReportSuffix is appended to output path by ReportPath.
+const ReportSuffix = ".report.json"
+
+// BuildReport is a JSON-serializable audit record of the last build.
+type BuildReport struct {
+	// GeneratedAt is the report creation time.
+	GeneratedAt time.Time `json:"generated_at" yaml:"generated_at"`
+	// Options holds options used by the build.
+	Options BuildOptions `json:"options" yaml:"options"`
+	// Inputs lists built inputs in build order.
+	Inputs []string `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	// Entries summarizes produced entries in output order.
+	Entries []ReportEntry `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// Issues holds skipped inputs and warnings.
+	Issues []BuildIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+	// Duplicates holds byte-identical source groups with DetectDuplicates.
+	Duplicates []DuplicateGroup `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+	// Stats holds aggregate statistics and timings.
+	Stats BuildStats `json:"stats" yaml:"stats"`
+}
+
+// ReportEntry is a short summary of one produced entry.
+type ReportEntry struct {
+	// Input is the source path the entry was built from.
+	Input string `json:"input" yaml:"input"`
+	// Path is the stored entry path.
+	Path string `json:"path" yaml:"path"`
+	// PaxFormat is the entry pax format.
+	PaxFormat PaxFormat `json:"pax_format" yaml:"pax_format"`
+	// SuffixType is the resolved suffix type.
+	SuffixType SuffixType `json:"suffix_type" yaml:"suffix_type"`
+	// FileSize is the source file size.
+	FileSize uint32 `json:"file_size" yaml:"file_size"`
+	// MipMaps is the number of mip levels.
+	MipMaps uint32 `json:"mipmaps" yaml:"mipmaps"`
+	// Width is the top mip width.
+	Width uint16 `json:"width" yaml:"width"`
+	// Height is the top mip height.
+	Height uint16 `json:"height" yaml:"height"`
+}
+
+// newReportEntry summarizes entry built from input.
+func newReportEntry(input string, entry *TextureEntry) ReportEntry {
+	r := ReportEntry{
+		Input:      input,
+		Path:       entry.PAAFile,
+		PaxFormat:  entry.PaxFormat,
+		SuffixType: entry.PaxSuffixType,
+		FileSize:   entry.PaxFileSize,
+		MipMaps:    entry.MipMapCount,
+	}
+
+	if len(entry.MipMaps) > 0 {
+		r.Width, r.Height = mipDimensions(entry.MipMaps[0])
+	}
+
+	return r
+}
+
+// Report returns audit record of the last build.
+func (b *Builder) Report() BuildReport {
+	return BuildReport{
+		GeneratedAt: time.Now().UTC(),
+		Options:     b.opts,
+		Inputs:      slices.Clone(b.lastInputs),
+		Entries:     slices.Clone(b.reportEntries),
+		Issues:      b.Issues(),
+		Duplicates:  b.Duplicates(),
+		Stats:       b.Stats(),
+	}
+}
+
+// WriteReport writes indented JSON report of the last build to w.
+func (b *Builder) WriteReport(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(b.Report()); err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteReportFile writes JSON report of the last build into file path.
+// Use ReportPath to place it next to the output file.
+func (b *Builder) WriteReportFile(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %q: %w", path, err)
+	}
+
+	defer func() {
+		_ = out.Close()
+	}()
+
+	if err = b.WriteReport(out); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReportPath returns report path next to texheaders output path.
+func ReportPath(outputPath string) string {
+	return outputPath + ReportSuffix
+}
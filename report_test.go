@@ -0,0 +1,77 @@
+package texheaders
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_Report(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{BaseDir: "testdata", SkipInvalid: true, ReportEntries: true})
+	if err := b.AppendMany("testdata/test_co.paa", "testdata/missing_co.paa"); err != nil {
+		t.Fatalf("AppendMany() error: %v", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "texHeaders.bin")
+	if err := b.WriteFile(out); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	if err := b.WriteReportFile(ReportPath(out)); err != nil {
+		t.Fatalf("WriteReportFile() error: %v", err)
+	}
+
+	data, err := os.ReadFile(out + ".report.json")
+	if err != nil {
+		t.Fatalf("ReadFile(report) error: %v", err)
+	}
+
+	var rep BuildReport
+	if err = json.Unmarshal(data, &rep); err != nil {
+		t.Fatalf("Unmarshal(report) error: %v", err)
+	}
+
+	if len(rep.Inputs) != 2 || len(rep.Entries) != 1 || len(rep.Issues) != 1 || !rep.Options.SkipInvalid {
+		t.Fatalf("report = %+v", rep)
+	}
+
+	e := rep.Entries[0]
+	if e.Input != "testdata/test_co.paa" || e.Path != "test_co.paa" || e.Width != 128 || e.PaxFormat != 6 {
+		t.Fatalf("report entry = %+v", e)
+	}
+
+	if rep.Issues[0].Kind != IssueNotFound || rep.Stats.Entries != 1 || rep.GeneratedAt.IsZero() {
+		t.Fatalf("report issues=%+v stats=%+v", rep.Issues, rep.Stats)
+	}
+}
+
+func TestBuilder_ReportEntriesOptIn(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "texHeaders.bin")
+	for _, opts := range []BuildOptions{{BaseDir: "testdata"}, {BaseDir: "testdata", ReportEntries: true}} {
+		b := NewBuilder(opts)
+		if err := b.Append("testdata/test_co.paa"); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+
+		if err := b.WriteStreamFile(out); err != nil {
+			t.Fatalf("WriteStreamFile() error: %v", err)
+		}
+
+		if rep := b.Report(); len(rep.Entries) != 0 || rep.Stats.Entries != 1 {
+			t.Fatalf("ReportEntries=%v stream report = %+v", opts.ReportEntries, rep)
+		}
+
+		if _, err := b.Build(); err != nil {
+			t.Fatalf("Build() error: %v", err)
+		}
+
+		if n := len(b.Report().Entries); (n == 1) != opts.ReportEntries {
+			t.Fatalf("ReportEntries=%v build report entries = %d", opts.ReportEntries, n)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+// RetryOptions controls retries of transient I/O errors per input.
+//
+// Transient errors are file system errors other than missing file or denied
+// permission, as met on flaky SMB/NFS shares. Format errors are never retried.
+type RetryOptions struct {
+	// Attempts is the total number of tries per input; values below 2 disable retry.
+	Attempts int `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	// Backoff is the delay before second try, doubled for every next one.
+	Backoff time.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// MaxBackoff caps the delay between tries; zero means no cap.
+	MaxBackoff time.Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+}
+
+// buildWithRetry runs entry build of path, retrying transient errors by BuildOptions.Retry.
+func (b *Builder) buildWithRetry(path string, build func() (TextureEntry, error)) (TextureEntry, error) {
+	entry, err := build()
+	for attempt := 1; err != nil && attempt < b.opts.Retry.Attempts && isTransientError(err); attempt++ {
+		delay := b.opts.Retry.delay(attempt)
+		b.logger().Debug("texture retry", "path", path, "attempt", attempt+1, "delay", delay, "error", err)
+		time.Sleep(delay)
+		entry, err = build()
+	}
+
+	return entry, err
+}
+
+// delay returns wait time after failed attempt, counted from 1.
+func (r RetryOptions) delay(attempt int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < attempt && d > 0; i++ {
+		if r.MaxBackoff > 0 && d >= r.MaxBackoff {
+			break
+		}
+
+		d *= 2
+	}
+
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+
+	return d
+}
+
+// isTransientError reports whether err is a file system error worth retrying.
+func isTransientError(err error) bool {
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) {
+		return false
+	}
+
+	return !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, fs.ErrPermission)
+}
@@ -0,0 +1,79 @@
+package texheaders
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryOptionsDelay(t *testing.T) {
+	t.Parallel()
+
+	r := RetryOptions{Backoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	want := []time.Duration{10, 20, 40, 50, 50}
+	for i, w := range want {
+		if got := r.delay(i + 1); got != w*time.Millisecond {
+			t.Fatalf("delay(%d) = %v, want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: fmt.Errorf("open source: %w", &fs.PathError{Op: "read", Path: "x", Err: syscall.EIO}), want: true},
+		{err: fmt.Errorf("%w: scan: %w", ErrCorruptSource, &fs.PathError{Op: "read", Path: "x", Err: syscall.EIO}), want: true},
+		{err: &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}},
+		{err: &fs.PathError{Op: "open", Path: "x", Err: fs.ErrPermission}},
+		{err: fmt.Errorf("%w: bad tag", ErrCorruptSource)},
+	}
+
+	for _, tt := range tests {
+		if got := isTransientError(tt.err); got != tt.want {
+			t.Fatalf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_BuildWithRetry(t *testing.T) {
+	t.Parallel()
+
+	transient := &fs.PathError{Op: "read", Path: "x", Err: syscall.EIO}
+	b := NewBuilder(BuildOptions{Retry: RetryOptions{Attempts: 3, Backoff: time.Millisecond}})
+
+	calls := 0
+	entry, err := b.buildWithRetry("x", func() (TextureEntry, error) {
+		calls++
+		if calls < 3 {
+			return TextureEntry{}, transient
+		}
+
+		return TextureEntry{PAAFile: "x"}, nil
+	})
+	if err != nil || entry.PAAFile != "x" || calls != 3 {
+		t.Fatalf("buildWithRetry() = %q, %v after %d calls", entry.PAAFile, err, calls)
+	}
+
+	calls = 0
+	if _, err = b.buildWithRetry("x", func() (TextureEntry, error) {
+		calls++
+		return TextureEntry{}, transient
+	}); !errors.Is(err, syscall.EIO) || calls != 3 {
+		t.Fatalf("buildWithRetry() error = %v after %d calls, want EIO after 3", err, calls)
+	}
+
+	calls = 0
+	if _, err = b.buildWithRetry("x", func() (TextureEntry, error) {
+		calls++
+		return TextureEntry{}, fmt.Errorf("%w: bad tag", ErrCorruptSource)
+	}); err == nil || calls != 1 {
+		t.Fatalf("buildWithRetry() error = %v after %d calls, want no retry", err, calls)
+	}
+}
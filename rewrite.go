@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathRewriter maps stored entry path to a new one.
+type PathRewriter func(path string) (string, error)
+
+// RewritePaths replaces path of every entry with rewrite result.
+//
+// The file is changed only when all paths rewrite without error, none is
+// empty and no two entries end up with the same normalized path.
+func (f *File) RewritePaths(rewrite PathRewriter) error {
+	paths := make([]string, len(f.Textures))
+	seen := make(map[string]string, len(f.Textures))
+	for i := range f.Textures {
+		old := f.Textures[i].PAAFile
+		p, err := rewrite(old)
+		if err != nil {
+			return fmt.Errorf("rewrite %q: %w", old, err)
+		}
+
+		if strings.TrimSpace(p) == "" {
+			return fmt.Errorf("rewrite %q: %w", old, ErrEmptyInputPath)
+		}
+
+		key := indexKey(p, IndexOptions{})
+		if prev, ok := seen[key]; ok {
+			return fmt.Errorf("rewrite %q: %w: %s (also from %q)", old, ErrEntryExists, p, prev)
+		}
+
+		seen[key] = old
+		paths[i] = p
+	}
+
+	for i := range f.Textures {
+		f.Textures[i].PAAFile = paths[i]
+	}
+
+	return nil
+}
+
+// ReplacePathPrefix returns rewriter moving paths under from to to.
+//
+// Prefix is matched case-insensitively on whole segments with any separator;
+// other paths are kept. Results use backslash separators.
+func ReplacePathPrefix(from, to string) PathRewriter {
+	rules := []PathRemap{{From: from, To: to}}
+	return func(path string) (string, error) {
+		if p, ok := remapPath(path, rules); ok {
+			return strings.ReplaceAll(p, "/", "\\"), nil
+		}
+
+		return path, nil
+	}
+}
+
+// CanonicalPath returns path in engine form: lowercase, backslash separators,
+// without leading separators or "./" prefix.
+func CanonicalPath(path string) (string, error) {
+	return indexKey(path, IndexOptions{}), nil
+}
+
+// ChainRewriters returns rewriter applying rewriters in order.
+func ChainRewriters(rewriters ...PathRewriter) PathRewriter {
+	return func(path string) (string, error) {
+		var err error
+		for _, r := range rewriters {
+			if path, err = r(path); err != nil {
+				return "", err
+			}
+		}
+
+		return path, nil
+	}
+}
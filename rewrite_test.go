@@ -0,0 +1,55 @@
+package texheaders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFileRewritePaths(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `OldMod\Data\Gun_co.paa`},
+		{PAAFile: "oldmod/data/gun_nohq.paa"},
+		{PAAFile: `oldmodextra\x_co.paa`},
+	}}
+
+	err := f.RewritePaths(ChainRewriters(ReplacePathPrefix(`oldmod`, `neworg\newmod`), CanonicalPath))
+	if err != nil {
+		t.Fatalf("RewritePaths() error: %v", err)
+	}
+
+	want := []string{`neworg\newmod\data\gun_co.paa`, `neworg\newmod\data\gun_nohq.paa`, `oldmodextra\x_co.paa`}
+	for i, path := range want {
+		if f.Textures[i].PAAFile != path {
+			t.Fatalf("texture[%d] = %q, want %q", i, f.Textures[i].PAAFile, path)
+		}
+	}
+
+	err = f.RewritePaths(func(string) (string, error) { return "same.paa", nil })
+	if !errors.Is(err, ErrEntryExists) {
+		t.Fatalf("RewritePaths(collision) error = %v, want ErrEntryExists", err)
+	}
+
+	boom := errors.New("boom")
+	calls := 0
+	err = f.RewritePaths(func(p string) (string, error) {
+		calls++
+		if calls == 2 {
+			return "", boom
+		}
+
+		return "x" + p, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("RewritePaths(error) = %v, want boom", err)
+	}
+
+	if f.Textures[0].PAAFile != want[0] {
+		t.Fatal("failed RewritePaths() must not change file")
+	}
+
+	if err = f.RewritePaths(func(string) (string, error) { return " ", nil }); !errors.Is(err, ErrEmptyInputPath) {
+		t.Fatalf("RewritePaths(empty) error = %v, want ErrEmptyInputPath", err)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import "time"
+
+// maxAdaptiveWorkers caps worker pool size in WorkersAdaptive mode.
+const maxAdaptiveWorkers = 64
+
+// adaptiveGain is the minimal throughput ratio that justifies more workers.
+const adaptiveGain = 1.1
+
+// workerScaler limits active workers and tunes the limit from measured throughput.
+//
+// Starting from initial count, active workers are doubled after every batch
+// while throughput keeps improving; on the first batch without gain the
+// previous count is restored and the scaler settles.
+type workerScaler struct {
+	start    time.Time        // start is the beginning of current batch.
+	now      func() time.Time // now returns current time.
+	tokens   chan struct{}    // tokens holds one token per active worker slot.
+	prevRate float64          // prevRate is throughput of previous batch.
+	active   int              // active is the current worker limit.
+	max      int              // max is the worker pool size.
+	seen     int              // seen is the number of results in current batch.
+	settled  bool             // settled stops further tuning.
+}
+
+// newWorkerScaler creates scaler with initial active workers out of maxWorkers pool.
+func newWorkerScaler(initial, maxWorkers int) *workerScaler {
+	initial = min(max(initial, 1), maxWorkers)
+	s := &workerScaler{
+		now:    time.Now,
+		tokens: make(chan struct{}, maxWorkers),
+		active: initial,
+		max:    maxWorkers,
+	}
+
+	for range initial {
+		s.tokens <- struct{}{}
+	}
+
+	s.start = s.now()
+	return s
+}
+
+// acquire blocks until worker slot is available.
+func (s *workerScaler) acquire() {
+	<-s.tokens
+}
+
+// release returns worker slot.
+func (s *workerScaler) release() {
+	s.tokens <- struct{}{}
+}
+
+// observe records one collected result and adjusts active workers after each batch.
+// It must be called from a single goroutine.
+func (s *workerScaler) observe() {
+	if s.settled {
+		return
+	}
+
+	s.seen++
+	if s.seen < s.batchSize() {
+		return
+	}
+
+	elapsed := s.now().Sub(s.start).Seconds()
+	rate := float64(s.seen) / max(elapsed, 1e-9)
+
+	switch {
+	case s.prevRate > 0 && rate < s.prevRate*adaptiveGain:
+		// No gain from last doubling, fall back to previous count.
+		s.shrink(s.active / 2)
+		s.settled = true
+	case s.active*2 > s.max:
+		s.settled = true
+	default:
+		s.grow(s.active)
+	}
+
+	s.prevRate = rate
+	s.seen = 0
+	s.start = s.now()
+}
+
+// batchSize returns number of results measured per step.
+func (s *workerScaler) batchSize() int {
+	return max(s.active*8, 16)
+}
+
+// grow adds n worker slots.
+func (s *workerScaler) grow(n int) {
+	for range n {
+		s.tokens <- struct{}{}
+	}
+
+	s.active += n
+}
+
+// shrink removes n worker slots, waiting for busy workers to release them.
+func (s *workerScaler) shrink(n int) {
+	for range n {
+		<-s.tokens
+	}
+
+	s.active -= n
+}
@@ -0,0 +1,72 @@
+package texheaders
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerScaler_GrowsWhileFaster(t *testing.T) {
+	t.Parallel()
+
+	s := newWorkerScaler(2, 16)
+	clock := time.Unix(0, 0)
+	s.now = func() time.Time { return clock }
+	s.start = clock
+
+	// Every batch takes one second regardless of workers: rate doubles with batch size.
+	step := func() {
+		n := s.batchSize()
+		clock = clock.Add(time.Second)
+		for range n {
+			s.observe()
+		}
+	}
+
+	step()
+	if s.active != 4 {
+		t.Fatalf("active after first batch = %d, want 4", s.active)
+	}
+
+	step()
+	step()
+	if s.active != 16 || s.settled {
+		t.Fatalf("active = %d settled=%v, want 16 not settled", s.active, s.settled)
+	}
+
+	step()
+	if s.active != 16 || !s.settled {
+		t.Fatalf("active = %d settled=%v, want 16 settled at pool size", s.active, s.settled)
+	}
+}
+
+func TestWorkerScaler_FallsBackWithoutGain(t *testing.T) {
+	t.Parallel()
+
+	s := newWorkerScaler(4, 32)
+	clock := time.Unix(0, 0)
+	s.now = func() time.Time { return clock }
+	s.start = clock
+
+	// Constant throughput of 100 results per second.
+	step := func() {
+		n := s.batchSize()
+		clock = clock.Add(time.Duration(n) * 10 * time.Millisecond)
+		for range n {
+			s.observe()
+		}
+	}
+
+	step()
+	if s.active != 8 {
+		t.Fatalf("active after first batch = %d, want 8", s.active)
+	}
+
+	step()
+	if s.active != 4 || !s.settled {
+		t.Fatalf("active = %d settled=%v, want 4 settled", s.active, s.settled)
+	}
+
+	if got := len(s.tokens); got != 4 {
+		t.Fatalf("free tokens = %d, want 4", got)
+	}
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/woozymasta/bcn"
+	"github.com/woozymasta/paa"
+	"github.com/woozymasta/paa/texconfig"
+)
+
+// Source image extensions accepted with BuildOptions.AllowSourceImages.
+const (
+	sourceExtPNG = ".png"
+	sourceExtTGA = ".tga"
+	sourceExtDDS = ".dds"
+)
+
+// errInvalidSourceImage means source image header or payload is malformed.
+var errInvalidSourceImage = errors.New("invalid source image")
+
+// ddsPaxTypes maps DDS payload formats to pax types kept on conversion.
+var ddsPaxTypes = map[bcn.Format]paa.PaxType{
+	bcn.FormatDXT1:  paa.PaxDXT1,
+	bcn.FormatDXT3:  paa.PaxDXT3,
+	bcn.FormatDXT5:  paa.PaxDXT5,
+	bcn.FormatRGBA8: paa.PaxARGB8,
+	bcn.FormatBGRA8: paa.PaxARGB8,
+}
+
+// sourceImage is decoded source art with pax format it must keep.
+type sourceImage struct {
+	img    image.Image
+	format paa.PaxType // format is zero when the converter picks it.
+}
+
+// byteCounter is io.Writer discarding data and counting its length.
+type byteCounter int64
+
+// Write implements io.Writer.
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// isSourceImageExt reports whether ext is a convertible source image extension.
+func isSourceImageExt(ext string) bool {
+	switch ext {
+	case sourceExtPNG, sourceExtTGA, sourceExtDDS:
+		return true
+	default:
+		return false
+	}
+}
+
+// deriveEntry builds entry for source image from paa encoder output.
+//
+// The image is encoded with options TexConvert hints select for its name, so
+// format, tags, mip offsets and file size match a real conversion; only the
+// encoded size is kept, not the data.
+func (b *Builder) deriveEntry(r io.Reader, rel, ext string) (TextureEntry, error) {
+	var entry TextureEntry
+
+	src, err := decodeSourceImage(r, ext)
+	if err != nil {
+		return entry, err
+	}
+
+	rel = rel[:len(rel)-len(ext)] + ".paa"
+	opts, err := sourceEncodeOptions(src.img, rel)
+	if err != nil {
+		return entry, err
+	}
+
+	if src.format != 0 {
+		opts.Type = src.format
+	}
+
+	var size byteCounter
+	meta, err := paa.EncodeWithOptionsAndMetadataHeaders(&size, src.img, opts)
+	if err != nil {
+		return entry, fmt.Errorf("encode %s: %w", rel, err)
+	}
+
+	paxFormat, err := paxTypeToU8(meta.Type)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.ColorPaletteCount = 1
+	entry.TransparentColor = 0xFFFFFFFF
+	entry.LittleEndian = true
+	entry.IsPAA = true
+	entry.PAAFile = rel
+	entry.PaxFormat = PaxFormat(paxFormat)
+	entry.PaxSuffixType = b.resolveSuffixType(rel)
+
+	assignColorHeaders(&entry, meta)
+	assignFlagHeaders(&entry, meta)
+	if err = assignMipmaps(&entry, meta.MipHeaders, paxFormat); err != nil {
+		return entry, err
+	}
+
+	if entry.PaxFileSize, err = int64ToU32Strict(int64(size)); err != nil {
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+// sourceEncodeOptions returns encode options the default TexConvert hints
+// select for rel, or auto DXT1/DXT5 selection when no hint matches.
+func sourceEncodeOptions(img image.Image, rel string) (*paa.EncodeOptions, error) {
+	cfg, err := texconfig.DefaultTexConvertConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	hint, ok := texconfig.Resolve(rel, cfg)
+	if !ok {
+		return &paa.EncodeOptions{UseLZO: !cfg.DisableLZO}, nil
+	}
+
+	opts, err := paa.EncodeOptionsFromHint(img, hint, cfg, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s hint %s: %w", errInvalidSourceImage, rel, hint.ClassName, err)
+	}
+
+	return opts, nil
+}
+
+// decodeSourceImage decodes supported source image; DDS keeps its format.
+func decodeSourceImage(r io.Reader, ext string) (sourceImage, error) {
+	var (
+		src sourceImage
+		err error
+	)
+
+	switch ext {
+	case sourceExtPNG:
+		src.img, err = png.Decode(r)
+	case sourceExtTGA:
+		src.img, err = decodeTGA(r)
+	case sourceExtDDS:
+		var dds *bcn.DDS
+		if dds, src.img, err = bcn.DecodeDDS(r); err == nil {
+			src.format = ddsPaxTypes[dds.Format]
+		}
+	default:
+		return src, fmt.Errorf("%w: %s", ErrUnsupportedInputFormat, ext)
+	}
+
+	if err != nil {
+		return src, fmt.Errorf("%w: decode %s: %w", errInvalidSourceImage, strings.TrimPrefix(ext, "."), err)
+	}
+
+	return src, nil
+}
+
+// decodeTGA decodes uncompressed and RLE true-color or grayscale TGA image.
+func decodeTGA(r io.Reader) (image.Image, error) {
+	var hdr [18]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	imageType := hdr[2]
+	width := int(binary.LittleEndian.Uint16(hdr[12:14]))
+	height := int(binary.LittleEndian.Uint16(hdr[14:16]))
+	bpp := int(hdr[16])
+	topDown := hdr[17]&0x20 != 0
+
+	rle := imageType == 10 || imageType == 11
+	gray := imageType == 3 || imageType == 11
+	switch {
+	case hdr[1] != 0, imageType != 2 && imageType != 3 && !rle:
+		return nil, fmt.Errorf("%w: tga image type %d", errInvalidSourceImage, imageType)
+	case gray && bpp != 8, !gray && bpp != 24 && bpp != 32:
+		return nil, fmt.Errorf("%w: tga depth %d", errInvalidSourceImage, bpp)
+	case width == 0 || height == 0:
+		return nil, fmt.Errorf("%w: tga dimensions %dx%d", errInvalidSourceImage, width, height)
+	}
+
+	if _, err := io.CopyN(io.Discard, r, int64(hdr[0])); err != nil {
+		return nil, fmt.Errorf("skip image id: %w", err)
+	}
+
+	pixelSize := bpp / 8
+	data := make([]byte, width*height*pixelSize)
+	if rle {
+		if err := readTGARLE(r, data, pixelSize); err != nil {
+			return nil, err
+		}
+	} else if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("read pixels: %w", err)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		row := y
+		if !topDown {
+			row = height - 1 - y
+		}
+
+		for x := range width {
+			px := data[(row*width+x)*pixelSize:]
+			c := color.NRGBA{A: 0xFF}
+			switch pixelSize {
+			case 1:
+				c.R, c.G, c.B = px[0], px[0], px[0]
+			case 3:
+				c.B, c.G, c.R = px[0], px[1], px[2]
+			case 4:
+				c.B, c.G, c.R, c.A = px[0], px[1], px[2], px[3]
+			}
+
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img, nil
+}
+
+// readTGARLE unpacks TGA run-length encoded packets into data.
+func readTGARLE(r io.Reader, data []byte, pixelSize int) error {
+	var head [1]byte
+	px := make([]byte, pixelSize)
+
+	for pos := 0; pos < len(data); {
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return fmt.Errorf("read rle packet: %w", err)
+		}
+
+		count := int(head[0]&0x7F) + 1
+		if pos+count*pixelSize > len(data) {
+			return fmt.Errorf("%w: tga rle overflow", errInvalidSourceImage)
+		}
+
+		if head[0]&0x80 == 0 {
+			if _, err := io.ReadFull(r, data[pos:pos+count*pixelSize]); err != nil {
+				return fmt.Errorf("read rle raw packet: %w", err)
+			}
+
+			pos += count * pixelSize
+			continue
+		}
+
+		if _, err := io.ReadFull(r, px); err != nil {
+			return fmt.Errorf("read rle run packet: %w", err)
+		}
+
+		for range count {
+			pos += copy(data[pos:], px)
+		}
+	}
+
+	return nil
+}
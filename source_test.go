@@ -0,0 +1,151 @@
+package texheaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/woozymasta/bcn"
+	"github.com/woozymasta/paa"
+)
+
+// writeTestPNG writes uniform PNG image.
+func writeTestPNG(t *testing.T, path string, w, h int, c color.NRGBA) {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error: %v", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile(%s) error: %v", path, err)
+	}
+}
+
+func TestBuilder_SourceImagePNG(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestPNG(t, filepath.Join(dir, "gun_co.png"), 128, 128, color.NRGBA{R: 10, G: 20, B: 30, A: 0xFF})
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, AllowSourceImages: true})
+	if err := b.Append(filepath.Join(dir, "gun_co.png")); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	e := got.Textures[0]
+	if e.PAAFile != "gun_co.paa" {
+		t.Fatalf("path = %q, want %q", e.PAAFile, "gun_co.paa")
+	}
+
+	// Layout comes from paa encoder output: DXT1 chain down to 4x4 after
+	// CGVA, CXAM and SFFO taggs, as in testdata/test_co.paa.
+	if e.PaxFormat != PaxFormatDXT1 || e.MipMapCount != 6 || e.MipMaps[0].DataOffset != 0x70 || e.MipMaps[5].Width != 4 {
+		t.Fatalf("layout = format %s mips %d offset %d", e.PaxFormat, e.MipMapCount, e.MipMaps[0].DataOffset)
+	}
+
+	if last := e.MipMaps[5]; e.PaxFileSize <= last.DataOffset {
+		t.Fatalf("pax_file_size = %d, want past last mip at %d", e.PaxFileSize, last.DataOffset)
+	}
+
+	if e.AverageColor != [4]byte{30, 20, 10, 0xFF} || e.IsAlpha {
+		t.Fatalf("average color = %v alpha = %v", e.AverageColor, e.IsAlpha)
+	}
+
+	if err = ValidateEntry(&e, 0); err != nil {
+		t.Fatalf("ValidateEntry() error: %v", err)
+	}
+}
+
+func TestBuilder_SourceImageDisabled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestPNG(t, filepath.Join(dir, "gun_co.png"), 4, 4, color.NRGBA{A: 0xFF})
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err := b.Append(filepath.Join(dir, "gun_co.png")); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if _, err := b.Build(); !errors.Is(err, ErrUnsupportedInputFormat) {
+		t.Fatalf("Build() error = %v, want %v", err, ErrUnsupportedInputFormat)
+	}
+}
+
+func TestDecodeTGA_RLEWithAlpha(t *testing.T) {
+	t.Parallel()
+
+	hdr := make([]byte, 18)
+	hdr[2] = 10 // RLE true color
+	binary.LittleEndian.PutUint16(hdr[12:], 8)
+	binary.LittleEndian.PutUint16(hdr[14:], 8)
+	hdr[16] = 32
+
+	data := append([]byte{}, hdr...)
+	// 64 pixels: one run of 64 would overflow packet size, use two runs of 32.
+	for range 2 {
+		data = append(data, 0x80|31, 0x10, 0x20, 0x30, 0x40)
+	}
+
+	src, err := decodeSourceImage(bytes.NewReader(data), sourceExtTGA)
+	if err != nil {
+		t.Fatalf("decodeSourceImage(tga) error: %v", err)
+	}
+
+	if got := color.NRGBAModel.Convert(src.img.At(7, 7)).(color.NRGBA); got != (color.NRGBA{R: 0x30, G: 0x20, B: 0x10, A: 0x40}) {
+		t.Fatalf("pixel = %v", got)
+	}
+
+	if src.format != 0 {
+		t.Fatalf("format = %v, want converter choice", src.format)
+	}
+}
+
+func TestDecodeSourceImage_DDSKeepsFormat(t *testing.T) {
+	t.Parallel()
+
+	dds, err := bcn.EncodeDDS(solidImage(8, 8, testRed), bcn.FormatDXT3)
+	if err != nil {
+		t.Fatalf("EncodeDDS() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = dds.Write(&buf); err != nil {
+		t.Fatalf("DDS.Write() error: %v", err)
+	}
+
+	src, err := decodeSourceImage(&buf, sourceExtDDS)
+	if err != nil {
+		t.Fatalf("decodeSourceImage(dds) error: %v", err)
+	}
+
+	if got := color.NRGBAModel.Convert(src.img.At(7, 7)).(color.NRGBA); got != testRed {
+		t.Fatalf("pixel = %v", got)
+	}
+
+	if src.format != paa.PaxDXT3 {
+		t.Fatalf("format = %v, want DXT3", src.format)
+	}
+
+	if _, err = decodeSourceImage(bytes.NewReader([]byte("DDS ")), sourceExtDDS); !errors.Is(err, errInvalidSourceImage) {
+		t.Fatalf("decodeSourceImage(truncated dds) error = %v, want %v", err, errInvalidSourceImage)
+	}
+}
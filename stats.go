@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"maps"
+	"strconv"
+	"time"
+)
+
+// BuildStats summarizes the last build of a Builder.
+type BuildStats struct {
+	// ByPaxFormat counts built entries per pax format.
+	ByPaxFormat map[PaxFormat]int `json:"by_pax_format,omitempty" yaml:"by_pax_format,omitempty"`
+	// BySuffixType counts built entries per suffix type.
+	BySuffixType map[SuffixType]int `json:"by_suffix_type,omitempty" yaml:"by_suffix_type,omitempty"`
+	// Duration is the build wall time.
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// FilesScanned is the number of inputs processed.
+	FilesScanned int `json:"files_scanned,omitempty" yaml:"files_scanned,omitempty"`
+	// Entries is the number of built entries.
+	Entries int `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// Failed is the number of skipped or failed inputs.
+	Failed int `json:"failed,omitempty" yaml:"failed,omitempty"`
+	// Warnings is the number of warnings about built entries.
+	Warnings int `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	// BytesStat is the total source file size of built entries.
+	BytesStat int64 `json:"bytes_stat,omitempty" yaml:"bytes_stat,omitempty"`
+	// PayloadSize is the total mip data size of built entries, source size
+	// without headers before the first mip.
+	PayloadSize int64 `json:"payload_size,omitempty" yaml:"payload_size,omitempty"`
+}
+
+// Stats returns statistics of the last Build, Update, WriteStream or BuildPBO call.
+func (b *Builder) Stats() BuildStats {
+	out := b.stats
+	out.ByPaxFormat = maps.Clone(b.stats.ByPaxFormat)
+	out.BySuffixType = maps.Clone(b.stats.BySuffixType)
+	return out
+}
+
+// resetStats starts statistics of a new build.
+func (b *Builder) resetStats() time.Time {
+	b.stats = BuildStats{
+		ByPaxFormat:  make(map[PaxFormat]int),
+		BySuffixType: make(map[SuffixType]int),
+	}
+
+	return time.Now()
+}
+
+// recordEntry adds one built entry and its warnings to statistics.
+func (s *BuildStats) recordEntry(entry *TextureEntry, warnings int) {
+	s.FilesScanned++
+	s.Entries++
+	s.Warnings += warnings
+	s.ByPaxFormat[entry.PaxFormat]++
+	s.BySuffixType[entry.PaxSuffixType]++
+	s.BytesStat += int64(entry.PaxFileSize)
+
+	if len(entry.MipMaps) > 0 && entry.MipMaps[0].DataOffset <= entry.PaxFileSize {
+		s.PayloadSize += int64(entry.PaxFileSize - entry.MipMaps[0].DataOffset)
+	}
+}
+
+// recordFailure adds one failed input to statistics.
+func (s *BuildStats) recordFailure() {
+	s.FilesScanned++
+	s.Failed++
+}
+
+// FileStats summarizes entries of a texheaders file.
+type FileStats struct {
+	// ByPaxFormat counts entries per pax format.
+	ByPaxFormat map[PaxFormat]int `json:"by_pax_format,omitempty" yaml:"by_pax_format,omitempty"`
+	// BySuffixType counts entries per suffix type.
+	BySuffixType map[SuffixType]int `json:"by_suffix_type,omitempty" yaml:"by_suffix_type,omitempty"`
+	// ByDimensions counts entries with mips per largest mip size, keyed
+	// "WIDTHxHEIGHT", e.g. "2048x2048".
+	ByDimensions map[string]int `json:"by_dimensions,omitempty" yaml:"by_dimensions,omitempty"`
+	// Entries is the number of entries.
+	Entries int `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// TotalFileSize is the sum of PaxFileSize of all entries.
+	TotalFileSize uint64 `json:"total_file_size,omitempty" yaml:"total_file_size,omitempty"`
+	// Width summarizes largest mip width of entries with mips.
+	Width RangeStats `json:"width" yaml:"width"`
+	// Height summarizes largest mip height of entries with mips.
+	Height RangeStats `json:"height" yaml:"height"`
+	// MipMaps summarizes mip counts of all entries.
+	MipMaps RangeStats `json:"mipmaps" yaml:"mipmaps"`
+}
+
+// RangeStats holds minimum, maximum and average of one entry metric.
+type RangeStats struct {
+	// Min is the smallest value.
+	Min int `json:"min" yaml:"min"`
+	// Max is the largest value.
+	Max int `json:"max" yaml:"max"`
+	// Avg is the arithmetic mean.
+	Avg float64 `json:"avg" yaml:"avg"`
+}
+
+// Stats returns aggregate summary of file entries.
+func (f *File) Stats() FileStats {
+	out := FileStats{
+		ByPaxFormat:  make(map[PaxFormat]int),
+		BySuffixType: make(map[SuffixType]int),
+		ByDimensions: make(map[string]int),
+		Entries:      len(f.Textures),
+	}
+
+	var widths, heights, mips metricAcc
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		out.ByPaxFormat[e.PaxFormat]++
+		out.BySuffixType[e.PaxSuffixType]++
+		out.TotalFileSize += uint64(e.PaxFileSize)
+		mips.add(len(e.MipMaps))
+
+		if len(e.MipMaps) > 0 {
+			w, h := e.dimensions()
+			widths.add(int(w))
+			heights.add(int(h))
+			out.ByDimensions[strconv.Itoa(int(w))+"x"+strconv.Itoa(int(h))]++
+		}
+	}
+
+	out.Width = widths.stats()
+	out.Height = heights.stats()
+	out.MipMaps = mips.stats()
+	return out
+}
+
+// metricAcc accumulates RangeStats values.
+type metricAcc struct {
+	stat  RangeStats
+	sum   int
+	count int
+}
+
+// add records one value.
+func (a *metricAcc) add(v int) {
+	if a.count == 0 || v < a.stat.Min {
+		a.stat.Min = v
+	}
+
+	a.stat.Max = max(a.stat.Max, v)
+	a.sum += v
+	a.count++
+}
+
+// stats returns accumulated statistics, zero when empty.
+func (a *metricAcc) stats() RangeStats {
+	if a.count > 0 {
+		a.stat.Avg = float64(a.sum) / float64(a.count)
+	}
+
+	return a.stat
+}
@@ -0,0 +1,94 @@
+package texheaders
+
+import "testing"
+
+func TestBuilder_Stats(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{BaseDir: "testdata", SkipInvalid: true, Workers: 2})
+	if err := b.AppendDir("testdata", DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	if err := b.Append("testdata/missing_co.paa"); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	st := b.Stats()
+	if st.Entries != len(f.Textures) || st.Failed != 1 || st.FilesScanned != len(f.Textures)+1 {
+		t.Fatalf("stats entries=%d failed=%d scanned=%d, want %d 1 %d", st.Entries, st.Failed, st.FilesScanned, len(f.Textures), len(f.Textures)+1)
+	}
+
+	var formats, suffixes int
+	var bytes, payload int64
+	for _, n := range st.ByPaxFormat {
+		formats += n
+	}
+
+	for _, n := range st.BySuffixType {
+		suffixes += n
+	}
+
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		bytes += int64(e.PaxFileSize)
+		payload += int64(e.PaxFileSize - e.MipMaps[0].DataOffset)
+	}
+
+	if formats != st.Entries || suffixes != st.Entries {
+		t.Fatalf("per-format=%d per-suffix=%d, want %d", formats, suffixes, st.Entries)
+	}
+
+	if st.BytesStat != bytes || st.PayloadSize != payload || st.Duration <= 0 {
+		t.Fatalf("stats bytes=%d payload=%d duration=%v, want %d %d >0", st.BytesStat, st.PayloadSize, st.Duration, bytes, payload)
+	}
+
+	st.ByPaxFormat[99] = 1
+	if _, ok := b.Stats().ByPaxFormat[99]; ok {
+		t.Fatal("Stats() returned shared map")
+	}
+}
+
+func TestFileStats(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PaxFormat: PaxFormatDXT1, PaxSuffixType: SuffixDiffuseSRGB, PaxFileSize: 100, MipMaps: []MipMap{{Width: 256, Height: 128}, {Width: 128, Height: 64}}},
+		{PaxFormat: PaxFormatDXT5, PaxSuffixType: SuffixNormalMap, PaxFileSize: 50, MipMaps: []MipMap{{Width: 64 | mipCompressedFlag, Height: 64}}},
+		{PaxFormat: PaxFormatDXT1, PaxSuffixType: SuffixDiffuseSRGB, PaxFileSize: 10},
+	}}
+
+	got := f.Stats()
+	if got.Entries != 3 || got.TotalFileSize != 160 {
+		t.Fatalf("Stats() entries=%d total=%d", got.Entries, got.TotalFileSize)
+	}
+
+	if got.ByPaxFormat[PaxFormatDXT1] != 2 || got.ByPaxFormat[PaxFormatDXT5] != 1 || got.BySuffixType[SuffixNormalMap] != 1 {
+		t.Fatalf("Stats() by format=%v by suffix=%v", got.ByPaxFormat, got.BySuffixType)
+	}
+
+	if len(got.ByDimensions) != 2 || got.ByDimensions["256x128"] != 1 || got.ByDimensions["64x64"] != 1 {
+		t.Fatalf("Stats().ByDimensions = %v", got.ByDimensions)
+	}
+
+	if got.Width != (RangeStats{Min: 64, Max: 256, Avg: 160}) {
+		t.Fatalf("Stats().Width = %+v", got.Width)
+	}
+
+	if got.Height != (RangeStats{Min: 64, Max: 128, Avg: 96}) {
+		t.Fatalf("Stats().Height = %+v", got.Height)
+	}
+
+	if got.MipMaps != (RangeStats{Min: 0, Max: 2, Avg: 1}) {
+		t.Fatalf("Stats().MipMaps = %+v", got.MipMaps)
+	}
+
+	if empty := (&File{}).Stats(); empty.Entries != 0 || empty.Width != (RangeStats{}) {
+		t.Fatalf("Stats(empty) = %+v", empty)
+	}
+}
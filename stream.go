@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// textureCountOffset is the offset of texture count field from file start.
+const textureCountOffset = 8
+
+// StreamEncoder writes texHeaders.bin entry by entry without holding the model in memory.
+//
+// Texture count is unknown until Close, so the header is written with zero
+// count and patched by seeking back to it.
+type StreamEncoder struct {
+	w     io.WriteSeeker // w is the destination stream.
+	e     encoder        // e encodes values into w.
+	start int64          // start is the stream position of file magic.
+	count int            // count is the number of encoded entries.
+}
+
+// NewStreamEncoder writes texHeaders.bin header into w and returns encoder for entries.
+func NewStreamEncoder(w io.WriteSeeker) (*StreamEncoder, error) {
+	start, err := w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("seek stream start: %w", err)
+	}
+
+	s := &StreamEncoder{w: w, e: encoder{w: w}, start: start}
+	if sw, ok := w.(io.StringWriter); ok {
+		s.e.strW = sw
+	}
+
+	if err = s.e.writeString(FileMagic); err != nil {
+		return nil, fmt.Errorf("write magic: %w", err)
+	}
+
+	if err = s.e.writeU32(SupportedVersion); err != nil {
+		return nil, fmt.Errorf("write version: %w", err)
+	}
+
+	if err = s.e.writeU32(0); err != nil {
+		return nil, fmt.Errorf("write texture count: %w", err)
+	}
+
+	return s, nil
+}
+
+// Encode writes one texture entry.
+func (s *StreamEncoder) Encode(entry *TextureEntry) error {
+	if err := s.e.writeTextureEntry(entry); err != nil {
+		return fmt.Errorf("write texture entry %d: %w", s.count, err)
+	}
+
+	s.count++
+	return nil
+}
+
+// Count returns the number of entries encoded so far.
+func (s *StreamEncoder) Count() int {
+	return s.count
+}
+
+// Close patches texture count in header and moves stream back to its end.
+// It does not close the underlying writer.
+func (s *StreamEncoder) Close() error {
+	end, err := s.w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("seek stream end: %w", err)
+	}
+
+	if _, err = s.w.Seek(s.start+textureCountOffset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek texture count: %w", err)
+	}
+
+	if err = s.e.writeU32FromInt(s.count); err != nil {
+		return fmt.Errorf("write texture count: %w", err)
+	}
+
+	if _, err = s.w.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("seek stream end: %w", err)
+	}
+
+	return nil
+}
+
+// WriteStream builds entries and writes them to w as they are produced.
+//
+// Unlike Write, built entries are not retained, so memory stays flat
+// regardless of input count; Report has no entry summaries even with
+// BuildOptions.ReportEntries.
+func (b *Builder) WriteStream(w io.WriteSeeker) error {
+	b.streaming = true
+	defer func() {
+		b.streaming = false
+	}()
+
+	inputs := b.snapshotInputs()
+	enc, err := NewStreamEncoder(w)
+	if err != nil {
+		return err
+	}
+
+	if err = b.runEntries(inputs, nil, enc.Encode); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// WriteStreamFile builds entries and streams them into file path.
+//
+// Entries are streamed into a temporary file next to path which is renamed
+// over it on success, so a failed build leaves no truncated output. The
+// file keeps mode of replaced output; new output gets WriteFile mode.
+func (b *Builder) WriteStreamFile(path string) error {
+	tmp, err := createTemp(path, ".texheaders-*.bin", 0)
+	if err != nil {
+		return fmt.Errorf("create %q: %w", path, err)
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err = b.WriteStream(tmp); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %q: %w", path, err)
+	}
+
+	return nil
+}
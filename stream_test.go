@@ -0,0 +1,134 @@
+package texheaders
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBuilder_WriteStreamMatchesWrite(t *testing.T) {
+	t.Parallel()
+
+	for _, workers := range []int{1, 4, WorkersAdaptive} {
+		b := NewBuilder(BuildOptions{BaseDir: "testdata", Workers: workers})
+		if err := b.AppendDir("testdata", DirOptions{}); err != nil {
+			t.Fatalf("AppendDir() error: %v", err)
+		}
+
+		var want bytes.Buffer
+		if err := b.Write(&want); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+
+		path := filepath.Join(t.TempDir(), "texHeaders.bin")
+		if err := b.WriteStreamFile(path); err != nil {
+			t.Fatalf("WriteStreamFile() error: %v", err)
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error: %v", err)
+		}
+
+		if !bytes.Equal(got, want.Bytes()) {
+			t.Fatalf("workers=%d: streamed output differs from Write output", workers)
+		}
+	}
+}
+
+func TestBuilder_WriteStreamFailFast(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	if err := os.WriteFile(filepath.Join(dir, "b_co.paa"), []byte("bad"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	for i := range 8 {
+		copyFixture(t, "test_co.paa", dir, "c"+strings.Repeat("x", i)+"_co.paa")
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, Workers: 2})
+	if err := b.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	out := t.TempDir()
+	path := filepath.Join(out, "texHeaders.bin")
+	if err := os.WriteFile(path, []byte("previous"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	if err := b.WriteStreamFile(path); err == nil || !strings.Contains(err.Error(), "b_co.paa") {
+		t.Fatalf("WriteStreamFile() error = %v, want b_co.paa failure", err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != "previous" {
+		t.Fatalf("failed WriteStreamFile() changed output: %q, %v", data, err)
+	}
+
+	if entries, err := os.ReadDir(out); err != nil || len(entries) != 1 {
+		t.Fatalf("failed WriteStreamFile() left files: %v, %v", entries, err)
+	}
+}
+
+func TestBuilder_WriteStreamFileMode(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not kept on windows")
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: "testdata"})
+	if err := b.Append(filepath.Join("testdata", "test_co.paa")); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	dir := t.TempDir()
+	want := filepath.Join(dir, "want.bin")
+	if err := WriteFile(want, &File{}); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	path := filepath.Join(dir, "texHeaders.bin")
+	if err := b.WriteStreamFile(path); err != nil {
+		t.Fatalf("WriteStreamFile() error: %v", err)
+	}
+
+	assertSameMode(t, path, want)
+
+	if err := os.Chmod(path, 0o640); err != nil {
+		t.Fatalf("Chmod() error: %v", err)
+	}
+
+	if err := b.WriteStreamFile(path); err != nil {
+		t.Fatalf("WriteStreamFile(existing) error: %v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o640 {
+		t.Fatalf("WriteStreamFile(existing) mode = %v, %v; want 0640", info.Mode(), err)
+	}
+}
+
+// assertSameMode checks that files at path and want have equal permissions.
+func assertSameMode(t *testing.T, path, want string) {
+	t.Helper()
+
+	got, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat(%s) error: %v", path, err)
+	}
+
+	exp, err := os.Stat(want)
+	if err != nil {
+		t.Fatalf("Stat(%s) error: %v", want, err)
+	}
+
+	if got.Mode().Perm() != exp.Mode().Perm() {
+		t.Fatalf("%s mode = %v, want %v", path, got.Mode().Perm(), exp.Mode().Perm())
+	}
+}
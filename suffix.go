@@ -4,107 +4,189 @@
 
 package texheaders
 
-import "strings"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SuffixType is the texture suffix class of texheaders entry.
+//
+// It marshals to text as snake_case name (e.g. "normal_map") and unknown
+// values as decimal numbers, so JSON and YAML stay readable and lossless.
+type SuffixType uint32
 
 // Known pax suffix kinds from available format docs.
 const (
-	SuffixDiffuseSRGB           uint32 = 0
-	SuffixDiffuseLinear         uint32 = 1
-	SuffixDetailLinear          uint32 = 2
-	SuffixNormalMap             uint32 = 3
-	SuffixIrradianceMap         uint32 = 4
-	SuffixRandom05To1           uint32 = 5
-	SuffixTreeCrownCalc         uint32 = 6
-	SuffixMacroObjectSRGB       uint32 = 7
-	SuffixAmbientShadow         uint32 = 8
-	SuffixSpecularAmount        uint32 = 9
-	SuffixDitherTexture         uint32 = 10
-	SuffixDetailSpecularAmount  uint32 = 11
-	SuffixMultiShaderMask       uint32 = 12
-	SuffixThermalImageTextureCA uint32 = 13
+	SuffixDiffuseSRGB           SuffixType = 0
+	SuffixDiffuseLinear         SuffixType = 1
+	SuffixDetailLinear          SuffixType = 2
+	SuffixNormalMap             SuffixType = 3
+	SuffixIrradianceMap         SuffixType = 4
+	SuffixRandom05To1           SuffixType = 5
+	SuffixTreeCrownCalc         SuffixType = 6
+	SuffixMacroObjectSRGB       SuffixType = 7
+	SuffixAmbientShadow         SuffixType = 8
+	SuffixSpecularAmount        SuffixType = 9
+	SuffixDitherTexture         SuffixType = 10
+	SuffixDetailSpecularAmount  SuffixType = 11
+	SuffixMultiShaderMask       SuffixType = 12
+	SuffixThermalImageTextureCA SuffixType = 13
 )
 
-// suffixGuessRule describes one suffix inference rule.
-type suffixGuessRule struct {
-	token string
-	value uint32
+// suffixTypeNames maps known suffix types to their names.
+var suffixTypeNames = [...]string{
+	SuffixDiffuseSRGB:           "diffuse_srgb",
+	SuffixDiffuseLinear:         "diffuse_linear",
+	SuffixDetailLinear:          "detail_linear",
+	SuffixNormalMap:             "normal_map",
+	SuffixIrradianceMap:         "irradiance_map",
+	SuffixRandom05To1:           "random_05_to_1",
+	SuffixTreeCrownCalc:         "tree_crown_calc",
+	SuffixMacroObjectSRGB:       "macro_object_srgb",
+	SuffixAmbientShadow:         "ambient_shadow",
+	SuffixSpecularAmount:        "specular_amount",
+	SuffixDitherTexture:         "dither_texture",
+	SuffixDetailSpecularAmount:  "detail_specular_amount",
+	SuffixMultiShaderMask:       "multi_shader_mask",
+	SuffixThermalImageTextureCA: "thermal_image_texture_ca",
 }
 
-// Ordered longest-first where overlap exists.
-var suffixGuessRules = []suffixGuessRule{
-	{token: "_nohq_alpha", value: SuffixDiffuseSRGB},
-	{token: "_dtsmdi", value: SuffixDetailSpecularAmount},
-	{token: "_ti_ca", value: SuffixThermalImageTextureCA},
-	{token: "_smdi", value: SuffixSpecularAmount},
-	{token: "_detail", value: SuffixDetailLinear},
-	{token: "_normalmap", value: SuffixNormalMap},
-	{token: "_nohq", value: SuffixNormalMap},
-	{token: "_novhq", value: SuffixNormalMap},
-	{token: "_nofhq", value: SuffixNormalMap},
-	{token: "_nofex", value: SuffixNormalMap},
-	{token: "_noex", value: SuffixNormalMap},
-	{token: "_nsex", value: SuffixNormalMap},
-	{token: "_nshq", value: SuffixNormalMap},
-	{token: "_nopx", value: SuffixNormalMap},
-	{token: "_non", value: SuffixNormalMap},
-	{token: "_nof", value: SuffixNormalMap},
-	{token: "_nse", value: SuffixNormalMap},
-	{token: "_ns", value: SuffixNormalMap},
-	{token: "_no", value: SuffixNormalMap},
-	{token: "_mask", value: SuffixMultiShaderMask},
-	{token: "_sky", value: SuffixDiffuseLinear},
-	{token: "_lco", value: SuffixDiffuseLinear},
-	{token: "_dxt5", value: SuffixDiffuseLinear},
-	{token: "_mco", value: SuffixDetailLinear},
-	{token: "_cdt", value: SuffixDetailLinear},
-	{token: "_dt", value: SuffixDetailLinear},
-	{token: "_mc", value: SuffixMacroObjectSRGB},
-	{token: "_as", value: SuffixAmbientShadow},
-	{token: "_sm", value: SuffixSpecularAmount},
-	{token: "_ca", value: SuffixDiffuseSRGB},
-	{token: "_co", value: SuffixDiffuseSRGB},
+// Known reports whether s is one of named suffix types.
+func (s SuffixType) Known() bool {
+	return int(s) < len(suffixTypeNames)
 }
 
-// GuessSuffixTypeFromPath tries to infer pax suffix type from texture file path.
-//
-// This is heuristic mapping based on known DayZ/Arma naming conventions.
-// Unknown patterns fall back to diffuse_srgb (0) and return ok=false.
-func GuessSuffixTypeFromPath(path string) (value uint32, ok bool) {
-	s := strings.ToLower(path)
-	dot := strings.LastIndexByte(s, '.')
-	if dot > 0 {
-		s = s[:dot]
+// String returns suffix type name, or "SuffixType(N)" for unknown values.
+func (s SuffixType) String() string {
+	if s.Known() {
+		return suffixTypeNames[s]
 	}
 
-	for _, rule := range suffixGuessRules {
-		if containsTokenBoundary(s, rule.token) {
-			return rule.value, true
-		}
+	return "SuffixType(" + strconv.FormatUint(uint64(s), 10) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SuffixType) MarshalText() ([]byte, error) {
+	if s.Known() {
+		return []byte(suffixTypeNames[s]), nil
 	}
 
-	return SuffixDiffuseSRGB, false
+	return strconv.AppendUint(nil, uint64(s), 10), nil
 }
 
-// containsTokenBoundary checks token match with a separator/end right after token.
-func containsTokenBoundary(s, token string) bool {
-	from := 0
-	for {
-		idx := strings.Index(s[from:], token)
-		if idx < 0 {
-			return false
-		}
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SuffixType) UnmarshalText(text []byte) error {
+	v, err := ParseSuffixType(string(text))
+	if err != nil {
+		return err
+	}
 
-		pos := from + idx
-		next := pos + len(token)
-		if next >= len(s) {
-			return true
-		}
+	*s = v
+	return nil
+}
 
-		ch := s[next]
-		if ch == '_' || ch == '-' || ch == '.' {
-			return true
+// ParseSuffixType parses suffix type name (case-insensitive) or decimal value.
+func ParseSuffixType(s string) (SuffixType, error) {
+	s = strings.TrimSpace(s)
+	for i, name := range suffixTypeNames {
+		if strings.EqualFold(s, name) {
+			return SuffixType(i), nil
 		}
+	}
+
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSuffixType, s)
+	}
+
+	return SuffixType(v), nil
+}
+
+// Built-in rules; ties of equal length are resolved by this order.
+var suffixGuessRules = []SuffixRule{
+	{Token: "_nohq_alpha", Type: SuffixDiffuseSRGB},
+	{Token: "_dtsmdi", Type: SuffixDetailSpecularAmount},
+	{Token: "_ti_ca", Type: SuffixThermalImageTextureCA},
+	{Token: "_smdi", Type: SuffixSpecularAmount},
+	{Token: "_detail", Type: SuffixDetailLinear},
+	{Token: "_normalmap", Type: SuffixNormalMap},
+	{Token: "_nohq", Type: SuffixNormalMap},
+	{Token: "_novhq", Type: SuffixNormalMap},
+	{Token: "_nofhq", Type: SuffixNormalMap},
+	{Token: "_nofex", Type: SuffixNormalMap},
+	{Token: "_noex", Type: SuffixNormalMap},
+	{Token: "_nsex", Type: SuffixNormalMap},
+	{Token: "_nshq", Type: SuffixNormalMap},
+	{Token: "_nopx", Type: SuffixNormalMap},
+	{Token: "_non", Type: SuffixNormalMap},
+	{Token: "_nof", Type: SuffixNormalMap},
+	{Token: "_nse", Type: SuffixNormalMap},
+	{Token: "_ns", Type: SuffixNormalMap},
+	{Token: "_no", Type: SuffixNormalMap},
+	{Token: "_mask", Type: SuffixMultiShaderMask},
+	{Token: "_sky", Type: SuffixDiffuseLinear},
+	{Token: "_lco", Type: SuffixDiffuseLinear},
+	{Token: "_dxt5", Type: SuffixDiffuseLinear},
+	{Token: "_mco", Type: SuffixDetailLinear},
+	{Token: "_cdt", Type: SuffixDetailLinear},
+	{Token: "_dt", Type: SuffixDetailLinear},
+	{Token: "_mc", Type: SuffixMacroObjectSRGB},
+	{Token: "_as", Type: SuffixAmbientShadow},
+	{Token: "_sm", Type: SuffixSpecularAmount},
+	// Recognized conventions without dedicated suffix class; official tools
+	// index them as diffuse_srgb. _adshq (vegetation ambient shadow, Arma 3
+	// and DayZ), _lca (linear color with alpha) and _ti (thermal map, Arma 3)
+	// are confirmed by testdata/texHeaders.bin; _mca (macro with alpha) and
+	// _sdm (specular detail map) follow the same rule by analogy.
+	{Token: "_adshq", Type: SuffixDiffuseSRGB},
+	{Token: "_mca", Type: SuffixDiffuseSRGB},
+	{Token: "_lca", Type: SuffixDiffuseSRGB},
+	{Token: "_sdm", Type: SuffixDiffuseSRGB},
+	{Token: "_ti", Type: SuffixDiffuseSRGB},
+	{Token: "_ca", Type: SuffixDiffuseSRGB},
+	{Token: "_co", Type: SuffixDiffuseSRGB},
+}
+
+// suffixCanonicalTokens lists recommended naming tokens per suffix type,
+// preferred token first. Legacy aliases such as _nofex or _dxt5 are still
+// recognized by suffixGuessRules but not suggested.
+var suffixCanonicalTokens = map[SuffixType][]string{
+	SuffixDiffuseSRGB:           {"_co", "_ca"},
+	SuffixDiffuseLinear:         {"_lco", "_sky"},
+	SuffixDetailLinear:          {"_dt", "_mco", "_cdt", "_detail"},
+	SuffixNormalMap:             {"_nohq", "_no", "_ns", "_nshq", "_nopx", "_non", "_nof"},
+	SuffixMacroObjectSRGB:       {"_mc"},
+	SuffixAmbientShadow:         {"_as"},
+	SuffixSpecularAmount:        {"_smdi", "_sm"},
+	SuffixDetailSpecularAmount:  {"_dtsmdi"},
+	SuffixMultiShaderMask:       {"_mask"},
+	SuffixThermalImageTextureCA: {"_ti_ca"},
+}
+
+// SuffixTokens returns canonical file name tokens of suffix type t,
+// preferred token first, e.g. "_nohq" for normal_map. It returns nil for
+// types without naming convention.
+func SuffixTokens(t SuffixType) []string {
+	return slices.Clone(suffixCanonicalTokens[t])
+}
+
+// GuessSuffixTypeFromPath tries to infer pax suffix type from texture file path.
+//
+// This is heuristic mapping based on known DayZ/Arma naming conventions.
+// Unknown patterns fall back to diffuse_srgb (0) and return ok=false.
+func GuessSuffixTypeFromPath(path string) (value SuffixType, ok bool) {
+	return defaultSuffixRuleset.Guess(path)
+}
 
-		from = pos + 1
+// isTokenEnd reports whether token ending at end of s is followed by a
+// separator ('_', '-', '.') or end of s.
+func isTokenEnd(s string, end int) bool {
+	if end >= len(s) {
+		return true
 	}
+
+	ch := s[end]
+	return ch == '_' || ch == '-' || ch == '.'
 }
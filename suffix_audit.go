@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+// SuffixMismatch describes entry whose stored suffix type differs from
+// the type inferred from its path.
+type SuffixMismatch struct {
+	// Rule is token rule that produced Guessed, nil for directory match or fallback.
+	Rule *SuffixRule `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// DirRule is directory rule that produced Guessed, nil otherwise.
+	DirRule *SuffixDirRule `json:"dir_rule,omitempty" yaml:"dir_rule,omitempty"`
+	// Path is stored entry path.
+	Path string `json:"path" yaml:"path"`
+	// Entry is entry index in File.Textures.
+	Entry int `json:"entry" yaml:"entry"`
+	// Stored is PaxSuffixType recorded in entry.
+	Stored SuffixType `json:"stored" yaml:"stored"`
+	// Guessed is suffix type inferred from path.
+	Guessed SuffixType `json:"guessed" yaml:"guessed"`
+}
+
+// AuditSuffixes infers suffix type of every entry with rs (built-in rules
+// when nil) and lists entries whose stored PaxSuffixType disagrees, in
+// entry order. Entries left to fallback (no rule matched) are reported
+// when their stored type is not diffuse_srgb.
+func AuditSuffixes(f *File, rs *SuffixRuleset) []SuffixMismatch {
+	if f == nil {
+		return nil
+	}
+
+	if rs == nil {
+		rs = defaultSuffixRuleset
+	}
+
+	var out []SuffixMismatch
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		t, rule, dir, _ := rs.lookup(e.PAAFile)
+		if t == e.PaxSuffixType {
+			continue
+		}
+
+		out = append(out, SuffixMismatch{
+			Rule:    rule,
+			DirRule: dir,
+			Path:    e.PAAFile,
+			Entry:   i,
+			Stored:  e.PaxSuffixType,
+			Guessed: t,
+		})
+	}
+
+	return out
+}
+
+// SuffixStats is histogram of suffix types over textures.
+type SuffixStats struct {
+	// ByType counts textures per suffix type: stored type for files,
+	// inferred type for paths.
+	ByType map[SuffixType]int `json:"by_type" yaml:"by_type"`
+	// ByRule counts inferred textures per matched token or directory rule.
+	ByRule map[string]int `json:"by_rule" yaml:"by_rule"`
+	// Total is the number of counted textures.
+	Total int `json:"total" yaml:"total"`
+	// Inferred is the number of textures matched by a rule.
+	Inferred int `json:"inferred" yaml:"inferred"`
+	// Fallback is the number of textures no rule matched.
+	Fallback int `json:"fallback" yaml:"fallback"`
+	// Mismatched is the number of file entries whose stored type differs
+	// from inference; always zero for paths.
+	Mismatched int `json:"mismatched" yaml:"mismatched"`
+}
+
+// FileSuffixStats counts stored suffix types of f entries and how their
+// paths resolve with rs (built-in rules when nil).
+func FileSuffixStats(f *File, rs *SuffixRuleset) SuffixStats {
+	st := newSuffixStats()
+	if f == nil {
+		return st
+	}
+
+	for i := range f.Textures {
+		e := &f.Textures[i]
+		t := st.record(rs, e.PAAFile)
+		st.ByType[e.PaxSuffixType]++
+		if t != e.PaxSuffixType {
+			st.Mismatched++
+		}
+	}
+
+	return st
+}
+
+// PathSuffixStats counts suffix types inferred for texture paths with rs
+// (built-in rules when nil), e.g. before building an index.
+func PathSuffixStats(paths []string, rs *SuffixRuleset) SuffixStats {
+	st := newSuffixStats()
+	for _, p := range paths {
+		st.ByType[st.record(rs, p)]++
+	}
+
+	return st
+}
+
+// newSuffixStats returns empty stats with allocated maps.
+func newSuffixStats() SuffixStats {
+	return SuffixStats{
+		ByType: make(map[SuffixType]int),
+		ByRule: make(map[string]int),
+	}
+}
+
+// record counts inference result of path and returns inferred type.
+func (st *SuffixStats) record(rs *SuffixRuleset, path string) SuffixType {
+	if rs == nil {
+		rs = defaultSuffixRuleset
+	}
+
+	t, rule, dir, ok := rs.lookup(path)
+	st.Total++
+	switch {
+	case !ok:
+		st.Fallback++
+	case dir != nil:
+		st.Inferred++
+		st.ByRule[dir.Dir]++
+	default:
+		st.Inferred++
+		st.ByRule[rule.Token]++
+	}
+
+	return t
+}
@@ -0,0 +1,101 @@
+package texheaders
+
+import (
+	"testing"
+)
+
+func TestAuditSuffixesFixture(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	if got := AuditSuffixes(f, nil); len(got) != 0 {
+		t.Fatalf("AuditSuffixes(fixture) = %+v, want none", got)
+	}
+
+	if got := AuditSuffixes(nil, nil); got != nil {
+		t.Fatalf("AuditSuffixes(nil) = %+v", got)
+	}
+}
+
+func TestAuditSuffixes(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `data\rock_nohq.paa`, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\rock_co.paa`, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\detail\grass_co.paa`, PaxSuffixType: SuffixDiffuseSRGB},
+		{PAAFile: `data\plain.paa`, PaxSuffixType: SuffixMultiShaderMask},
+	}}
+
+	rs := DefaultSuffixRuleset()
+	rs.AddDirRule(`data\detail`, SuffixDetailLinear)
+
+	got := AuditSuffixes(f, rs)
+	if len(got) != 3 {
+		t.Fatalf("AuditSuffixes() = %+v, want 3 mismatches", got)
+	}
+
+	if m := got[0]; m.Entry != 1 || m.Guessed != SuffixDiffuseSRGB || m.Rule == nil || m.Rule.Token != "_co" || m.DirRule != nil {
+		t.Fatalf("token mismatch = %+v", m)
+	}
+
+	if m := got[1]; m.Entry != 2 || m.Guessed != SuffixDetailLinear || m.Rule != nil || m.DirRule == nil || m.DirRule.Dir != `data\detail\` {
+		t.Fatalf("dir mismatch = %+v", m)
+	}
+
+	if m := got[2]; m.Entry != 3 || m.Stored != SuffixMultiShaderMask || m.Rule != nil || m.DirRule != nil {
+		t.Fatalf("fallback mismatch = %+v", m)
+	}
+}
+
+func TestFileSuffixStats(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `data\rock_nohq.paa`, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\wall_nohq.paa`, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\rock_co.paa`, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\plain.paa`},
+	}}
+
+	st := FileSuffixStats(f, nil)
+	if st.Total != 4 || st.Inferred != 3 || st.Fallback != 1 || st.Mismatched != 1 {
+		t.Fatalf("FileSuffixStats() = %+v", st)
+	}
+
+	if st.ByType[SuffixNormalMap] != 3 || st.ByType[SuffixDiffuseSRGB] != 1 {
+		t.Fatalf("ByType = %v", st.ByType)
+	}
+
+	if st.ByRule["_nohq"] != 2 || st.ByRule["_co"] != 1 || len(st.ByRule) != 2 {
+		t.Fatalf("ByRule = %v", st.ByRule)
+	}
+
+	if empty := FileSuffixStats(nil, nil); empty.Total != 0 || empty.ByType == nil {
+		t.Fatalf("FileSuffixStats(nil) = %+v", empty)
+	}
+}
+
+func TestPathSuffixStats(t *testing.T) {
+	t.Parallel()
+
+	rs := DefaultSuffixRuleset()
+	rs.AddDirRule("detail", SuffixDetailLinear)
+
+	st := PathSuffixStats([]string{"detail/grass_co.paa", "rock_co.png", "rock_as.tga", "noise.paa"}, rs)
+	if st.Total != 4 || st.Inferred != 3 || st.Fallback != 1 || st.Mismatched != 0 {
+		t.Fatalf("PathSuffixStats() = %+v", st)
+	}
+
+	if st.ByType[SuffixDiffuseSRGB] != 2 || st.ByType[SuffixDetailLinear] != 1 || st.ByType[SuffixAmbientShadow] != 1 {
+		t.Fatalf("ByType = %v", st.ByType)
+	}
+
+	if st.ByRule[`detail\`] != 1 || st.ByRule["_co"] != 1 {
+		t.Fatalf("ByRule = %v", st.ByRule)
+	}
+}
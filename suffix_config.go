@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"os"
+)
+
+// SuffixRulesConfig describes suffix rules stored in JSON or YAML file.
+//
+// Example YAML:
+//
+//	builtins: true
+//	rules:
+//	  - token: _hm
+//	    type: ambient_shadow
+//	  - token: _co
+//	    type: diffuse_linear
+//	    priority: 1
+//	dirs:
+//	  - dir: data\detailmaps
+//	    type: detail_linear
+//	remove: [_dxt5]
+type SuffixRulesConfig struct {
+	// Builtins starts from built-in rules when nil or true.
+	Builtins *bool `json:"builtins,omitempty" yaml:"builtins,omitempty"`
+	// Rules adds rules; a rule with built-in token overrides it.
+	Rules []SuffixRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Dirs adds directory rules, which take precedence over token rules.
+	Dirs []SuffixDirRule `json:"dirs,omitempty" yaml:"dirs,omitempty"`
+	// Remove lists tokens dropped after Rules are applied.
+	Remove []string `json:"remove,omitempty" yaml:"remove,omitempty"`
+}
+
+// Ruleset returns ruleset described by config.
+func (c SuffixRulesConfig) Ruleset() *SuffixRuleset {
+	rs := NewSuffixRuleset()
+	if c.Builtins == nil || *c.Builtins {
+		rs = DefaultSuffixRuleset()
+	}
+
+	for _, r := range c.Rules {
+		rs.Add(r)
+	}
+
+	for _, d := range c.Dirs {
+		rs.AddDirRule(d.Dir, d.Type)
+	}
+
+	for _, token := range c.Remove {
+		rs.RemoveRule(token)
+	}
+
+	return rs
+}
+
+// LoadSuffixRules reads suffix rules config from JSON or YAML file.
+func LoadSuffixRules(path string) (*SuffixRuleset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", path, err)
+	}
+
+	rs, err := ParseSuffixRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+
+	return rs, nil
+}
+
+// ParseSuffixRules decodes suffix rules config. Input starting with '{' is
+// decoded as JSON, anything else as YAML subset: block mappings and
+// sequences, plain and quoted scalars and one-line flow sequences.
+func ParseSuffixRules(data []byte) (*SuffixRuleset, error) {
+	cfg, err := parseSuffixRulesConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.Ruleset(), nil
+}
+
+// parseSuffixRulesConfig decodes and checks config without building ruleset.
+func parseSuffixRulesConfig(data []byte) (SuffixRulesConfig, error) {
+	var cfg SuffixRulesConfig
+	if err := decodeConfig(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%w: %w", ErrInvalidSuffixRules, err)
+	}
+
+	for i, r := range cfg.Rules {
+		if normalizeSuffixToken(r.Token) == "" {
+			return cfg, fmt.Errorf("%w: rules[%d]: empty token", ErrInvalidSuffixRules, i)
+		}
+	}
+
+	for i, d := range cfg.Dirs {
+		if normalizeSuffixDir(d.Dir) == "" {
+			return cfg, fmt.Errorf("%w: dirs[%d]: empty dir", ErrInvalidSuffixRules, i)
+		}
+	}
+
+	return cfg, nil
+}
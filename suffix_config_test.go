@@ -0,0 +1,102 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSuffixRules(t *testing.T) {
+	t.Parallel()
+
+	yaml := `# house conventions
+builtins: yes
+rules:
+  - token: _HM   # ambient shadow
+    type: ambient_shadow
+  - token: "_co"
+    type: diffuse_linear
+    priority: 1
+dirs:
+  - dir: data\detailmaps
+    type: detail_linear
+remove: [_dxt5, '_sky']
+`
+	json := `{"rules":[{"token":"_hm","type":"ambient_shadow"},{"token":"_co","type":"diffuse_linear","priority":1}],"dirs":[{"dir":"data/detailmaps","type":"detail_linear"}],"remove":["_dxt5","_sky"]}`
+
+	dir := t.TempDir()
+	for name, data := range map[string]string{"rules.yaml": yaml, "rules.json": json} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile() error: %v", err)
+		}
+
+		rs, err := LoadSuffixRules(path)
+		if err != nil {
+			t.Fatalf("LoadSuffixRules(%s) error: %v", name, err)
+		}
+
+		cases := map[string]SuffixType{
+			"rock_hm.paa":                  SuffixAmbientShadow,
+			"wall_mask_co.paa":             SuffixDiffuseLinear,
+			"wall_nohq.paa":                SuffixNormalMap,
+			`data\detailmaps\grass_co.paa`: SuffixDetailLinear,
+		}
+		for p, want := range cases {
+			if got, ok := rs.Guess(p); !ok || got != want {
+				t.Fatalf("%s: Guess(%s) = %s, %v; want %s", name, p, got, ok, want)
+			}
+		}
+
+		if _, ok := rs.Guess("x_sky.paa"); ok {
+			t.Fatalf("%s: removed token _sky still matches", name)
+		}
+	}
+}
+
+func TestParseSuffixRulesNoBuiltins(t *testing.T) {
+	t.Parallel()
+
+	rs, err := ParseSuffixRules([]byte("builtins: false\nrules:\n- token: _hm\n  type: ambient_shadow\n"))
+	if err != nil {
+		t.Fatalf("ParseSuffixRules() error: %v", err)
+	}
+
+	if rules := rs.Rules(); len(rules) != 1 || rules[0] != (SuffixRule{Token: "_hm", Type: SuffixAmbientShadow}) {
+		t.Fatalf("Rules() = %+v", rules)
+	}
+
+	empty, err := ParseSuffixRules(nil)
+	if err != nil {
+		t.Fatalf("ParseSuffixRules(nil) error: %v", err)
+	}
+
+	if len(empty.Rules()) != len(suffixGuessRules) {
+		t.Fatalf("ParseSuffixRules(nil) rules = %d", len(empty.Rules()))
+	}
+}
+
+func TestParseSuffixRulesErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"unknown field": "rule:\n  - token: _hm\n",
+		"unknown type":  "rules:\n  - token: _hm\n    type: shiny\n",
+		"empty token":   `{"rules":[{"token":" ","type":"normal_map"}]}`,
+		"bad indent":    "rules:\n  - token: _hm\n   type: normal_map\n",
+		"tab":           "rules:\n\t- token: _hm\n",
+		"flow map":      "rules: {token: _hm}\n",
+		"bad json":      `{"rules":`,
+		"empty dir":     "dirs:\n  - dir: /\n    type: normal_map\n",
+	}
+	for name, data := range cases {
+		if _, err := ParseSuffixRules([]byte(data)); !errors.Is(err, ErrInvalidSuffixRules) {
+			t.Fatalf("%s: ParseSuffixRules() error = %v, want ErrInvalidSuffixRules", name, err)
+		}
+	}
+
+	if _, err := LoadSuffixRules(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("LoadSuffixRules(missing) error = %v", err)
+	}
+}
@@ -28,3 +28,24 @@ func TestGuessSuffixTypeFromPath_TexHeadersFixture(t *testing.T) {
 		})
 	}
 }
+
+func TestGuessSuffixTypeFromPath_FixtureConventions(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	view := NewFileView(f)
+	for _, name := range []string{"test_adshq.paa", "test_lca.paa", "test_ti.paa"} {
+		e, ok := view.FindByPath(name)
+		if !ok {
+			t.Fatalf("fixture entry %s not found", name)
+		}
+
+		if got, ok := GuessSuffixTypeFromPath(name); !ok || got != e.PaxSuffixType {
+			t.Fatalf("GuessSuffixTypeFromPath(%q) = %s, %v; stored %s", name, got, ok, e.PaxSuffixType)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SuffixRule maps file name token (e.g. "_nohq") to suffix type.
+type SuffixRule struct {
+	// Token is matched case-insensitively in file name without extension
+	// and must be followed by '_', '-', '.' or end of name.
+	Token string `json:"token" yaml:"token"`
+	// Type is the suffix type assigned on match.
+	Type SuffixType `json:"type" yaml:"type"`
+	// Priority ranks rule among all rules matching a name; higher wins.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// SuffixDirRule assigns suffix type to every texture under directory.
+type SuffixDirRule struct {
+	// Dir is matched case-insensitively against whole path segments, at
+	// path start or after any separator, e.g. `data\detailmaps`.
+	Dir string `json:"dir" yaml:"dir"`
+	// Type is the suffix type assigned on match.
+	Type SuffixType `json:"type" yaml:"type"`
+}
+
+// SuffixRuleset is a set of suffix inference rules compiled into a token
+// trie, so matching costs O(path length) regardless of rule count.
+//
+// Directory rules take precedence over token rules; the longest matching
+// directory wins. When several token rules match a name, the highest
+// Priority wins, then the longest token, then the rule listed first by
+// Rules. It is safe for concurrent use.
+type SuffixRuleset struct {
+	root  *suffixTrieNode // root is the compiled token trie.
+	rules []SuffixRule    // rules holds rules in tie-break order, tokens lowercase.
+	dirs  []SuffixDirRule // dirs holds directory rules, lowercase with trailing '\\'.
+	mu    sync.RWMutex    // mu guards rules, dirs and root.
+}
+
+// suffixTrieNode is one byte step of compiled token trie.
+type suffixTrieNode struct {
+	next map[byte]*suffixTrieNode // next maps following token byte to child.
+	rule int                      // rule is index of rule ending here or -1.
+}
+
+// defaultSuffixRuleset backs GuessSuffixTypeFromPath.
+var defaultSuffixRuleset = NewSuffixRuleset(suffixGuessRules...)
+
+// NewSuffixRuleset returns ruleset with rules in given tie-break order.
+// Empty tokens are skipped; later duplicates of a token are dropped.
+func NewSuffixRuleset(rules ...SuffixRule) *SuffixRuleset {
+	rs := &SuffixRuleset{rules: make([]SuffixRule, 0, len(rules))}
+	for _, r := range rules {
+		r.Token = normalizeSuffixToken(r.Token)
+		if r.Token != "" && rs.find(r.Token) < 0 {
+			rs.rules = append(rs.rules, r)
+		}
+	}
+
+	rs.compile()
+	return rs
+}
+
+// DefaultSuffixRuleset returns a new ruleset holding the built-in rules,
+// ready to be extended.
+func DefaultSuffixRuleset() *SuffixRuleset {
+	return NewSuffixRuleset(suffixGuessRules...)
+}
+
+// DefaultSuffixRules returns a copy of the built-in rules in tie-break
+// order, for display, documentation or building custom rulesets.
+func DefaultSuffixRules() []SuffixRule {
+	return slices.Clone(suffixGuessRules)
+}
+
+// AddRule maps token to t with zero priority, see Add.
+func (rs *SuffixRuleset) AddRule(token string, t SuffixType) {
+	rs.Add(SuffixRule{Token: token, Type: t})
+}
+
+// Add inserts rule r. An existing rule for the token is replaced in place;
+// a new rule goes first in tie-break order, so project rules win ties with
+// built-ins. Empty tokens are ignored.
+func (rs *SuffixRuleset) Add(r SuffixRule) {
+	r.Token = normalizeSuffixToken(r.Token)
+	if r.Token == "" {
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if i := rs.find(r.Token); i >= 0 {
+		rs.rules[i] = r
+	} else {
+		rs.rules = slices.Insert(rs.rules, 0, r)
+	}
+
+	rs.compile()
+}
+
+// RemoveRule removes rule for token and reports whether it existed.
+func (rs *SuffixRuleset) RemoveRule(token string) bool {
+	token = normalizeSuffixToken(token)
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	i := rs.find(token)
+	if i < 0 {
+		return false
+	}
+
+	rs.rules = slices.Delete(rs.rules, i, i+1)
+	rs.compile()
+	return true
+}
+
+// AddDirRule maps every texture under dir to t, replacing existing rule for
+// the same directory. Empty directories are ignored.
+func (rs *SuffixRuleset) AddDirRule(dir string, t SuffixType) {
+	dir = normalizeSuffixDir(dir)
+	if dir == "" {
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if i := rs.findDir(dir); i >= 0 {
+		rs.dirs[i].Type = t
+		return
+	}
+
+	rs.dirs = append(rs.dirs, SuffixDirRule{Dir: dir, Type: t})
+}
+
+// RemoveDirRule removes rule for dir and reports whether it existed.
+func (rs *SuffixRuleset) RemoveDirRule(dir string) bool {
+	dir = normalizeSuffixDir(dir)
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	i := rs.findDir(dir)
+	if i < 0 {
+		return false
+	}
+
+	rs.dirs = slices.Delete(rs.dirs, i, i+1)
+	return true
+}
+
+// DirRules returns a copy of directory rules in insertion order.
+func (rs *SuffixRuleset) DirRules() []SuffixDirRule {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return slices.Clone(rs.dirs)
+}
+
+// Rules returns a copy of rules in tie-break order.
+func (rs *SuffixRuleset) Rules() []SuffixRule {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return slices.Clone(rs.rules)
+}
+
+// Clone returns an independent copy of ruleset with the same rules.
+func (rs *SuffixRuleset) Clone() *SuffixRuleset {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	c := &SuffixRuleset{rules: slices.Clone(rs.rules), dirs: slices.Clone(rs.dirs)}
+	c.compile()
+	return c
+}
+
+// Guess infers suffix type from texture file path with the best matching
+// rule. Unmatched paths fall back to diffuse_srgb (0) and return ok=false.
+func (rs *SuffixRuleset) Guess(path string) (SuffixType, bool) {
+	t, _, _, ok := rs.lookup(path)
+	return t, ok
+}
+
+// SuffixMatch is result of SuffixRuleset.Match.
+type SuffixMatch struct {
+	// Rule is matched token rule, nil for directory match or fallback.
+	Rule *SuffixRule `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// DirRule is matched directory rule, nil otherwise.
+	DirRule *SuffixDirRule `json:"dir_rule,omitempty" yaml:"dir_rule,omitempty"`
+	// Type is inferred suffix type, diffuse_srgb on fallback.
+	Type SuffixType `json:"type" yaml:"type"`
+	// OK is false when no rule matched.
+	OK bool `json:"ok" yaml:"ok"`
+}
+
+// Match infers suffix type like Guess and also returns the rule that
+// produced it, for explaining inference.
+func (rs *SuffixRuleset) Match(path string) SuffixMatch {
+	t, rule, dir, ok := rs.lookup(path)
+	return SuffixMatch{Rule: rule, DirRule: dir, Type: t, OK: ok}
+}
+
+// lookup infers suffix type like Guess and returns copy of matched token
+// or directory rule; both are nil on fallback.
+func (rs *SuffixRuleset) lookup(path string) (SuffixType, *SuffixRule, *SuffixDirRule, bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	if i := rs.matchDir(path); i >= 0 {
+		d := rs.dirs[i]
+		return d.Type, nil, &d, true
+	}
+
+	if i := rs.match(path); i >= 0 {
+		r := rs.rules[i]
+		return r.Type, &r, nil, true
+	}
+
+	return SuffixDiffuseSRGB, nil, nil, false
+}
+
+// match returns index of best rule matching path or -1; caller holds mu.
+func (rs *SuffixRuleset) match(path string) int {
+	s := strings.ToLower(path)
+	if dot := strings.LastIndexByte(s, '.'); dot > 0 {
+		s = s[:dot]
+	}
+
+	best := -1
+	for start := range len(s) {
+		node := rs.root
+		for j := start; j < len(s); j++ {
+			if node = node.next[s[j]]; node == nil {
+				break
+			}
+
+			if node.rule >= 0 && isTokenEnd(s, j+1) && rs.better(node.rule, best) {
+				best = node.rule
+			}
+		}
+	}
+
+	return best
+}
+
+// matchDir returns index of longest directory rule matching path or -1;
+// caller holds mu.
+func (rs *SuffixRuleset) matchDir(path string) int {
+	if len(rs.dirs) == 0 {
+		return -1
+	}
+
+	s := strings.ToLower(strings.ReplaceAll(path, "/", `\`))
+	best := -1
+	for i, d := range rs.dirs {
+		if (strings.HasPrefix(s, d.Dir) || strings.Contains(s, `\`+d.Dir)) &&
+			(best < 0 || len(d.Dir) > len(rs.dirs[best].Dir)) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// better reports whether rule i beats rule best (-1 for none).
+func (rs *SuffixRuleset) better(i, best int) bool {
+	if best < 0 {
+		return true
+	}
+
+	a, b := &rs.rules[i], &rs.rules[best]
+	switch {
+	case a.Priority != b.Priority:
+		return a.Priority > b.Priority
+	case len(a.Token) != len(b.Token):
+		return len(a.Token) > len(b.Token)
+	default:
+		return i < best
+	}
+}
+
+// compile rebuilds token trie from rules; caller holds mu for writing.
+func (rs *SuffixRuleset) compile() {
+	rs.root = &suffixTrieNode{rule: -1}
+	for i := range rs.rules {
+		node := rs.root
+		for _, c := range []byte(rs.rules[i].Token) {
+			child := node.next[c]
+			if child == nil {
+				if node.next == nil {
+					node.next = make(map[byte]*suffixTrieNode)
+				}
+				child = &suffixTrieNode{rule: -1}
+				node.next[c] = child
+			}
+			node = child
+		}
+		node.rule = i
+	}
+}
+
+// find returns index of rule with normalized token or -1; caller holds mu.
+func (rs *SuffixRuleset) find(token string) int {
+	return slices.IndexFunc(rs.rules, func(r SuffixRule) bool { return r.Token == token })
+}
+
+// normalizeSuffixToken lowercases and trims token.
+func normalizeSuffixToken(token string) string {
+	return strings.ToLower(strings.TrimSpace(token))
+}
+
+// normalizeSuffixDir lowercases dir, uses '\\' separators and ends it with
+// one separator so matches stop at segment boundary. Empty dir yields "".
+func normalizeSuffixDir(dir string) string {
+	dir = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(dir), "/", `\`))
+	if dir = strings.Trim(dir, `\`); dir == "" {
+		return ""
+	}
+
+	return dir + `\`
+}
+
+// findDir returns index of normalized dir or -1; caller holds mu.
+func (rs *SuffixRuleset) findDir(dir string) int {
+	return slices.IndexFunc(rs.dirs, func(d SuffixDirRule) bool { return d.Dir == dir })
+}
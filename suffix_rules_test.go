@@ -0,0 +1,191 @@
+package texheaders
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSuffixRuleset(t *testing.T) {
+	t.Parallel()
+
+	rs := DefaultSuffixRuleset()
+	if got, ok := rs.Guess(`data\wall_nohq.paa`); !ok || got != SuffixNormalMap {
+		t.Fatalf("Guess(_nohq) = %s, %v", got, ok)
+	}
+
+	rs.AddRule(" _HM ", SuffixAmbientShadow)
+	rs.AddRule("_co", SuffixDiffuseLinear)
+	if got, ok := rs.Guess("rock_hm.paa"); !ok || got != SuffixAmbientShadow {
+		t.Fatalf("Guess(_hm) = %s, %v", got, ok)
+	}
+
+	if got, _ := rs.Guess("rock_co.paa"); got != SuffixDiffuseLinear {
+		t.Fatalf("Guess(updated _co) = %s", got)
+	}
+
+	if rules := rs.Rules(); rules[0] != (SuffixRule{Token: "_hm", Type: SuffixAmbientShadow}) {
+		t.Fatalf("Rules()[0] = %+v", rules[0])
+	}
+
+	if !rs.RemoveRule("_NOHQ") || rs.RemoveRule("_nohq") {
+		t.Fatal("RemoveRule(_nohq) must succeed once")
+	}
+
+	if got, ok := rs.Guess("wall_nohq.paa"); ok {
+		t.Fatalf("Guess(removed) = %s, true", got)
+	}
+
+	if got, _ := GuessSuffixTypeFromPath("wall_nohq.paa"); got != SuffixNormalMap {
+		t.Fatal("ruleset changes leaked into built-in rules")
+	}
+
+	custom := NewSuffixRuleset(SuffixRule{Token: "_x"}, SuffixRule{Token: ""}, SuffixRule{Token: "_X", Type: SuffixNormalMap})
+	if !slices.Equal(custom.Rules(), []SuffixRule{{Token: "_x"}}) {
+		t.Fatalf("NewSuffixRuleset() rules = %+v", custom.Rules())
+	}
+}
+
+func TestBuildOptionsSuffixRules(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := copyFixture(t, "test_co.paa", dir, "rock_hm.paa")
+
+	rs := DefaultSuffixRuleset()
+	rs.AddRule("_hm", SuffixAmbientShadow)
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, SuffixRules: rs})
+	if err := b.Append(path); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if got := f.Textures[0].PaxSuffixType; got != SuffixAmbientShadow {
+		t.Fatalf("PaxSuffixType = %s, want %s", got, SuffixAmbientShadow)
+	}
+}
+
+func TestSuffixRulesetPriority(t *testing.T) {
+	t.Parallel()
+
+	rs := DefaultSuffixRuleset()
+	if got, _ := rs.Guess("wall_mask_co.paa"); got != SuffixMultiShaderMask {
+		t.Fatalf("Guess(longest token) = %s", got)
+	}
+
+	rs.Add(SuffixRule{Token: "_co", Type: SuffixDiffuseLinear, Priority: 1})
+	if got, _ := rs.Guess("wall_mask_co.paa"); got != SuffixDiffuseLinear {
+		t.Fatalf("Guess(priority) = %s", got)
+	}
+
+	rs.AddRule("_ma", SuffixAmbientShadow)
+	if got, _ := rs.Guess("wall_mask.paa"); got != SuffixMultiShaderMask {
+		t.Fatalf("Guess(token boundary) = %s", got)
+	}
+
+	tie := NewSuffixRuleset(SuffixRule{Token: "_aa", Type: SuffixMultiShaderMask}, SuffixRule{Token: "_bb", Type: SuffixNormalMap})
+	tie.AddRule("_cc", SuffixDetailLinear)
+	if got, _ := tie.Guess("x_bb_aa_cc.paa"); got != SuffixDetailLinear {
+		t.Fatalf("Guess(tie) = %s", got)
+	}
+
+	if got, _ := tie.Guess("x_bb_aa.paa"); got != SuffixMultiShaderMask {
+		t.Fatalf("Guess(tie order) = %s", got)
+	}
+}
+
+func BenchmarkSuffixRulesetGuess(b *testing.B) {
+	rs := DefaultSuffixRuleset()
+	for i := range 500 {
+		rs.AddRule(fmt.Sprintf("_custom%03d", i), SuffixDetailLinear)
+	}
+
+	for b.Loop() {
+		rs.Guess(`dz\structures\data\wall_brick_nohq_alpha.paa`)
+	}
+}
+
+func TestDefaultSuffixRules(t *testing.T) {
+	t.Parallel()
+
+	rules := DefaultSuffixRules()
+	if !slices.Equal(rules, DefaultSuffixRuleset().Rules()) {
+		t.Fatal("DefaultSuffixRules() differs from DefaultSuffixRuleset().Rules()")
+	}
+
+	rules[0].Type = SuffixMultiShaderMask
+	if DefaultSuffixRules()[0].Type == SuffixMultiShaderMask {
+		t.Fatal("DefaultSuffixRules() must return a copy")
+	}
+
+	for _, r := range rules {
+		if r.Token != normalizeSuffixToken(r.Token) || !r.Type.Known() {
+			t.Fatalf("built-in rule %+v is not normalized", r)
+		}
+	}
+}
+
+func TestSuffixRulesetDirRules(t *testing.T) {
+	t.Parallel()
+
+	rs := DefaultSuffixRuleset()
+	rs.AddDirRule(`Data\DetailMaps\`, SuffixDetailLinear)
+	rs.AddDirRule("data/detailmaps/normals", SuffixNormalMap)
+	rs.AddDirRule(" ", SuffixMultiShaderMask)
+
+	cases := map[string]SuffixType{
+		`data\detailmaps\grass_co.paa`:          SuffixDetailLinear,
+		`mod/data/detailmaps/rock.paa`:          SuffixDetailLinear,
+		`data\detailmaps\normals\rock_co.paa`:   SuffixNormalMap,
+		`data\detailmaps2\grass_co.paa`:         SuffixDiffuseSRGB,
+		`otherdata\detailmaps\grass_nohq.paa`:   SuffixNormalMap,
+		`xdata\detailmaps\grass_nohq_alpha.paa`: SuffixDiffuseSRGB,
+	}
+	for p, want := range cases {
+		if got, ok := rs.Guess(p); !ok || got != want {
+			t.Fatalf("Guess(%s) = %s, %v; want %s", p, got, ok, want)
+		}
+	}
+
+	want := []SuffixDirRule{{Dir: `data\detailmaps\`, Type: SuffixDetailLinear}, {Dir: `data\detailmaps\normals\`, Type: SuffixNormalMap}}
+	if !slices.Equal(rs.DirRules(), want) {
+		t.Fatalf("DirRules() = %+v", rs.DirRules())
+	}
+
+	c := rs.Clone()
+	if !rs.RemoveDirRule("DATA/detailmaps") || rs.RemoveDirRule(`data\detailmaps`) {
+		t.Fatal("RemoveDirRule() must succeed once")
+	}
+
+	if got, _ := rs.Guess(`data\detailmaps\grass_co.paa`); got != SuffixDiffuseSRGB {
+		t.Fatalf("Guess(removed dir) = %s", got)
+	}
+
+	if got, _ := c.Guess(`data\detailmaps\grass_co.paa`); got != SuffixDetailLinear || !slices.Equal(c.DirRules(), want) {
+		t.Fatalf("Clone() shares rules with original: %s %+v", got, c.DirRules())
+	}
+}
+
+func TestSuffixRulesetMatch(t *testing.T) {
+	t.Parallel()
+
+	rs := DefaultSuffixRuleset()
+	rs.AddDirRule("detail", SuffixDetailLinear)
+
+	if m := rs.Match("rock_nohq.paa"); !m.OK || m.Type != SuffixNormalMap || m.Rule == nil || m.Rule.Token != "_nohq" || m.DirRule != nil {
+		t.Fatalf("Match(token) = %+v", m)
+	}
+
+	if m := rs.Match(`detail\rock_nohq.paa`); !m.OK || m.Type != SuffixDetailLinear || m.Rule != nil || m.DirRule == nil {
+		t.Fatalf("Match(dir) = %+v", m)
+	}
+
+	if m := rs.Match("plain.paa"); m.OK || m.Rule != nil || m.DirRule != nil || m.Type != SuffixDiffuseSRGB {
+		t.Fatalf("Match(fallback) = %+v", m)
+	}
+}
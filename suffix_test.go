@@ -1,6 +1,11 @@
 package texheaders
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestGuessSuffixTypeFromPath(t *testing.T) {
 	t.Parallel()
@@ -8,7 +13,7 @@ func TestGuessSuffixTypeFromPath(t *testing.T) {
 	tests := []struct {
 		name     string
 		path     string
-		wantType uint32
+		wantType SuffixType
 		wantOK   bool
 	}{
 		{
@@ -35,6 +40,42 @@ func TestGuessSuffixTypeFromPath(t *testing.T) {
 			wantType: SuffixDetailSpecularAmount,
 			wantOK:   true,
 		},
+		{
+			name:     "adshq recognized",
+			path:     `data\tree_adshq.paa`,
+			wantType: SuffixDiffuseSRGB,
+			wantOK:   true,
+		},
+		{
+			name:     "ti recognized",
+			path:     "data/vehicle_ti.paa",
+			wantType: SuffixDiffuseSRGB,
+			wantOK:   true,
+		},
+		{
+			name:     "ti_ca thermal",
+			path:     "data/vehicle_ti_ca.paa",
+			wantType: SuffixThermalImageTextureCA,
+			wantOK:   true,
+		},
+		{
+			name:     "mca not macro",
+			path:     "data/terrain_mca.paa",
+			wantType: SuffixDiffuseSRGB,
+			wantOK:   true,
+		},
+		{
+			name:     "lca not linear",
+			path:     "data/glass_lca.paa",
+			wantType: SuffixDiffuseSRGB,
+			wantOK:   true,
+		},
+		{
+			name:     "sdm recognized",
+			path:     "data/metal_sdm.paa",
+			wantType: SuffixDiffuseSRGB,
+			wantOK:   true,
+		},
 		{
 			name:     "unknown fallback",
 			path:     "a/b/c/plain_texture.paa",
@@ -55,3 +96,88 @@ func TestGuessSuffixTypeFromPath(t *testing.T) {
 		})
 	}
 }
+
+func TestSuffixTypeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		v    SuffixType
+		want string
+	}{
+		{v: SuffixDiffuseSRGB, want: "diffuse_srgb"},
+		{v: SuffixNormalMap, want: "normal_map"},
+		{v: SuffixSpecularAmount, want: "specular_amount"},
+		{v: SuffixThermalImageTextureCA, want: "thermal_image_texture_ca"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Fatalf("SuffixType(%d).String() = %q, want %q", uint32(tt.v), got, tt.want)
+		}
+
+		got, err := ParseSuffixType(strings.ToUpper(tt.want))
+		if err != nil || got != tt.v {
+			t.Fatalf("ParseSuffixType(%q) = %v, %v; want %v", tt.want, got, err, tt.v)
+		}
+	}
+
+	if got := SuffixType(77).String(); got != "SuffixType(77)" {
+		t.Fatalf("String() = %q, want SuffixType(77)", got)
+	}
+
+	if got, err := ParseSuffixType("77"); err != nil || got != 77 {
+		t.Fatalf("ParseSuffixType(77) = %v, %v", got, err)
+	}
+
+	if _, err := ParseSuffixType("albedo"); !errors.Is(err, ErrUnknownSuffixType) {
+		t.Fatalf("ParseSuffixType(albedo) error = %v, want ErrUnknownSuffixType", err)
+	}
+}
+
+func TestSuffixTypeJSON(t *testing.T) {
+	t.Parallel()
+
+	in := BuildOptions{SuffixOverrides: map[string]SuffixType{`a\b_co.paa`: SuffixNormalMap}}
+	raw, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+
+	if !strings.Contains(string(raw), `"normal_map"`) {
+		t.Fatalf("Marshal() = %s, want normal_map", raw)
+	}
+
+	var out BuildOptions
+	if err = json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	if out.SuffixOverrides[`a\b_co.paa`] != SuffixNormalMap {
+		t.Fatalf("SuffixOverrides = %v", out.SuffixOverrides)
+	}
+}
+
+func TestSuffixTokens(t *testing.T) {
+	t.Parallel()
+
+	if got := SuffixTokens(SuffixNormalMap); len(got) == 0 || got[0] != "_nohq" {
+		t.Fatalf("SuffixTokens(normal_map) = %v", got)
+	}
+
+	if got := SuffixTokens(SuffixRandom05To1); got != nil {
+		t.Fatalf("SuffixTokens(random_05_to_1) = %v, want nil", got)
+	}
+
+	SuffixTokens(SuffixDiffuseSRGB)[0] = "_x"
+	if SuffixTokens(SuffixDiffuseSRGB)[0] != "_co" {
+		t.Fatal("SuffixTokens() must return a copy")
+	}
+
+	for st, tokens := range suffixCanonicalTokens {
+		for _, token := range tokens {
+			if got, ok := GuessSuffixTypeFromPath("rock" + token + ".paa"); !ok || got != st {
+				t.Fatalf("token %s of %s guesses %s, %v", token, st, got, ok)
+			}
+		}
+	}
+}
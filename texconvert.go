@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/woozymasta/paa/texconfig"
+)
+
+// TexConvertConfig holds texture hints read from TexConvert.cfg, the
+// configuration of Bohemia's texture conversion tools.
+type TexConvertConfig struct {
+	// Config is the configuration parsed by paa texconfig package, with
+	// TextureHints classes flattened in file order; first match wins.
+	Config texconfig.TexConvertConfig `json:"config" yaml:"config"`
+}
+
+// texConvertFormats maps TexConvert hint formats to pax formats.
+var texConvertFormats = map[texconfig.TexFormat]PaxFormat{
+	texconfig.TexFormatP8:       PaxFormatP8,
+	texconfig.TexFormatARGB4444: PaxFormatARGB4,
+	texconfig.TexFormatARGB1555: PaxFormatARGBA5,
+	texconfig.TexFormatAI88:     PaxFormatGRAYA,
+	texconfig.TexFormatDXT1:     PaxFormatDXT1,
+	texconfig.TexFormatDXT2:     PaxFormatDXT2,
+	texconfig.TexFormatDXT3:     PaxFormatDXT3,
+	texconfig.TexFormatDXT4:     PaxFormatDXT4,
+	texconfig.TexFormatDXT5:     PaxFormatDXT5,
+}
+
+// LoadTexConvert reads TexConvert.cfg from file path.
+func LoadTexConvert(path string) (*TexConvertConfig, error) {
+	cfg, err := texconfig.LoadTexConvertConfig(path)
+	if err != nil {
+		if pathErr := (*fs.PathError)(nil); errors.As(err, &pathErr) {
+			return nil, fmt.Errorf("read %q: %w", path, err)
+		}
+
+		return nil, fmt.Errorf("parse %q: %w: %w", path, ErrInvalidTexConvert, err)
+	}
+
+	return &TexConvertConfig{Config: cfg}, nil
+}
+
+// ParseTexConvert decodes TexConvert.cfg text. Only TextureHints classes
+// are interpreted; hint classes may inherit from earlier siblings.
+func ParseTexConvert(data []byte) (*TexConvertConfig, error) {
+	cfg, err := texconfig.ParseTexConvertConfig(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTexConvert, err)
+	}
+
+	return &TexConvertConfig{Config: cfg}, nil
+}
+
+// Ruleset derives suffix rules from "*<token>.*" hint patterns in file
+// order. Suffix type of each token is resolved with base (built-in rules
+// when nil); tokens base does not know are skipped. Tokens absent from
+// config do not match, as TexConvert treats them as plain textures.
+func (c *TexConvertConfig) Ruleset(base *SuffixRuleset) *SuffixRuleset {
+	if base == nil {
+		base = defaultSuffixRuleset
+	}
+
+	rules := make([]SuffixRule, 0, len(c.Config.Hints))
+	for _, h := range c.Config.Hints {
+		token := texConvertToken(h.Pattern)
+		if token == "" {
+			continue
+		}
+
+		if t, ok := base.Guess("x" + token + ".paa"); ok {
+			rules = append(rules, SuffixRule{Token: token, Type: t})
+		}
+	}
+
+	return NewSuffixRuleset(rules...)
+}
+
+// Format returns pax format expected for texture path by first hint whose
+// pattern matches file name; ok is false when no hint with format matches.
+func (c *TexConvertConfig) Format(texturePath string) (PaxFormat, bool) {
+	name := texturePath
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	h, ok := texconfig.Resolve(name, c.Config)
+	if !ok {
+		return 0, false
+	}
+
+	f, ok := texConvertFormats[h.Format]
+	return f, ok
+}
+
+// texConvertToken extracts token from "*<token>.*" pattern.
+func texConvertToken(pattern string) string {
+	p := strings.ToLower(strings.TrimSpace(pattern))
+	if !strings.HasPrefix(p, "*") || !strings.HasSuffix(p, ".*") {
+		return ""
+	}
+
+	token := p[1 : len(p)-2]
+	if token == "" || strings.ContainsAny(token, "*?[]\\/.") {
+		return ""
+	}
+
+	return token
+}
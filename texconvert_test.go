@@ -0,0 +1,140 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/woozymasta/paa/texconfig"
+)
+
+const testTexConvertCfg = `// TexConvert.cfg sample
+convertVersion = 9;
+class TextureHints
+{
+	/* normal maps */
+	class NormalMap_NOHQ
+	{
+		name = "*_nohq.*";
+		format = "DXT5";
+		channelSwizzleA = "1-R";
+		mipmapFilter = "NormalizeNormalMapAlpha";
+	};
+	class NormalMap_NOFHQ: NormalMap_NOHQ
+	{
+		name = "*_nofhq.*";
+	};
+	class SpecularMap
+	{
+		name = "*_smdi.*";
+		format = "ARGB1555";
+		dynRange = 0;
+	};
+	class Macro { name = "*_mc.*"; format = default; };
+	class Custom { name = "*_hm.*"; format = "AI88"; };
+	class Diffuse { name = "*_co.*"; format = "DXT1"; };
+	class Sky { name = "sky_*.*"; format = "ARGB4444"; };
+};
+class Other { class Ignored { name = "*_ca.*"; }; };
+`
+
+func TestParseTexConvert(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ParseTexConvert([]byte(testTexConvertCfg))
+	if err != nil {
+		t.Fatalf("ParseTexConvert() error: %v", err)
+	}
+
+	hints := cfg.Config.Hints
+	if len(hints) != 7 {
+		t.Fatalf("Hints = %d, want 7", len(hints))
+	}
+
+	if h := hints[1]; h.ClassName != "NormalMap_NOFHQ" || texConvertToken(h.Pattern) != "_nofhq" || h.Format != texconfig.TexFormatDXT5 {
+		t.Fatalf("inherited hint = %+v", h)
+	}
+
+	if h := hints[6]; texConvertToken(h.Pattern) != "" || h.Pattern != "sky_*.*" {
+		t.Fatalf("non-token hint = %+v", h)
+	}
+
+	formats := map[string]struct {
+		want PaxFormat
+		ok   bool
+	}{
+		`data\wall_NOHQ.paa`:  {PaxFormatDXT5, true},
+		"data/rock_smdi.tga":  {PaxFormatARGBA5, true},
+		"rock_hm.png":         {PaxFormatGRAYA, true},
+		"sky_day.paa":         {PaxFormatARGB4, true},
+		"terrain_mc.paa":      {0, false},
+		"plain.paa":           {0, false},
+		"thermal_ti_ca.paa":   {0, false},
+		`data\rock_co_co.paa`: {PaxFormatDXT1, true},
+	}
+	for p, want := range formats {
+		if got, ok := cfg.Format(p); got != want.want || ok != want.ok {
+			t.Fatalf("Format(%s) = %s, %v; want %s, %v", p, got, ok, want.want, want.ok)
+		}
+	}
+}
+
+func TestTexConvertRuleset(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ParseTexConvert([]byte(testTexConvertCfg))
+	if err != nil {
+		t.Fatalf("ParseTexConvert() error: %v", err)
+	}
+
+	rs := cfg.Ruleset(nil)
+	if got, ok := rs.Guess("wall_nofhq.paa"); !ok || got != SuffixNormalMap {
+		t.Fatalf("Guess(_nofhq) = %s, %v", got, ok)
+	}
+
+	if got, ok := rs.Guess("rock_hm.paa"); ok {
+		t.Fatalf("Guess(unknown token) = %s, true", got)
+	}
+
+	if got, ok := rs.Guess("glass_ca.paa"); ok {
+		t.Fatalf("Guess(token absent from config) = %s, true", got)
+	}
+
+	base := DefaultSuffixRuleset()
+	base.AddRule("_hm", SuffixAmbientShadow)
+	if got, ok := cfg.Ruleset(base).Guess("rock_hm.paa"); !ok || got != SuffixAmbientShadow {
+		t.Fatalf("Guess(_hm with base) = %s, %v", got, ok)
+	}
+}
+
+func TestParseTexConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"unclosed class":   "class TextureHints {",
+		"stray brace":      "};",
+		"missing value":    "class A { name = ; };",
+		"missing equals":   "class A { name \"x\"; };",
+		"unterminated str": "class A { name = \"x; };",
+		"comment":          "/* open",
+		"array":            "a[] = {1 2};",
+		"no hints":         "class Other {};",
+		"unknown format":   "class TextureHints { class A { name = \"*_co.*\"; format = \"ARGB8888\"; }; };",
+		"unknown base":     "class TextureHints { class A: B { name = \"*_co.*\"; }; };",
+	}
+	for name, data := range cases {
+		if _, err := ParseTexConvert([]byte(data)); !errors.Is(err, ErrInvalidTexConvert) {
+			t.Fatalf("%s: ParseTexConvert() error = %v, want ErrInvalidTexConvert", name, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "TexConvert.cfg")
+	if err := os.WriteFile(path, []byte(testTexConvertCfg), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	if _, err := LoadTexConvert(path); err != nil {
+		t.Fatalf("LoadTexConvert() error: %v", err)
+	}
+}
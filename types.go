@@ -21,53 +21,59 @@ type File struct {
 }
 
 // TextureEntry describes one texture metadata entry.
+//
+// Only path and mip list are omitted when empty; binary fields are always
+// marshaled because zero values (P8 format, diffuse suffix, big endian,
+// transparent color 0) are meaningful and must survive a round trip.
 type TextureEntry struct {
 	// PAAFile is a path relative to texHeaders.bin location.
 	PAAFile string `json:"paa_file,omitempty" yaml:"paa_file,omitempty"`
 	// MipMaps contains mip descriptors.
 	MipMaps []MipMap `json:"mipmaps,omitempty" yaml:"mipmaps,omitempty"`
 
-	// ColorPaletteCount is usually 1.
-	ColorPaletteCount uint32 `json:"color_palette_count,omitempty" yaml:"color_palette_count,omitempty"`
-	// PalettePtr is usually 0.
-	PalettePtr uint32 `json:"palette_ptr,omitempty" yaml:"palette_ptr,omitempty"`
+	// ColorPaletteCount is 1 for regular textures and the number of palette
+	// colors for palettized (P8) textures.
+	ColorPaletteCount uint32 `json:"color_palette_count" yaml:"color_palette_count"`
+	// PalettePtr is 0 for regular textures and the palette data offset in
+	// source file for palettized (P8) textures.
+	PalettePtr uint32 `json:"palette_ptr" yaml:"palette_ptr"`
 
 	// AverageColorF stores average color as float32 tuple.
-	AverageColorF [4]float32 `json:"average_color_f,omitempty" yaml:"average_color_f,omitempty"`
+	AverageColorF [4]float32 `json:"average_color_f" yaml:"average_color_f"`
 	// AverageColor stores average color as byte tuple.
-	AverageColor [4]byte `json:"average_color,omitempty" yaml:"average_color,omitempty"`
+	AverageColor [4]byte `json:"average_color" yaml:"average_color"`
 	// MaxColor stores max color as byte tuple.
-	MaxColor [4]byte `json:"max_color,omitempty" yaml:"max_color,omitempty"`
+	MaxColor [4]byte `json:"max_color" yaml:"max_color"`
 
 	// ClampFlags is usually 0.
-	ClampFlags uint32 `json:"clamp_flags,omitempty" yaml:"clamp_flags,omitempty"`
+	ClampFlags uint32 `json:"clamp_flags" yaml:"clamp_flags"`
 	// TransparentColor is usually 0xFFFFFFFF.
-	TransparentColor uint32 `json:"transparent_color,omitempty" yaml:"transparent_color,omitempty"`
+	TransparentColor uint32 `json:"transparent_color" yaml:"transparent_color"`
 
 	// HasMaxCtagg means MaxColor was set by source paa.
-	HasMaxCtagg bool `json:"has_max_ctagg,omitempty" yaml:"has_max_ctagg,omitempty"`
+	HasMaxCtagg bool `json:"has_max_ctagg" yaml:"has_max_ctagg"`
 	// IsAlpha means FLAGTAG = 1 basic transparency.
-	IsAlpha bool `json:"is_alpha,omitempty" yaml:"is_alpha,omitempty"`
+	IsAlpha bool `json:"is_alpha" yaml:"is_alpha"`
 	// IsTransparent means FLAGTAG = 2 non-interpolated alpha.
-	IsTransparent bool `json:"is_transparent,omitempty" yaml:"is_transparent,omitempty"`
+	IsTransparent bool `json:"is_transparent" yaml:"is_transparent"`
 	// IsAlphaNonOpaque means IsAlpha and average alpha < 0x80.
-	IsAlphaNonOpaque bool `json:"is_alpha_non_opaque,omitempty" yaml:"is_alpha_non_opaque,omitempty"`
+	IsAlphaNonOpaque bool `json:"is_alpha_non_opaque" yaml:"is_alpha_non_opaque"`
 
 	// MipMapCount is usually equal to MipMapCountCopy.
-	MipMapCount uint32 `json:"mipmap_count,omitempty" yaml:"mipmap_count,omitempty"`
+	MipMapCount uint32 `json:"mipmap_count" yaml:"mipmap_count"`
 	// PaxFormat describes texture storage format.
-	PaxFormat uint32 `json:"pax_format,omitempty" yaml:"pax_format,omitempty"`
+	PaxFormat PaxFormat `json:"pax_format" yaml:"pax_format"`
 	// LittleEndian is expected to be true.
-	LittleEndian bool `json:"little_endian,omitempty" yaml:"little_endian,omitempty"`
+	LittleEndian bool `json:"little_endian" yaml:"little_endian"`
 	// IsPAA tells whether source file is .paa.
-	IsPAA bool `json:"is_paa,omitempty" yaml:"is_paa,omitempty"`
+	IsPAA bool `json:"is_paa" yaml:"is_paa"`
 	// PaxSuffixType is texture suffix class identifier.
-	PaxSuffixType uint32 `json:"pax_suffix_type,omitempty" yaml:"pax_suffix_type,omitempty"`
+	PaxSuffixType SuffixType `json:"pax_suffix_type" yaml:"pax_suffix_type"`
 
 	// MipMapCountCopy is usually equal to MipMapCount.
-	MipMapCountCopy uint32 `json:"mipmap_count_copy,omitempty" yaml:"mipmap_count_copy,omitempty"`
+	MipMapCountCopy uint32 `json:"mipmap_count_copy" yaml:"mipmap_count_copy"`
 	// PaxFileSize stores source pax file size in bytes.
-	PaxFileSize uint32 `json:"pax_file_size,omitempty" yaml:"pax_file_size,omitempty"`
+	PaxFileSize uint32 `json:"pax_file_size" yaml:"pax_file_size"`
 }
 
 // MipMap describes one mipmap descriptor.
@@ -75,11 +81,11 @@ type MipMap struct {
 	Width  uint16 `json:"width,omitempty" yaml:"width,omitempty"`
 	Height uint16 `json:"height,omitempty" yaml:"height,omitempty"`
 	// AlwaysZero is expected to be 0 in known files.
-	AlwaysZero uint16 `json:"always_zero,omitempty" yaml:"always_zero,omitempty"`
+	AlwaysZero uint16 `json:"always_zero" yaml:"always_zero"`
 	// PaxFormat usually matches entry PaxFormat.
-	PaxFormat uint8 `json:"pax_format,omitempty" yaml:"pax_format,omitempty"`
+	PaxFormat uint8 `json:"pax_format" yaml:"pax_format"`
 	// AlwaysThree is expected to be 3 in known files.
-	AlwaysThree uint8 `json:"always_three,omitempty" yaml:"always_three,omitempty"`
+	AlwaysThree uint8 `json:"always_three" yaml:"always_three"`
 	// DataOffset points to mip payload inside source pax.
 	DataOffset uint32 `json:"data_offset,omitempty" yaml:"data_offset,omitempty"`
 }
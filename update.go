@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+// Update builds appended inputs reusing entries of existing model.
+//
+// Entries whose normalized path is present in existing and whose source file
+// size is unchanged are carried over without re-scanning. Changed and new
+// inputs are scanned, and existing entries with no matching input are dropped.
+func (b *Builder) Update(existing *File) (*File, error) {
+	if existing == nil {
+		return nil, ErrNilFile
+	}
+
+	return b.build(existing.Index())
+}
+
+// UpdateFile updates texheaders file at path in place from appended inputs.
+func (b *Builder) UpdateFile(path string) error {
+	existing, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := b.Update(existing)
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(path, f)
+}
+
+// entryFor returns reusable or fresh entry for input, hashing its source
+// when DetectDuplicates is set and retrying transient errors.
+func (b *Builder) entryFor(path string, reuse map[string]*TextureEntry) (TextureEntry, error) {
+	if b.pool != nil {
+		b.pool <- struct{}{}
+		defer func() {
+			<-b.pool
+		}()
+	}
+
+	if b.opts.StrictPaths {
+		if err := checkStoredPath(b.normalizePath(path)); err != nil {
+			return TextureEntry{}, err
+		}
+	}
+
+	return b.buildWithRetry(path, func() (TextureEntry, error) {
+		entry, err := b.reuseOrBuild(path, reuse)
+		if err == nil && b.opts.DetectDuplicates {
+			err = b.recordHash(path, entry.PAAFile)
+		}
+
+		return entry, err
+	})
+}
+
+// reuseOrBuild returns entry from reuse when source size is unchanged, otherwise builds it.
+func (b *Builder) reuseOrBuild(path string, reuse map[string]*TextureEntry) (TextureEntry, error) {
+	if reuse != nil {
+		rel := b.normalizePath(path)
+		if old, ok := reuse[indexKey(rel, IndexOptions{})]; ok {
+			info, err := b.inputInfo(path)
+			if err == nil && info.Size() == int64(old.PaxFileSize) {
+				entry := cloneEntry(old)
+				entry.PAAFile = rel
+				return entry, nil
+			}
+		}
+	}
+
+	return b.buildEntry(path)
+}
+
+// cloneEntry returns a copy of entry that does not share mip slice.
+func cloneEntry(entry *TextureEntry) TextureEntry {
+	out := *entry
+	if entry.MipMaps != nil {
+		out.MipMaps = make([]MipMap, len(entry.MipMaps))
+		copy(out.MipMaps, entry.MipMaps)
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package texheaders
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilder_Update(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	copyFixture(t, "test_co.paa", dir, "b_co.paa")
+
+	existing, err := buildDir(t, dir).Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	// Marker survives only for entries carried over without re-scan.
+	for i := range existing.Textures {
+		existing.Textures[i].ClampFlags = 7
+	}
+
+	copyFixture(t, "test_nohq.paa", dir, "b_co.paa")
+	copyFixture(t, "test_co.paa", dir, "c_co.paa")
+	existing.Textures = append(existing.Textures, TextureEntry{PAAFile: "removed_co.paa"})
+
+	got, err := buildDir(t, dir).Update(existing)
+	if err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	wantClamp := map[string]uint32{"a_co.paa": 7, "b_co.paa": 0, "c_co.paa": 0}
+	if len(got.Textures) != len(wantClamp) {
+		t.Fatalf("textures = %d, want %d", len(got.Textures), len(wantClamp))
+	}
+
+	for _, tex := range got.Textures {
+		want, ok := wantClamp[tex.PAAFile]
+		if !ok {
+			t.Fatalf("unexpected entry %q", tex.PAAFile)
+		}
+
+		if tex.ClampFlags != want {
+			t.Fatalf("%s clamp flags = %d, want %d", tex.PAAFile, tex.ClampFlags, want)
+		}
+	}
+
+	if &got.Textures[0].MipMaps[0] == &existing.Textures[0].MipMaps[0] {
+		t.Fatalf("carried over entry shares mipmaps with existing model")
+	}
+}
+
+// buildDir returns builder with all textures from dir appended.
+func buildDir(t *testing.T, dir string) *Builder {
+	t.Helper()
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err := b.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir(%s) error: %v", filepath.Base(dir), err)
+	}
+
+	return b
+}
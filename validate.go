@@ -5,108 +5,705 @@
 package texheaders
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"path/filepath"
+	"strings"
 )
 
+// Severity is the weight of ValidationIssue.
+type Severity string
+
+// Validation issue severities.
+const (
+	// SeverityError marks an invariant violation; the file is invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a suspicious value the file is still usable with.
+	SeverityWarning Severity = "warning"
+	// SeverityOff disables a check in ValidateOptions.Checks; reported
+	// issues never have it.
+	SeverityOff Severity = "off"
+)
+
+// Strictness is a ValidateOptions preset adjusting default check severities.
+type Strictness int
+
+// Validation strictness presets.
+const (
+	// StrictnessDefault keeps built-in severities.
+	StrictnessDefault Strictness = iota
+	// StrictnessStrict turns every warning into an error.
+	StrictnessStrict
+	// StrictnessLenient downgrades constant and ordering checks often broken
+	// by legacy tools (lenientCodes) to warnings.
+	StrictnessLenient
+)
+
+// lenientCodes are checks downgraded to warnings by StrictnessLenient.
+var lenientCodes = map[ValidationCode]bool{
+	CodeAlwaysZero:  true,
+	CodeAlwaysThree: true,
+	CodeMipFormat:   true,
+	CodeOffsetOrder: true,
+}
+
+// optInCodes are checks beyond the ValidateFile baseline which run only when
+// ValidateOptions.Checks sets their severity; Strictness does not enable them.
+var optInCodes = map[ValidationCode]bool{
+	CodeOffsetBounds:  true,
+	CodeMipOrder:      true,
+	CodePathEscape:    true,
+	CodePathExtension: true,
+}
+
+// ValidateOptions configures ValidateFileWithOptions.
+type ValidateOptions struct {
+	// Checks overrides severity per check after Strictness is applied;
+	// SeverityOff disables the check, any other severity enables opt-in
+	// checks.
+	Checks map[ValidationCode]Severity `json:"checks,omitempty" yaml:"checks,omitempty"`
+	// ColorEpsilon is the tolerance of color_mismatch check;
+	// DefaultColorEpsilon when zero.
+	ColorEpsilon float32 `json:"color_epsilon,omitempty" yaml:"color_epsilon,omitempty"`
+	// Profile names built-in engine profile (ProfileDayZ, ProfileArma3,
+	// ProfileLegacy) whose texture size, format and suffix limits are checked;
+	// empty disables profile checks.
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// MaxEntries limits entry count; Profile limit when zero, unlimited when
+	// negative or when zero without Profile.
+	MaxEntries int `json:"max_entries,omitempty" yaml:"max_entries,omitempty"`
+	// MaxPathLength limits PAAFile length in bytes; Profile limit when zero,
+	// unlimited when negative or when zero without Profile.
+	MaxPathLength int `json:"max_path_length,omitempty" yaml:"max_path_length,omitempty"`
+	// MaxMipMaps limits mip count per entry; Profile limit when zero,
+	// unlimited when negative or when zero without Profile.
+	MaxMipMaps int `json:"max_mipmaps,omitempty" yaml:"max_mipmaps,omitempty"`
+	// Strictness selects severity preset.
+	Strictness Strictness `json:"strictness,omitempty" yaml:"strictness,omitempty"`
+}
+
+// Default structural limits of built-in engine profiles.
+const (
+	// DefaultMaxEntries is far above entry count of the largest known addons.
+	DefaultMaxEntries = 65536
+	// DefaultMaxPathLength keeps stored path within Windows MAX_PATH.
+	DefaultMaxPathLength = 259
+	// DefaultMaxMipMaps is the mip count of a full 16384x16384 chain.
+	DefaultMaxMipMaps = 15
+)
+
+// limit returns v, def when v is zero, or -1 (unlimited) when the result
+// is not positive.
+func limit(v, def int) int {
+	if v == 0 {
+		v = def
+	}
+
+	if v <= 0 {
+		return -1
+	}
+
+	return v
+}
+
+// severity resolves effective severity of check with built-in severity def.
+func (o *ValidateOptions) severity(code ValidationCode, def Severity) Severity {
+	if s, ok := o.Checks[code]; ok {
+		return s
+	}
+
+	if optInCodes[code] {
+		return SeverityOff
+	}
+
+	switch {
+	case o.Strictness == StrictnessStrict:
+		return SeverityError
+	case o.Strictness == StrictnessLenient && lenientCodes[code]:
+		return SeverityWarning
+	default:
+		return def
+	}
+}
+
+// ValidationCode is a stable machine-readable identifier of a validation check.
+type ValidationCode string
+
+// Validation checks.
+const (
+	// CodeNilFile means validated file is nil.
+	CodeNilFile ValidationCode = "nil_file"
+	// CodeInvalidMagic means file magic is not FileMagic.
+	CodeInvalidMagic ValidationCode = "invalid_magic"
+	// CodeUnsupportedVersion means file version is not SupportedVersion.
+	CodeUnsupportedVersion ValidationCode = "unsupported_version"
+	// CodeTooManyTextures means entry count does not fit uint32.
+	CodeTooManyTextures ValidationCode = "too_many_textures"
+	// CodeTooManyEntries means entry count exceeds ValidateOptions.MaxEntries.
+	CodeTooManyEntries ValidationCode = "too_many_entries"
+	// CodePathLength means entry path exceeds ValidateOptions.MaxPathLength.
+	CodePathLength ValidationCode = "path_length"
+	// CodeTooManyMipMaps means entry mip count exceeds ValidateOptions.MaxMipMaps.
+	CodeTooManyMipMaps ValidationCode = "too_many_mipmaps"
+	// CodeNilEntry means validated entry is nil.
+	CodeNilEntry ValidationCode = "nil_entry"
+	// CodeEmptyPath means entry has no PAAFile.
+	CodeEmptyPath ValidationCode = "empty_path"
+	// CodePathEscape means entry path is absolute, has drive letter or ".."
+	// segments; opt-in.
+	CodePathEscape ValidationCode = "path_escape"
+	// CodePathSeparator means entry path uses forward slashes.
+	CodePathSeparator ValidationCode = "path_separator"
+	// CodePathCase means entry path is not lowercase; disable it in
+	// ValidateOptions.Checks for case-preserving pipelines.
+	CodePathCase ValidationCode = "path_case"
+	// CodePathExtension means entry path does not end in .paa or .pac; opt-in.
+	CodePathExtension ValidationCode = "path_extension"
+	// CodeSuffixMismatch means suffix type differs from the one guessed by file name.
+	CodeSuffixMismatch ValidationCode = "suffix_mismatch"
+	// CodePaxFormatRange means entry pax format does not fit mip descriptor byte.
+	CodePaxFormatRange ValidationCode = "pax_format_range"
+	// CodePalette means palette fields do not match palettized or regular format.
+	CodePalette ValidationCode = "palette"
+	// CodeMipCount means mip counters disagree with each other or mip list.
+	CodeMipCount ValidationCode = "mip_count"
+	// CodeZeroDimension means mip has zero width or height.
+	CodeZeroDimension ValidationCode = "zero_dimension"
+	// CodeAlwaysZero means mip always_zero field is not 0.
+	CodeAlwaysZero ValidationCode = "always_zero"
+	// CodeAlwaysThree means mip always_three field is not 3.
+	CodeAlwaysThree ValidationCode = "always_three"
+	// CodeMipFormat means mip pax format differs from entry pax format.
+	CodeMipFormat ValidationCode = "mip_format"
+	// CodeOffsetOrder means mip data offsets are not ascending.
+	CodeOffsetOrder ValidationCode = "offset_order"
+	// CodeMipOrder means mip dimensions do not decrease through the chain;
+	// opt-in.
+	CodeMipOrder ValidationCode = "mip_order"
+	// CodeNonPowerOfTwo means top mip dimensions are not power of two.
+	CodeNonPowerOfTwo ValidationCode = "non_power_of_two"
+	// CodeBrokenMipChain means mip level is not half (clamped at 1) of previous one.
+	CodeBrokenMipChain ValidationCode = "broken_mip_chain"
+	// CodeBlockAlignment means DXT mip dimensions are not multiples of 4x4 block.
+	CodeBlockAlignment ValidationCode = "block_alignment"
+	// CodeOffsetBounds means mip data offset points past declared pax file
+	// size; opt-in.
+	CodeOffsetBounds ValidationCode = "offset_bounds"
+	// CodeColorMismatch means AverageColorF diverges from AverageColor.
+	CodeColorMismatch ValidationCode = "color_mismatch"
+	// CodeAlphaFlags means is_alpha_non_opaque contradicts is_alpha and average alpha.
+	CodeAlphaFlags ValidationCode = "alpha_flags"
+	// CodeDuplicatePath means several entries share a path ignoring case and separators.
+	CodeDuplicatePath ValidationCode = "duplicate_path"
+)
+
+// ValidationIssue is one finding of file validation.
+type ValidationIssue struct {
+	// Code identifies the failed check.
+	Code ValidationCode `json:"code" yaml:"code"`
+	// Severity tells whether the issue invalidates the file.
+	Severity Severity `json:"severity" yaml:"severity"`
+	// Field is the JSON name of offending field relative to entry or file,
+	// e.g. "mipmaps[1].always_zero".
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Path is PAAFile of offending entry.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Message is human-readable description.
+	Message string `json:"message" yaml:"message"`
+	// Entry is index of offending entry, -1 for file-level issues.
+	Entry int `json:"entry" yaml:"entry"`
+	// Fixable tells whether ValidateAndFix corrects the issue.
+	Fixable bool `json:"fixable,omitempty" yaml:"fixable,omitempty"`
+}
+
+// fixableCodes are checks corrected by ValidateAndFix.
+var fixableCodes = map[ValidationCode]bool{
+	CodeMipCount:      true,
+	CodeAlwaysZero:    true,
+	CodeAlwaysThree:   true,
+	CodeMipFormat:     true,
+	CodeColorMismatch: true,
+}
+
+// ValidationReport holds all findings of file validation in check order.
+type ValidationReport struct {
+	// Issues lists errors and warnings.
+	Issues []ValidationIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// HasErrors reports whether report contains any error-severity issue.
+func (r *ValidationReport) HasErrors() bool {
+	for i := range r.Issues {
+		if r.Issues[i].Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Errors returns error-severity issues.
+func (r *ValidationReport) Errors() []ValidationIssue {
+	return r.bySeverity(SeverityError)
+}
+
+// Warnings returns warning-severity issues.
+func (r *ValidationReport) Warnings() []ValidationIssue {
+	return r.bySeverity(SeverityWarning)
+}
+
+// Err joins error-severity issues into one error wrapping ErrValidation;
+// nil when there are none. Warnings are not included.
+func (r *ValidationReport) Err() error {
+	var errs []error
+	for i := range r.Issues {
+		if r.Issues[i].Severity == SeverityError {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrValidation, r.Issues[i].Message))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Counts returns number of issues per code.
+func (r *ValidationReport) Counts() map[ValidationCode]int {
+	out := make(map[ValidationCode]int)
+	for i := range r.Issues {
+		out[r.Issues[i].Code]++
+	}
+
+	return out
+}
+
+// validationReportJSON is the serialized form of ValidationReport.
+type validationReportJSON struct {
+	Counts   map[ValidationCode]int `json:"counts"`
+	Issues   []ValidationIssue      `json:"issues"`
+	Errors   int                    `json:"errors"`
+	Warnings int                    `json:"warnings"`
+	Valid    bool                   `json:"valid"`
+}
+
+// MarshalJSON encodes report with summary: validity, error and warning
+// totals and per-code counts next to the issue list, which is never null.
+func (r ValidationReport) MarshalJSON() ([]byte, error) {
+	out := validationReportJSON{
+		Counts:   r.Counts(),
+		Issues:   r.Issues,
+		Errors:   len(r.Errors()),
+		Warnings: len(r.Warnings()),
+	}
+	out.Valid = out.Errors == 0
+	if out.Issues == nil {
+		out.Issues = []ValidationIssue{}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes report produced by MarshalJSON; summary fields are
+// derived from issues and ignored.
+func (r *ValidationReport) UnmarshalJSON(data []byte) error {
+	var in validationReportJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	r.Issues = in.Issues
+	return nil
+}
+
+// bySeverity returns issues of given severity.
+func (r *ValidationReport) bySeverity(s Severity) []ValidationIssue {
+	var out []ValidationIssue
+	for i := range r.Issues {
+		if r.Issues[i].Severity == s {
+			out = append(out, r.Issues[i])
+		}
+	}
+
+	return out
+}
+
+// validator collects issues into report.
+type validator struct {
+	profile *EngineProfile // profile is resolved opts.Profile, nil when unset or unknown.
+	report  ValidationReport
+	opts    ValidateOptions
+}
+
+// newValidator returns validator for opts with resolved engine profile.
+func newValidator(opts ValidateOptions) *validator {
+	v := &validator{opts: opts}
+	if p, ok := engineProfiles[strings.ToLower(strings.TrimSpace(opts.Profile))]; ok {
+		v.profile = &p
+	}
+
+	return v
+}
+
+// limits returns structural limits (entries, path length, mips) of
+// ValidateOptions resolved against profile; -1 means unlimited.
+func (v *validator) limits() (entries, pathLength, mips int) {
+	var p EngineProfile
+	if v.profile != nil {
+		p = *v.profile
+	}
+
+	return limit(v.opts.MaxEntries, p.MaxEntries),
+		limit(v.opts.MaxPathLength, p.MaxPathLength),
+		limit(v.opts.MaxMipMaps, p.MaxMipMaps)
+}
+
+// add records issue of entry (-1 for file-level) at field.
+// Checks resolved to SeverityOff are dropped.
+func (v *validator) add(code ValidationCode, sev Severity, entry int, path, field, format string, args ...any) {
+	if sev = v.opts.severity(code, sev); sev == SeverityOff {
+		return
+	}
+
+	v.report.Issues = append(v.report.Issues, ValidationIssue{
+		Code:     code,
+		Severity: sev,
+		Entry:    entry,
+		Path:     path,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+		Fixable:  fixableCodes[code],
+	})
+}
+
 // ValidateFile validates file-level and entry-level invariants.
+// It returns error-severity issues of ValidateFileReport joined together;
+// checks added with the report are warnings or opt-in, so the baseline set
+// of errors is unchanged.
 func ValidateFile(f *File) error {
+	return ValidateFileReport(f).Err()
+}
+
+// ValidateFileReport validates file-level and entry-level invariants with
+// default options and returns all findings; it never returns nil.
+func ValidateFileReport(f *File) *ValidationReport {
+	return ValidateFileWithOptions(f, ValidateOptions{})
+}
+
+// ValidateFileWithOptions validates f with per-check severities from opts
+// and returns all findings; it never returns nil.
+func ValidateFileWithOptions(f *File, opts ValidateOptions) *ValidationReport {
+	v := newValidator(opts)
+	v.file(f)
+	return &v.report
+}
+
+// ValidateEntry validates one texture entry invariants with default options
+// and returns its error-severity issues joined together.
+func ValidateEntry(entry *TextureEntry, entryIndex int) error {
+	var v validator
+	v.entry(entry, entryIndex)
+	return v.report.Err()
+}
+
+// file validates file header and every entry.
+func (v *validator) file(f *File) {
 	if f == nil {
-		return fmt.Errorf("%w: file is nil", ErrValidation)
+		v.add(CodeNilFile, SeverityError, -1, "", "", "file is nil")
+		return
 	}
 
-	var issues []error
 	if f.Magic != "" && f.Magic != FileMagic {
-		issues = append(issues, fmt.Errorf("%w: magic=%q want=%q", ErrValidation, f.Magic, FileMagic))
+		v.add(CodeInvalidMagic, SeverityError, -1, "", "magic", "magic=%q want=%q", f.Magic, FileMagic)
 	}
 
 	if f.Version != 0 && f.Version != SupportedVersion {
-		issues = append(issues, fmt.Errorf("%w: version=%d want=%d", ErrValidation, f.Version, SupportedVersion))
+		v.add(CodeUnsupportedVersion, SeverityError, -1, "", "version", "version=%d want=%d", f.Version, SupportedVersion)
 	}
 
 	if len(f.Textures) > math.MaxUint32 {
-		issues = append(issues, fmt.Errorf("%w: texture count out of range: %d", ErrValidation, len(f.Textures)))
+		v.add(CodeTooManyTextures, SeverityError, -1, "", "textures", "texture count out of range: %d", len(f.Textures))
 	}
 
-	for i := range f.Textures {
-		if err := ValidateEntry(&f.Textures[i], i); err != nil {
-			issues = append(issues, err)
-		}
+	if n, _, _ := v.limits(); n >= 0 && len(f.Textures) > n {
+		v.add(CodeTooManyEntries, SeverityError, -1, "", "textures", "texture count %d exceeds limit %d", len(f.Textures), n)
 	}
 
-	if len(issues) == 0 {
-		return nil
+	for i := range f.Textures {
+		v.entry(&f.Textures[i], i)
 	}
 
-	return errors.Join(issues...)
+	v.duplicates(f)
+	v.profileFile(f)
 }
 
-// ValidateEntry validates one texture entry invariants.
-func ValidateEntry(entry *TextureEntry, entryIndex int) error {
-	if entry == nil {
-		return fmt.Errorf("%w: texture[%d] is nil", ErrValidation, entryIndex)
+// duplicates reports one issue per group of entries sharing a path after
+// default normalization, attributed to the second entry of the group.
+func (v *validator) duplicates(f *File) {
+	groups := make(map[string][]int, len(f.Textures))
+	var order []string
+	for i := range f.Textures {
+		key := indexKey(f.Textures[i].PAAFile, IndexOptions{})
+		if key == "" {
+			continue
+		}
+
+		if len(groups[key]) == 1 {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
 	}
 
-	var issues []error
+	for _, key := range order {
+		idx := groups[key]
+		v.add(CodeDuplicatePath, SeverityWarning, idx[1], f.Textures[idx[1]].PAAFile, "paa_file",
+			"textures %v share path %q", idx, f.Textures[idx[0]].PAAFile)
+	}
+}
+
+// entry validates one texture entry invariants.
+func (v *validator) entry(entry *TextureEntry, entryIndex int) {
 	prefix := fmt.Sprintf("texture[%d]", entryIndex)
+	if entry == nil {
+		v.add(CodeNilEntry, SeverityError, entryIndex, "", "", "%s is nil", prefix)
+		return
+	}
 
+	path := entry.PAAFile
 	if entry.PAAFile == "" {
-		issues = append(issues, fmt.Errorf("%w: %s.paa_file is empty", ErrValidation, prefix))
+		v.add(CodeEmptyPath, SeverityError, entryIndex, path, "paa_file", "%s.paa_file is empty", prefix)
+	} else {
+		v.pathHygiene(path, entryIndex, prefix)
+		if _, n, _ := v.limits(); n >= 0 && len(path) > n {
+			v.add(CodePathLength, SeverityError, entryIndex, path, "paa_file",
+				"%s.paa_file length %d exceeds limit %d", prefix, len(path), n)
+		}
+		if guess, ok := GuessSuffixTypeFromPath(path); ok && guess != entry.PaxSuffixType {
+			v.add(CodeSuffixMismatch, SeverityWarning, entryIndex, path, "pax_suffix_type",
+				"%s.pax_suffix_type=%s but file name suggests %s", prefix, entry.PaxSuffixType, guess)
+		}
 	}
 
 	if entry.PaxFormat > math.MaxUint8 {
-		issues = append(issues, fmt.Errorf("%w: %s.pax_format out of uint8 range: %d", ErrValidation, prefix, entry.PaxFormat))
+		v.add(CodePaxFormatRange, SeverityError, entryIndex, path, "pax_format",
+			"%s.pax_format out of uint8 range: %d", prefix, entry.PaxFormat)
+	}
+
+	if entry.PaxFormat == PaxFormatP8 && len(entry.MipMaps) > 0 {
+		if entry.ColorPaletteCount == 0 {
+			v.add(CodePalette, SeverityError, entryIndex, path, "color_palette_count",
+				"%s.color_palette_count=0 for palettized texture", prefix)
+		}
+
+		if entry.PalettePtr == 0 {
+			v.add(CodePalette, SeverityError, entryIndex, path, "palette_ptr",
+				"%s.palette_ptr=0 for palettized texture", prefix)
+		}
+	} else if entry.PalettePtr != 0 {
+		v.add(CodePalette, SeverityWarning, entryIndex, path, "palette_ptr",
+			"%s.palette_ptr=%d for non-palettized texture", prefix, entry.PalettePtr)
+	}
+
+	if _, _, n := v.limits(); n >= 0 && len(entry.MipMaps) > n {
+		v.add(CodeTooManyMipMaps, SeverityError, entryIndex, path, "mipmaps",
+			"%s has %d mipmaps, limit %d", prefix, len(entry.MipMaps), n)
 	}
 
 	mipLen, convErr := intToU32Strict(len(entry.MipMaps))
 	if convErr != nil {
-		issues = append(issues, fmt.Errorf("%w: %s.mipmaps length out of range: %d", ErrValidation, prefix, len(entry.MipMaps)))
+		v.add(CodeMipCount, SeverityError, entryIndex, path, "mipmaps",
+			"%s.mipmaps length out of range: %d", prefix, len(entry.MipMaps))
 		mipLen = 0
 	}
 	if entry.MipMapCount != mipLen {
-		issues = append(issues, fmt.Errorf("%w: %s.mipmap_count=%d len(mipmaps)=%d", ErrValidation, prefix, entry.MipMapCount, mipLen))
+		v.add(CodeMipCount, SeverityError, entryIndex, path, "mipmap_count",
+			"%s.mipmap_count=%d len(mipmaps)=%d", prefix, entry.MipMapCount, mipLen)
 	}
 
 	if entry.MipMapCountCopy != mipLen {
-		issues = append(issues, fmt.Errorf("%w: %s.mipmap_count_copy=%d len(mipmaps)=%d", ErrValidation, prefix, entry.MipMapCountCopy, mipLen))
+		v.add(CodeMipCount, SeverityError, entryIndex, path, "mipmap_count_copy",
+			"%s.mipmap_count_copy=%d len(mipmaps)=%d", prefix, entry.MipMapCountCopy, mipLen)
 	}
 
 	if entry.MipMapCount != entry.MipMapCountCopy {
-		issues = append(issues, fmt.Errorf("%w: %s.mipmap_count=%d != mipmap_count_copy=%d", ErrValidation, prefix, entry.MipMapCount, entry.MipMapCountCopy))
+		v.add(CodeMipCount, SeverityError, entryIndex, path, "mipmap_count",
+			"%s.mipmap_count=%d != mipmap_count_copy=%d", prefix, entry.MipMapCount, entry.MipMapCountCopy)
 	}
 
 	var prevOffset uint32
 	for i := range entry.MipMaps {
 		m := entry.MipMaps[i]
-		mp := fmt.Sprintf("%s.mipmaps[%d]", prefix, i)
+		field := fmt.Sprintf("mipmaps[%d]", i)
+		mp := prefix + "." + field
 
 		if m.Width == 0 || m.Height == 0 {
-			issues = append(issues, fmt.Errorf("%w: %s has zero dimension (%d x %d)", ErrValidation, mp, m.Width, m.Height))
+			v.add(CodeZeroDimension, SeverityError, entryIndex, path, field,
+				"%s has zero dimension (%d x %d)", mp, m.Width, m.Height)
 		}
 
 		if m.AlwaysZero != 0 {
-			issues = append(issues, fmt.Errorf("%w: %s.always_zero=%d want=0", ErrValidation, mp, m.AlwaysZero))
+			v.add(CodeAlwaysZero, SeverityError, entryIndex, path, field+".always_zero",
+				"%s.always_zero=%d want=0", mp, m.AlwaysZero)
 		}
 
 		if m.AlwaysThree != 3 {
-			issues = append(issues, fmt.Errorf("%w: %s.always_three=%d want=3", ErrValidation, mp, m.AlwaysThree))
+			v.add(CodeAlwaysThree, SeverityError, entryIndex, path, field+".always_three",
+				"%s.always_three=%d want=3", mp, m.AlwaysThree)
 		}
 
-		if entry.PaxFormat <= math.MaxUint8 && uint32(m.PaxFormat) != entry.PaxFormat {
-			issues = append(issues, fmt.Errorf("%w: %s.pax_format=%d entry.pax_format=%d", ErrValidation, mp, m.PaxFormat, entry.PaxFormat))
+		if entry.PaxFormat <= math.MaxUint8 && PaxFormat(m.PaxFormat) != entry.PaxFormat {
+			v.add(CodeMipFormat, SeverityError, entryIndex, path, field+".pax_format",
+				"%s.pax_format=%s entry.pax_format=%s", mp, PaxFormat(m.PaxFormat), entry.PaxFormat)
 		}
 
 		if i > 0 && m.DataOffset < prevOffset {
-			issues = append(issues, fmt.Errorf("%w: %s.data_offset=%d is less than previous=%d", ErrValidation, mp, m.DataOffset, prevOffset))
+			v.add(CodeOffsetOrder, SeverityError, entryIndex, path, field+".data_offset",
+				"%s.data_offset=%d is less than previous=%d", mp, m.DataOffset, prevOffset)
 		}
 
 		prevOffset = m.DataOffset
 	}
 
-	if len(issues) == 0 {
-		return nil
+	v.mipOrder(entry, entryIndex, prefix)
+	v.mipChain(entry, entryIndex, prefix)
+	v.blockAlignment(entry, entryIndex, prefix)
+	v.offsetBounds(entry, entryIndex, prefix)
+	v.colorCoherence(entry, entryIndex, prefix)
+	v.alphaFlags(entry, entryIndex, prefix)
+}
+
+// alphaFlags reports is_alpha_non_opaque inconsistent with is_alpha and
+// average alpha. is_transparent is not tied to is_alpha: official files carry
+// GALF bit 2 alone.
+func (v *validator) alphaFlags(entry *TextureEntry, entryIndex int, prefix string) {
+	if want := entry.IsAlpha && entry.AverageColor[3] < 0x80; entry.IsAlphaNonOpaque != want {
+		v.add(CodeAlphaFlags, SeverityWarning, entryIndex, entry.PAAFile, "is_alpha_non_opaque",
+			"%s.is_alpha_non_opaque=%t want %t (is_alpha=%t, average alpha=%d)",
+			prefix, entry.IsAlphaNonOpaque, want, entry.IsAlpha, entry.AverageColor[3])
+	}
+}
+
+// DefaultColorEpsilon is the allowed difference between AverageColorF
+// components and AverageColor bytes scaled to [0,1] when
+// ValidateOptions.ColorEpsilon is zero; one byte step.
+const DefaultColorEpsilon = 1.0 / 255
+
+// colorCoherence reports AverageColorF diverging from AverageColor, taking
+// B,G,R,A byte order of the latter into account.
+func (v *validator) colorCoherence(entry *TextureEntry, entryIndex int, prefix string) {
+	eps := v.opts.ColorEpsilon
+	if eps <= 0 {
+		eps = DefaultColorEpsilon
+	}
+
+	want := *entry
+	want.syncAverageColorF()
+	for i, got := range entry.AverageColorF {
+		if d := got - want.AverageColorF[i]; math.IsNaN(float64(d)) || d > eps || -d > eps {
+			v.add(CodeColorMismatch, SeverityWarning, entryIndex, entry.PAAFile, "average_color_f",
+				"%s.average_color_f=%v does not match average_color=%v (want %v)",
+				prefix, entry.AverageColorF, entry.AverageColor, want.AverageColorF)
+			return
+		}
+	}
+}
+
+// minMipRecordSize is the smallest mip record in source file: 2+2 byte
+// dimensions and 3 byte payload size.
+const minMipRecordSize = 7
+
+// offsetBounds reports mips whose record starting at DataOffset does not fit
+// into PaxFileSize. Entries with unknown (zero) size are skipped.
+func (v *validator) offsetBounds(entry *TextureEntry, entryIndex int, prefix string) {
+	if entry.PaxFileSize == 0 {
+		return
+	}
+
+	for i := range entry.MipMaps {
+		off := uint64(entry.MipMaps[i].DataOffset)
+		if off+minMipRecordSize > uint64(entry.PaxFileSize) {
+			v.add(CodeOffsetBounds, SeverityError, entryIndex, entry.PAAFile, fmt.Sprintf("mipmaps[%d].data_offset", i),
+				"%s.mipmaps[%d].data_offset=%d overruns pax_file_size=%d", prefix, i, off, entry.PaxFileSize)
+		}
+	}
+}
+
+// blockAlignment reports the first mip of DXT entry whose dimension of 4 or
+// more is not a multiple of 4x4 block size; smaller tail mips are padded by
+// the format and allowed.
+func (v *validator) blockAlignment(entry *TextureEntry, entryIndex int, prefix string) {
+	if entry.PaxFormat < PaxFormatDXT1 || entry.PaxFormat > PaxFormatDXT5 {
+		return
+	}
+
+	for i := range entry.MipMaps {
+		w, h := mipDimensions(entry.MipMaps[i])
+		if (w >= 4 && w%4 != 0) || (h >= 4 && h%4 != 0) {
+			v.add(CodeBlockAlignment, SeverityWarning, entryIndex, entry.PAAFile, fmt.Sprintf("mipmaps[%d]", i),
+				"%s.mipmaps[%d] %dx%d is not aligned to %s 4x4 blocks", prefix, i, w, h, entry.PaxFormat)
+			return
+		}
+	}
+}
+
+// pathHygiene reports stored path forms the engine mishandles at runtime.
+func (v *validator) pathHygiene(path string, entryIndex int, prefix string) {
+	if checkStoredPath(path) != nil {
+		v.add(CodePathEscape, SeverityError, entryIndex, path, "paa_file",
+			"%s.paa_file=%q is absolute or has .. segments", prefix, path)
+	}
+
+	if strings.Contains(path, "/") {
+		v.add(CodePathSeparator, SeverityWarning, entryIndex, path, "paa_file",
+			"%s.paa_file=%q uses forward slashes", prefix, path)
+	}
+
+	if strings.ToLower(path) != path {
+		v.add(CodePathCase, SeverityWarning, entryIndex, path, "paa_file",
+			"%s.paa_file=%q is not lowercase", prefix, path)
+	}
+
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".paa" && ext != ".pac" {
+		v.add(CodePathExtension, SeverityError, entryIndex, path, "paa_file",
+			"%s.paa_file=%q does not end in .paa or .pac", prefix, path)
+	}
+}
+
+// mipOrder reports the first mip which is not smaller than the previous one:
+// neither dimension may grow and at least one must shrink, so chains clamped
+// at 1 pass while shuffled or repeated descriptors do not.
+func (v *validator) mipOrder(entry *TextureEntry, entryIndex int, prefix string) {
+	for i := 1; i < len(entry.MipMaps); i++ {
+		pw, ph := mipDimensions(entry.MipMaps[i-1])
+		cw, ch := mipDimensions(entry.MipMaps[i])
+		if cw > pw || ch > ph || (cw == pw && ch == ph) {
+			v.add(CodeMipOrder, SeverityError, entryIndex, entry.PAAFile, fmt.Sprintf("mipmaps[%d]", i),
+				"%s.mipmaps[%d] %dx%d is not smaller than previous %dx%d", prefix, i, cw, ch, pw, ph)
+			return
+		}
+	}
+}
+
+// mipChain reports non power of two top mip and the first level which is not
+// half (clamped at 1) of the previous one.
+func (v *validator) mipChain(entry *TextureEntry, entryIndex int, prefix string) {
+	if len(entry.MipMaps) == 0 {
+		return
+	}
+
+	path := entry.PAAFile
+	w, h := mipDimensions(entry.MipMaps[0])
+	if w != 0 && h != 0 && (!isPowerOfTwo(w) || !isPowerOfTwo(h)) {
+		v.add(CodeNonPowerOfTwo, SeverityWarning, entryIndex, path, "mipmaps[0]",
+			"%s top mip %dx%d is not power of two", prefix, w, h)
 	}
 
-	return errors.Join(issues...)
+	for i := 1; i < len(entry.MipMaps); i++ {
+		pw, ph := mipDimensions(entry.MipMaps[i-1])
+		cw, ch := mipDimensions(entry.MipMaps[i])
+		if cw != max(pw/2, 1) || ch != max(ph/2, 1) {
+			v.add(CodeBrokenMipChain, SeverityWarning, entryIndex, path, fmt.Sprintf("mipmaps[%d]", i),
+				"%s mip chain broken at level %d: %dx%d after %dx%d", prefix, i, cw, ch, pw, ph)
+			return
+		}
+	}
 }
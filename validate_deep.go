@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// Source verification checks reported by ValidateDeepContext.
+const (
+	// CodeSourceMissing means entry source file does not exist.
+	CodeSourceMissing ValidationCode = "source_missing"
+	// CodeSourceError means entry source file could not be resolved, opened or scanned.
+	CodeSourceError ValidationCode = "source_error"
+	// CodeStaleEntry means entry fields no longer match its source file.
+	CodeStaleEntry ValidationCode = "stale_entry"
+)
+
+// DeepValidateOptions configures ValidateDeepContext.
+type DeepValidateOptions struct {
+	// BaseDir is the directory entry paths are resolved under.
+	BaseDir string `json:"base_dir,omitempty" yaml:"base_dir,omitempty"`
+	// Verify controls source comparison.
+	Verify VerifyOptions `json:"verify" yaml:"verify"`
+	// Validate controls structural checks and severities of all checks.
+	Validate ValidateOptions `json:"validate" yaml:"validate"`
+	// Workers controls number of sources verified in parallel, with the same
+	// meaning as BuildOptions.Workers; WorkersAdaptive acts as WorkersAuto.
+	Workers int `json:"workers,omitempty" yaml:"workers,omitempty"`
+}
+
+// ValidateDeepContext runs structural validation of f and then verifies
+// every entry against its source under opts.BaseDir with a worker pool.
+// Source problems are reported as source_missing, source_error and
+// stale_entry issues after structural ones, in entry order.
+//
+// When ctx is done workers stop picking new entries and the partial report
+// is returned together with ctx.Err().
+func ValidateDeepContext(ctx context.Context, f *File, opts DeepValidateOptions) (*ValidationReport, error) {
+	v := newValidator(opts.Validate)
+	v.file(f)
+	if f == nil {
+		return &v.report, nil
+	}
+
+	workers := opts.Workers
+	if workers == WorkersAdaptive {
+		workers = WorkersAuto
+	}
+	workers = resolveBuildWorkers(workers, len(f.Textures))
+
+	results := make([]*VerifyIssue, len(f.Textures))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			b := NewBuilder(opts.Verify.Build)
+			for i := range jobs {
+				if issue, ok := verifySource(b, &f.Textures[i], opts.BaseDir, opts.Verify); !ok {
+					issue.Entry = i
+					results[i] = &issue
+				}
+			}
+		})
+	}
+
+feed:
+	for i := range f.Textures {
+		// Checked first: select picks randomly when both cases are ready.
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, issue := range results {
+		if issue != nil {
+			v.sourceIssue(issue)
+		}
+	}
+
+	return &v.report, ctx.Err()
+}
+
+// sourceIssue records failed source verification of one entry.
+func (v *validator) sourceIssue(issue *VerifyIssue) {
+	prefix := fmt.Sprintf("texture[%d]", issue.Entry)
+	switch {
+	case errors.Is(issue.Err, fs.ErrNotExist):
+		v.add(CodeSourceMissing, SeverityError, issue.Entry, issue.Path, "paa_file",
+			"%s source %s does not exist", prefix, issue.Source)
+	case issue.Err != nil:
+		v.add(CodeSourceError, SeverityError, issue.Entry, issue.Path, "paa_file",
+			"%s source: %s", prefix, issue.Error)
+	default:
+		v.add(CodeStaleEntry, SeverityError, issue.Entry, issue.Path, "",
+			"%s is stale: %s", prefix, strings.Join(issue.Diffs, "; "))
+	}
+}
@@ -0,0 +1,65 @@
+package texheaders
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateDeepContext(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a_co.paa", "b_co.paa", "c_co.paa"} {
+		copyFixture(t, "test_co.paa", dir, name)
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err := b.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	opts := DeepValidateOptions{BaseDir: dir, Workers: 2}
+	rep, err := ValidateDeepContext(context.Background(), f, opts)
+	if err != nil || len(rep.Issues) != 0 {
+		t.Fatalf("ValidateDeepContext(fresh) = %+v, %v", rep, err)
+	}
+
+	f.Textures[0].PaxFileSize++
+	if err = os.Remove(filepath.Join(dir, "b_co.paa")); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, "c_co.paa"), []byte("junk"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	rep, err = ValidateDeepContext(context.Background(), f, opts)
+	if err != nil {
+		t.Fatalf("ValidateDeepContext() error: %v", err)
+	}
+
+	want := []ValidationCode{CodeStaleEntry, CodeSourceMissing, CodeSourceError}
+	if len(rep.Issues) != len(want) {
+		t.Fatalf("ValidateDeepContext() = %+v", rep.Issues)
+	}
+
+	for i, code := range want {
+		if rep.Issues[i].Code != code || rep.Issues[i].Entry != i || rep.Issues[i].Severity != SeverityError {
+			t.Fatalf("issue[%d] = %+v, want %s", i, rep.Issues[i], code)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rep, err = ValidateDeepContext(ctx, f, opts)
+	if !errors.Is(err, context.Canceled) || rep == nil || len(rep.Issues) != 0 {
+		t.Fatalf("ValidateDeepContext(canceled) = %+v, %v", rep, err)
+	}
+}
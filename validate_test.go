@@ -1,7 +1,11 @@
 package texheaders
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -75,3 +79,528 @@ func TestValidateEntry_InvalidMipConstants(t *testing.T) {
 		t.Fatalf("ValidateEntry(invalid mip constants) error = %v, want %v", err, ErrValidation)
 	}
 }
+
+func TestValidateFileReport(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	if rep := ValidateFileReport(f); len(rep.Issues) != 0 || rep.Err() != nil {
+		t.Fatalf("ValidateFileReport(valid fixture) = %+v", rep.Issues)
+	}
+
+	f.Version = 7
+	f.Textures[1].MipMaps[0].AlwaysZero = 1
+	rep := ValidateFileReport(f)
+	if !rep.HasErrors() || len(rep.Errors()) != 2 || len(rep.Warnings()) != 0 {
+		t.Fatalf("ValidateFileReport() = %+v", rep.Issues)
+	}
+
+	file, entry := rep.Issues[0], rep.Issues[1]
+	if file.Code != CodeUnsupportedVersion || file.Entry != -1 || file.Field != "version" {
+		t.Fatalf("file issue = %+v", file)
+	}
+
+	if entry.Code != CodeAlwaysZero || entry.Severity != SeverityError || entry.Entry != 1 ||
+		entry.Field != "mipmaps[0].always_zero" || entry.Path != f.Textures[1].PAAFile {
+		t.Fatalf("entry issue = %+v", entry)
+	}
+
+	if err = rep.Err(); !errors.Is(err, ErrValidation) || err.Error() != ValidateFile(f).Error() {
+		t.Fatalf("Err() = %v, ValidateFile() = %v", err, ValidateFile(f))
+	}
+
+	if rep = ValidateFileReport(nil); len(rep.Issues) != 1 || rep.Issues[0].Code != CodeNilFile {
+		t.Fatalf("ValidateFileReport(nil) = %+v", rep.Issues)
+	}
+}
+
+func TestValidateFile_Baseline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		edit func(f *File)
+		name string
+		code ValidationCode
+	}{
+		{name: "path case", code: CodePathCase, edit: func(f *File) {
+			f.Textures[0].PAAFile = strings.ToUpper(f.Textures[0].PAAFile)
+		}},
+		{name: "path separator", code: CodePathSeparator, edit: func(f *File) {
+			f.Textures[0].PAAFile = "data/" + f.Textures[0].PAAFile
+		}},
+		{name: "duplicate path", code: CodeDuplicatePath, edit: func(f *File) {
+			f.Textures = append(f.Textures, f.Textures[0])
+		}},
+		{name: "offset bounds", code: CodeOffsetBounds, edit: func(f *File) {
+			f.Textures[0].PaxFileSize = f.Textures[0].MipMaps[0].DataOffset
+		}},
+		{name: "mip order", code: CodeMipOrder, edit: func(f *File) {
+			m := f.Textures[0].MipMaps
+			m[0], m[1] = m[1], m[0]
+			m[0].DataOffset, m[1].DataOffset = m[1].DataOffset, m[0].DataOffset
+		}},
+		{name: "path escape", code: CodePathEscape, edit: func(f *File) {
+			f.Textures[0].PAAFile = `..\` + f.Textures[0].PAAFile
+		}},
+		{name: "path extension", code: CodePathExtension, edit: func(f *File) {
+			f.Textures[0].PAAFile += ".bak"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ReadFile("testdata/texHeaders.bin")
+			if err != nil {
+				t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+			}
+
+			tt.edit(f)
+			if err = ValidateFile(f); err != nil {
+				t.Fatalf("ValidateFile() error: %v", err)
+			}
+
+			if err = ValidateEntry(&f.Textures[0], 0); err != nil {
+				t.Fatalf("ValidateEntry() error: %v", err)
+			}
+
+			opts := ValidateOptions{Checks: map[ValidationCode]Severity{tt.code: SeverityError}}
+			if n := ValidateFileWithOptions(f, opts).Counts()[tt.code]; n == 0 {
+				t.Fatalf("report with %s enabled has no such issue", tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateFileWithOptions(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	f.Textures[0].MipMaps[0].AlwaysZero = 1
+	f.Textures[0].MipMapCountCopy++
+
+	tests := []struct {
+		name     string
+		opts     ValidateOptions
+		errors   int
+		warnings int
+	}{
+		{name: "default", errors: 3},
+		{name: "strict", opts: ValidateOptions{Strictness: StrictnessStrict}, errors: 3},
+		{name: "lenient", opts: ValidateOptions{Strictness: StrictnessLenient}, errors: 2, warnings: 1},
+		{name: "off", opts: ValidateOptions{Checks: map[ValidationCode]Severity{CodeAlwaysZero: SeverityOff}}, errors: 2},
+		{
+			name: "override preset",
+			opts: ValidateOptions{Strictness: StrictnessLenient, Checks: map[ValidationCode]Severity{
+				CodeAlwaysZero: SeverityError,
+				CodeMipCount:   SeverityWarning,
+			}},
+			errors:   1,
+			warnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := ValidateFileWithOptions(f, tt.opts)
+			if len(rep.Errors()) != tt.errors || len(rep.Warnings()) != tt.warnings {
+				t.Fatalf("ValidateFileWithOptions() = %+v", rep.Issues)
+			}
+		})
+	}
+}
+
+func TestValidateFile_DuplicatePaths(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	if len(f.Textures) < 2 {
+		t.Fatal("fixture must hold at least two textures")
+	}
+
+	first, second := f.Textures[0], f.Textures[1]
+	first.PAAFile = strings.ToUpper(strings.ReplaceAll(first.PAAFile, `\`, "/"))
+	second.PAAFile = `.\` + second.PAAFile
+	f.Textures = append(f.Textures, first, second, first)
+
+	rep := ValidateFileReport(f)
+	var dups []ValidationIssue
+	for _, is := range rep.Warnings() {
+		if is.Code == CodeDuplicatePath {
+			dups = append(dups, is)
+		}
+	}
+
+	if len(dups) != 2 || rep.HasErrors() {
+		t.Fatalf("ValidateFileReport() = %+v", rep.Issues)
+	}
+
+	n := len(f.Textures)
+	if dups[0].Code != CodeDuplicatePath || dups[0].Entry != n-3 ||
+		!strings.Contains(dups[0].Message, fmt.Sprintf("[0 %d %d]", n-3, n-1)) {
+		t.Fatalf("first group = %+v", dups[0])
+	}
+
+	if dups[1].Entry != n-2 || !strings.Contains(dups[1].Message, fmt.Sprintf("[1 %d]", n-2)) {
+		t.Fatalf("second group = %+v", dups[1])
+	}
+}
+
+func TestValidateEntry_MipChain(t *testing.T) {
+	t.Parallel()
+
+	mips := func(dims ...uint16) []MipMap {
+		out := make([]MipMap, 0, len(dims)/2)
+		for i := 0; i < len(dims); i += 2 {
+			out = append(out, MipMap{Width: dims[i], Height: dims[i+1], AlwaysThree: 3, PaxFormat: 6,
+				DataOffset: uint32(i)})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		mips  []MipMap
+		codes []ValidationCode
+	}{
+		{name: "ok", mips: mips(8, 2, 4, 1, 2, 1, 1, 1)},
+		{name: "compressed flag", mips: mips(8|mipCompressedFlag, 8, 4, 4)},
+		{name: "non power of two", mips: mips(6, 4, 3, 2), codes: []ValidationCode{CodeNonPowerOfTwo, CodeBlockAlignment}},
+		{name: "broken", mips: mips(8, 8, 2, 2, 1, 1), codes: []ValidationCode{CodeBrokenMipChain}},
+		{name: "repeated", mips: mips(8, 8, 4, 4, 4, 4), codes: []ValidationCode{CodeMipOrder, CodeBrokenMipChain}},
+		{name: "growing", mips: mips(4, 4, 8, 8), codes: []ValidationCode{CodeMipOrder, CodeBrokenMipChain}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &File{Textures: []TextureEntry{{
+				PAAFile:           "a_co.paa",
+				PaxFormat:         PaxFormatDXT1,
+				ColorPaletteCount: 1,
+				MipMaps:           tt.mips,
+				MipMapCount:       uint32(len(tt.mips)),
+				MipMapCountCopy:   uint32(len(tt.mips)),
+			}}}
+
+			rep := ValidateFileWithOptions(f, ValidateOptions{Checks: map[ValidationCode]Severity{CodeMipOrder: SeverityError}})
+			if len(rep.Issues) != len(tt.codes) {
+				t.Fatalf("ValidateFileWithOptions() = %+v", rep.Issues)
+			}
+
+			for i, code := range tt.codes {
+				if rep.Issues[i].Code != code {
+					t.Fatalf("issue[%d] = %+v, want %s", i, rep.Issues[i], code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEntry_BlockAlignment(t *testing.T) {
+	t.Parallel()
+
+	entry := func(format PaxFormat, dims ...uint16) *File {
+		e := TextureEntry{PAAFile: "a_co.paa", PaxFormat: format, ColorPaletteCount: 1}
+		for i := 0; i < len(dims); i += 2 {
+			e.MipMaps = append(e.MipMaps, MipMap{Width: dims[i], Height: dims[i+1], AlwaysThree: 3,
+				PaxFormat: uint8(format)})
+		}
+		e.MipMapCount = uint32(len(e.MipMaps))
+		e.MipMapCountCopy = e.MipMapCount
+		return &File{Textures: []TextureEntry{e}}
+	}
+
+	skip := ValidateOptions{Checks: map[ValidationCode]Severity{
+		CodeNonPowerOfTwo:  SeverityOff,
+		CodeBrokenMipChain: SeverityOff,
+	}}
+
+	if rep := ValidateFileWithOptions(entry(PaxFormatDXT1, 8, 8, 4, 4, 2, 2, 1, 1), skip); len(rep.Issues) != 0 {
+		t.Fatalf("aligned DXT1 = %+v", rep.Issues)
+	}
+
+	if rep := ValidateFileWithOptions(entry(PaxFormatARGB8, 6, 6), skip); len(rep.Issues) != 0 {
+		t.Fatalf("unaligned ARGB8 = %+v", rep.Issues)
+	}
+
+	rep := ValidateFileWithOptions(entry(PaxFormatDXT5, 12, 12, 6, 6, 3, 3), skip)
+	if len(rep.Issues) != 1 || rep.Issues[0].Code != CodeBlockAlignment || rep.Issues[0].Field != "mipmaps[1]" {
+		t.Fatalf("unaligned DXT5 = %+v", rep.Issues)
+	}
+}
+
+func TestValidateEntry_OffsetBounds(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	e := &f.Textures[0]
+	last := len(e.MipMaps) - 1
+	e.PaxFileSize = e.MipMaps[last].DataOffset + minMipRecordSize - 1
+
+	if rep := ValidateFileReport(f); len(rep.Issues) != 0 {
+		t.Fatalf("ValidateFileReport(truncated, default) = %+v", rep.Issues)
+	}
+
+	opts := ValidateOptions{Checks: map[ValidationCode]Severity{CodeOffsetBounds: SeverityError}}
+	rep := ValidateFileWithOptions(f, opts)
+	if len(rep.Issues) != 1 || rep.Issues[0].Code != CodeOffsetBounds ||
+		rep.Issues[0].Field != fmt.Sprintf("mipmaps[%d].data_offset", last) {
+		t.Fatalf("ValidateFileWithOptions(truncated) = %+v", rep.Issues)
+	}
+
+	e.PaxFileSize = 0
+	if rep = ValidateFileWithOptions(f, opts); len(rep.Issues) != 0 {
+		t.Fatalf("ValidateFileWithOptions(unknown size) = %+v", rep.Issues)
+	}
+}
+
+func TestValidateEntry_PathHygiene(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path  string
+		codes []ValidationCode
+	}{
+		{path: `data\tree_co.paa`},
+		{path: `data\palette.pac`},
+		{path: "data/tree_co.paa", codes: []ValidationCode{CodePathSeparator}},
+		{path: `Data\Tree_CO.paa`, codes: []ValidationCode{CodePathCase}},
+		{path: `c:\data\tree_co.paa`, codes: []ValidationCode{CodePathEscape}},
+		{path: `\data\tree_co.paa`, codes: []ValidationCode{CodePathEscape}},
+		{path: `data\..\tree_co.paa`, codes: []ValidationCode{CodePathEscape}},
+		{path: `data\tree_co.png`, codes: []ValidationCode{CodePathExtension}},
+		{path: "../Tree.tga", codes: []ValidationCode{CodePathEscape, CodePathSeparator, CodePathCase, CodePathExtension}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			f := &File{Textures: []TextureEntry{{PAAFile: tt.path, ColorPaletteCount: 1}}}
+			rep := ValidateFileWithOptions(f, ValidateOptions{Checks: map[ValidationCode]Severity{
+				CodePathEscape:    SeverityError,
+				CodePathExtension: SeverityError,
+			}})
+			if len(rep.Issues) != len(tt.codes) {
+				t.Fatalf("ValidateFileWithOptions() = %+v", rep.Issues)
+			}
+
+			for i, code := range tt.codes {
+				if rep.Issues[i].Code != code || rep.Issues[i].Field != "paa_file" {
+					t.Fatalf("issue[%d] = %+v, want %s", i, rep.Issues[i], code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEntry_SuffixMismatch(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Textures: []TextureEntry{
+		{PAAFile: `data\wall_nohq.paa`, ColorPaletteCount: 1, PaxSuffixType: SuffixDiffuseSRGB},
+		{PAAFile: `data\wall_nohq.paa`, ColorPaletteCount: 1, PaxSuffixType: SuffixNormalMap},
+		{PAAFile: `data\custom.paa`, ColorPaletteCount: 1, PaxSuffixType: SuffixNormalMap},
+	}}
+
+	rep := ValidateFileWithOptions(f, ValidateOptions{Checks: map[ValidationCode]Severity{CodeDuplicatePath: SeverityOff}})
+	if len(rep.Issues) != 1 {
+		t.Fatalf("ValidateFileWithOptions() = %+v", rep.Issues)
+	}
+
+	if is := rep.Issues[0]; is.Code != CodeSuffixMismatch || is.Severity != SeverityWarning || is.Entry != 0 {
+		t.Fatalf("issue = %+v", is)
+	}
+}
+
+func TestValidateEntry_ColorMismatch(t *testing.T) {
+	t.Parallel()
+
+	e := TextureEntry{PAAFile: "a_co.paa", ColorPaletteCount: 1, AverageColor: [4]byte{0x00, 0x80, 0xFF, 0x40}}
+	e.syncAverageColorF()
+	e.AverageColorF[1] += 0.5 / 255
+
+	f := &File{Textures: []TextureEntry{e}}
+	if rep := ValidateFileReport(f); len(rep.Issues) != 0 {
+		t.Fatalf("ValidateFileReport(within epsilon) = %+v", rep.Issues)
+	}
+
+	if rep := ValidateFileWithOptions(f, ValidateOptions{ColorEpsilon: 0.1 / 255}); len(rep.Issues) != 1 ||
+		rep.Issues[0].Code != CodeColorMismatch {
+		t.Fatalf("ValidateFileWithOptions(tight epsilon) = %+v", rep.Issues)
+	}
+
+	// Byte tuple is B,G,R,A: swapped red and blue must be reported.
+	f.Textures[0].AverageColorF = [4]float32{0, 0x80 / 255.0, 1, 0x40 / 255.0}
+	rep := ValidateFileReport(f)
+	if len(rep.Issues) != 1 || rep.Issues[0].Code != CodeColorMismatch || rep.Issues[0].Severity != SeverityWarning {
+		t.Fatalf("ValidateFileReport(swapped) = %+v", rep.Issues)
+	}
+}
+
+func TestValidateEntry_AlphaFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		entry  TextureEntry
+		fields []string
+	}{
+		{name: "opaque", entry: TextureEntry{AverageColor: [4]byte{0, 0, 0, 0x10}}},
+		{name: "non opaque", entry: TextureEntry{IsAlpha: true, IsAlphaNonOpaque: true, AverageColor: [4]byte{0, 0, 0, 0x7F}}},
+		{name: "alpha opaque", entry: TextureEntry{IsAlpha: true, IsTransparent: true, AverageColor: [4]byte{0, 0, 0, 0x80}}},
+		{
+			name:   "missing non opaque",
+			entry:  TextureEntry{IsAlpha: true, AverageColor: [4]byte{0, 0, 0, 0x10}},
+			fields: []string{"is_alpha_non_opaque"},
+		},
+		{
+			name:   "non opaque without alpha",
+			entry:  TextureEntry{IsAlphaNonOpaque: true},
+			fields: []string{"is_alpha_non_opaque"},
+		},
+		{name: "transparent without alpha", entry: TextureEntry{IsTransparent: true, AverageColor: [4]byte{0, 0, 0, 0xFF}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := tt.entry
+			e.PAAFile, e.ColorPaletteCount = "a_ca.paa", 1
+			e.syncAverageColorF()
+
+			rep := ValidateFileReport(&File{Textures: []TextureEntry{e}})
+			if len(rep.Issues) != len(tt.fields) {
+				t.Fatalf("ValidateFileReport() = %+v", rep.Issues)
+			}
+
+			for i, field := range tt.fields {
+				if rep.Issues[i].Code != CodeAlphaFlags || rep.Issues[i].Field != field {
+					t.Fatalf("issue[%d] = %+v, want %s", i, rep.Issues[i], field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationReportJSON(t *testing.T) {
+	t.Parallel()
+
+	f, err := ReadFile("testdata/texHeaders.bin")
+	if err != nil {
+		t.Fatalf("ReadFile(testdata/texHeaders.bin) error: %v", err)
+	}
+
+	raw, err := json.Marshal(ValidateFileReport(f))
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	if want := `{"counts":{},"issues":[],"errors":0,"warnings":0,"valid":true}`; string(raw) != want {
+		t.Fatalf("json.Marshal(valid) = %s, want %s", raw, want)
+	}
+
+	f.Textures[0].MipMaps[0].AlwaysZero = 1
+	f.Textures[0].MipMaps[1].AlwaysZero = 1
+	f.Textures[1].PAAFile = "Test_Detail.paa"
+	rep := ValidateFileReport(f)
+	if raw, err = json.Marshal(rep); err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	var summary struct {
+		Counts   map[string]int `json:"counts"`
+		Errors   int            `json:"errors"`
+		Warnings int            `json:"warnings"`
+		Valid    bool           `json:"valid"`
+	}
+	if err = json.Unmarshal(raw, &summary); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if summary.Valid || summary.Errors != 2 || summary.Warnings != 1 ||
+		summary.Counts["always_zero"] != 2 || summary.Counts["path_case"] != 1 {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	var back ValidationReport
+	if err = json.Unmarshal(raw, &back); err != nil {
+		t.Fatalf("json.Unmarshal(report) error: %v", err)
+	}
+
+	if !slices.Equal(back.Issues, rep.Issues) {
+		t.Fatalf("round trip = %+v, want %+v", back.Issues, rep.Issues)
+	}
+}
+
+func TestValidateFileWithOptions_Limits(t *testing.T) {
+	t.Parallel()
+
+	e := TextureEntry{PAAFile: strings.Repeat("a", 300) + ".paa", ColorPaletteCount: 1}
+	for size := uint16(1 << 14); size > 0; size /= 2 {
+		e.MipMaps = append(e.MipMaps, MipMap{Width: size, Height: size, AlwaysThree: 3, DataOffset: uint32(len(e.MipMaps))})
+	}
+	e.MipMapCount = uint32(len(e.MipMaps))
+	e.MipMapCountCopy = e.MipMapCount
+	e.PaxFormat = PaxFormatARGB8
+	for i := range e.MipMaps {
+		e.MipMaps[i].PaxFormat = uint8(PaxFormatARGB8)
+	}
+
+	f := &File{Textures: []TextureEntry{e, e}}
+	f.Textures[1].PAAFile = "b.paa"
+
+	codes := func(opts ValidateOptions) []ValidationCode {
+		var out []ValidationCode
+		for _, is := range ValidateFileWithOptions(f, opts).Issues {
+			out = append(out, is.Code)
+		}
+		return out
+	}
+
+	if got := codes(ValidateOptions{}); len(got) != 0 {
+		t.Fatalf("default limits = %v, want none", got)
+	}
+
+	if err := ValidateEntry(&f.Textures[0], 0); err != nil {
+		t.Fatalf("ValidateEntry() error: %v", err)
+	}
+
+	profile := ValidateOptions{Profile: ProfileArma3, Checks: map[ValidationCode]Severity{CodeTextureSize: SeverityOff}}
+	want := []ValidationCode{CodePathLength}
+	if got := codes(profile); !slices.Equal(got, want) {
+		t.Fatalf("profile limits = %v, want %v", got, want)
+	}
+
+	profile.MaxEntries, profile.MaxPathLength, profile.MaxMipMaps = -1, -1, -1
+	if got := codes(profile); len(got) != 0 {
+		t.Fatalf("unlimited = %v", got)
+	}
+
+	want = []ValidationCode{CodeTooManyEntries, CodeTooManyMipMaps, CodeTooManyMipMaps}
+	if got := codes(ValidateOptions{MaxEntries: 1, MaxPathLength: 400, MaxMipMaps: 14}); !slices.Equal(got, want) {
+		t.Fatalf("custom limits = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VerifyEntryAgainstPAA re-scans source texture at paaPath and confirms that
+// e still matches it: file size, pax format, mip count, mip dimensions and
+// mip data offsets. Mismatches are reported together wrapped in
+// ErrStaleEntry; open and scan failures are returned as is.
+//
+// Both .paa and .pac sources are accepted; e must not be nil.
+func VerifyEntryAgainstPAA(e *TextureEntry, paaPath string) error {
+	src, err := scanSource(NewBuilder(BuildOptions{}), paaPath, e.PAAFile)
+	if err != nil {
+		return err
+	}
+
+	if diffs := staleFields(e, &src); len(diffs) > 0 {
+		return fmt.Errorf("%w: %s: %s", ErrStaleEntry, e.PAAFile, strings.Join(diffs, "; "))
+	}
+
+	return nil
+}
+
+// VerifyOptions configures VerifyAgainstSources.
+type VerifyOptions struct {
+	// Build should match options the index was built with, so pixel-derived
+	// colors and flags (ComputeMissingColors, RecomputeFlags) are reproduced.
+	Build BuildOptions `json:"build" yaml:"build"`
+	// IgnoreFlags skips alpha flag comparison.
+	IgnoreFlags bool `json:"ignore_flags,omitempty" yaml:"ignore_flags,omitempty"`
+	// IgnoreColors skips average and max color comparison.
+	IgnoreColors bool `json:"ignore_colors,omitempty" yaml:"ignore_colors,omitempty"`
+}
+
+// VerifyIssue describes one entry which failed source verification.
+type VerifyIssue struct {
+	// Err is the open or scan error; nil for stale entries.
+	Err error `json:"-" yaml:"-"`
+	// Path is PAAFile of the entry.
+	Path string `json:"path" yaml:"path"`
+	// Source is the resolved source file path.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Error is Err message.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Diffs lists mismatching fields as "field stored != source".
+	Diffs []string `json:"diffs,omitempty" yaml:"diffs,omitempty"`
+	// Entry is the entry index.
+	Entry int `json:"entry" yaml:"entry"`
+}
+
+// VerifyReport is the result of VerifyAgainstSources.
+type VerifyReport struct {
+	// Issues lists missing, unreadable and stale entries in file order.
+	Issues []VerifyIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+	// Checked is the number of verified entries.
+	Checked int `json:"checked" yaml:"checked"`
+}
+
+// Stale reports whether any entry failed verification.
+func (r *VerifyReport) Stale() bool {
+	return len(r.Issues) > 0
+}
+
+// VerifyAgainstSources resolves every entry path under baseDir, re-scans the
+// source texture and compares file size, pax format, mip table and, unless
+// disabled in opts, alpha flags and colors. Per-entry problems (missing
+// file, path escaping baseDir, corrupt source, stale fields) are collected
+// into the report; the error is returned only for a nil file.
+func VerifyAgainstSources(f *File, baseDir string, opts VerifyOptions) (*VerifyReport, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+
+	b := NewBuilder(opts.Build)
+	rep := &VerifyReport{Checked: len(f.Textures)}
+	for i := range f.Textures {
+		if issue, ok := verifySource(b, &f.Textures[i], baseDir, opts); !ok {
+			issue.Entry = i
+			rep.Issues = append(rep.Issues, issue)
+		}
+	}
+
+	return rep, nil
+}
+
+// verifySource checks one entry; ok is false when issue should be reported.
+func verifySource(b *Builder, e *TextureEntry, baseDir string, opts VerifyOptions) (VerifyIssue, bool) {
+	issue := VerifyIssue{Path: e.PAAFile}
+	if err := checkStoredPath(e.PAAFile); err != nil {
+		issue.Err, issue.Error = err, err.Error()
+		return issue, false
+	}
+
+	issue.Source = filepath.Join(baseDir, filepath.FromSlash(strings.ReplaceAll(e.PAAFile, "\\", "/")))
+	src, err := scanSource(b, issue.Source, e.PAAFile)
+	if err != nil {
+		issue.Err, issue.Error = err, err.Error()
+		return issue, false
+	}
+
+	issue.Diffs = staleFields(e, &src)
+	if !opts.IgnoreFlags {
+		issue.Diffs = append(issue.Diffs, staleFlags(e, &src)...)
+	}
+
+	if !opts.IgnoreColors {
+		issue.Diffs = append(issue.Diffs, staleColors(e, &src)...)
+	}
+
+	return issue, len(issue.Diffs) == 0
+}
+
+// scanSource opens .paa or .pac source at path and scans it as entry rel.
+func scanSource(b *Builder, path, rel string) (TextureEntry, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".paa" && ext != ".pac" {
+		return TextureEntry{}, fmt.Errorf("%w: %s", ErrUnsupportedInputFormat, path)
+	}
+
+	fh, err := os.Open(ioPath(path))
+	if err != nil {
+		return TextureEntry{}, fmt.Errorf("open source: %w", err)
+	}
+	defer func() { _ = fh.Close() }()
+
+	info, err := fh.Stat()
+	if err != nil {
+		return TextureEntry{}, fmt.Errorf("stat source: %w", err)
+	}
+
+	src, err := b.scanEntry(fh, info.Size(), rel, ext)
+	if err != nil {
+		return TextureEntry{}, fmt.Errorf("scan %s: %w", path, err)
+	}
+
+	return src, nil
+}
+
+// staleFields lists source-derived fields of e that differ from scanned src.
+func staleFields(e, src *TextureEntry) []string {
+	var diffs []string
+	if e.PaxFileSize != src.PaxFileSize {
+		diffs = append(diffs, fmt.Sprintf("pax_file_size %d != %d", e.PaxFileSize, src.PaxFileSize))
+	}
+
+	if e.PaxFormat != src.PaxFormat {
+		diffs = append(diffs, fmt.Sprintf("pax_format %s != %s", e.PaxFormat, src.PaxFormat))
+	}
+
+	if len(e.MipMaps) != len(src.MipMaps) {
+		return append(diffs, fmt.Sprintf("mipmaps %d != %d", len(e.MipMaps), len(src.MipMaps)))
+	}
+
+	for i := range e.MipMaps {
+		got, want := &e.MipMaps[i], &src.MipMaps[i]
+		if got.Width != want.Width || got.Height != want.Height {
+			diffs = append(diffs, fmt.Sprintf("mipmap %d size %dx%d != %dx%d",
+				i, got.Width, got.Height, want.Width, want.Height))
+		}
+
+		if got.DataOffset != want.DataOffset {
+			diffs = append(diffs, fmt.Sprintf("mipmap %d data_offset %d != %d", i, got.DataOffset, want.DataOffset))
+		}
+	}
+
+	return diffs
+}
+
+// staleFlags lists alpha flags of e that differ from scanned src.
+func staleFlags(e, src *TextureEntry) []string {
+	var diffs []string
+	for _, f := range []struct {
+		name     string
+		got, src bool
+	}{
+		{"is_alpha", e.IsAlpha, src.IsAlpha},
+		{"is_transparent", e.IsTransparent, src.IsTransparent},
+		{"is_alpha_non_opaque", e.IsAlphaNonOpaque, src.IsAlphaNonOpaque},
+		{"has_max_ctagg", e.HasMaxCtagg, src.HasMaxCtagg},
+	} {
+		if f.got != f.src {
+			diffs = append(diffs, fmt.Sprintf("%s %t != %t", f.name, f.got, f.src))
+		}
+	}
+
+	return diffs
+}
+
+// verifyColorEpsilon tolerates float color rounding of other tools, half of
+// one byte step.
+const verifyColorEpsilon = 0.5 / 255
+
+// staleColors lists colors of e that differ from scanned src.
+func staleColors(e, src *TextureEntry) []string {
+	var diffs []string
+	if e.AverageColor != src.AverageColor {
+		diffs = append(diffs, fmt.Sprintf("average_color %v != %v", e.AverageColor, src.AverageColor))
+	}
+
+	if !colorsWithin(e.AverageColorF, src.AverageColorF, verifyColorEpsilon) {
+		diffs = append(diffs, fmt.Sprintf("average_color_f %v != %v", e.AverageColorF, src.AverageColorF))
+	}
+
+	if e.MaxColor != src.MaxColor {
+		diffs = append(diffs, fmt.Sprintf("max_color %v != %v", e.MaxColor, src.MaxColor))
+	}
+
+	return diffs
+}
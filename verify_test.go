@@ -0,0 +1,129 @@
+package texheaders
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyEntryAgainstPAA(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder(BuildOptions{})
+	if err := b.Append("testdata/test_co.paa"); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	entry := &f.Textures[0]
+	if err = VerifyEntryAgainstPAA(entry, "testdata/test_co.paa"); err != nil {
+		t.Fatalf("VerifyEntryAgainstPAA() error: %v", err)
+	}
+
+	stale := entry.Clone()
+	stale.PaxFileSize++
+	stale.MipMaps[1].DataOffset++
+	err = VerifyEntryAgainstPAA(&stale, "testdata/test_co.paa")
+	if !errors.Is(err, ErrStaleEntry) {
+		t.Fatalf("VerifyEntryAgainstPAA(stale) error = %v", err)
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, "pax_file_size") || !strings.Contains(msg, "mipmap 1 data_offset") {
+		t.Fatalf("VerifyEntryAgainstPAA(stale) error = %v", err)
+	}
+
+	stale = entry.Clone()
+	stale.MipMaps = stale.MipMaps[:1]
+	if err = VerifyEntryAgainstPAA(&stale, "testdata/test_co.paa"); !errors.Is(err, ErrStaleEntry) {
+		t.Fatalf("VerifyEntryAgainstPAA(mip count) error = %v", err)
+	}
+
+	if err = VerifyEntryAgainstPAA(entry, "testdata/missing_co.paa"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("VerifyEntryAgainstPAA(missing) error = %v", err)
+	}
+
+	if err = VerifyEntryAgainstPAA(entry, "testdata/test_co.png"); !errors.Is(err, ErrUnsupportedInputFormat) {
+		t.Fatalf("VerifyEntryAgainstPAA(png) error = %v", err)
+	}
+}
+
+func TestStaleColors_NaN(t *testing.T) {
+	t.Parallel()
+
+	e := TextureEntry{AverageColorF: [4]float32{0.5, 0.5, 0.5, 1}}
+	src := e
+	src.AverageColorF[2] = float32(math.NaN())
+	if diffs := staleColors(&e, &src); len(diffs) != 1 || !strings.HasPrefix(diffs[0], "average_color_f") {
+		t.Fatalf("staleColors(number, NaN) = %v", diffs)
+	}
+
+	e.AverageColorF[2] = src.AverageColorF[2]
+	if diffs := staleColors(&e, &src); len(diffs) != 0 {
+		t.Fatalf("staleColors(NaN, NaN) = %v", diffs)
+	}
+}
+
+func TestVerifyAgainstSources(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	copyFixture(t, "test_co.paa", filepath.Join(dir, "data"), "a_co.paa")
+	copyFixture(t, "test_co.paa", filepath.Join(dir, "data"), "b_co.paa")
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err := b.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	rep, err := VerifyAgainstSources(f, dir, VerifyOptions{})
+	if err != nil || rep.Stale() || rep.Checked != 2 {
+		t.Fatalf("VerifyAgainstSources(fresh) = %+v, %v", rep, err)
+	}
+
+	f.Textures[0].IsAlpha = !f.Textures[0].IsAlpha
+	f.Textures[0].AverageColor[0]++
+	f.Textures = append(f.Textures, TextureEntry{PAAFile: `data\missing_co.paa`}, TextureEntry{PAAFile: `..\x_co.paa`})
+
+	if rep, err = VerifyAgainstSources(f, dir, VerifyOptions{}); err != nil || len(rep.Issues) != 3 {
+		t.Fatalf("VerifyAgainstSources(stale) = %+v, %v", rep, err)
+	}
+
+	if is := rep.Issues[0]; is.Entry != 0 || len(is.Diffs) != 2 || !strings.HasPrefix(is.Diffs[0], "is_alpha ") ||
+		!strings.HasPrefix(is.Diffs[1], "average_color ") {
+		t.Fatalf("stale issue = %+v", is)
+	}
+
+	if is := rep.Issues[1]; is.Entry != 2 || !errors.Is(is.Err, fs.ErrNotExist) || is.Error == "" {
+		t.Fatalf("missing issue = %+v", is)
+	}
+
+	if is := rep.Issues[2]; !errors.Is(is.Err, ErrPathEscapesRoot) {
+		t.Fatalf("escape issue = %+v", is)
+	}
+
+	rep, _ = VerifyAgainstSources(f, dir, VerifyOptions{IgnoreFlags: true, IgnoreColors: true})
+	if len(rep.Issues) != 2 {
+		t.Fatalf("VerifyAgainstSources(ignore) = %+v", rep.Issues)
+	}
+
+	if _, err = VerifyAgainstSources(nil, dir, VerifyOptions{}); !errors.Is(err, ErrNilFile) {
+		t.Fatalf("VerifyAgainstSources(nil) error = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import "iter"
+
+// FileView is an immutable snapshot of a File safe for concurrent use.
+//
+// All methods return copies, so callers cannot alter the shared state;
+// path lookups use an index built once at creation.
+type FileView struct {
+	file  *File          // file is the private deep copy.
+	index map[string]int // index maps normalized path to entry index.
+}
+
+// NewFileView returns view of a deep copy of f; later changes of f are not visible.
+// A nil f gives an empty view.
+func NewFileView(f *File) *FileView {
+	if f == nil {
+		f = &File{Magic: FileMagic, Version: SupportedVersion}
+	}
+
+	v := &FileView{file: f.Clone(), index: make(map[string]int, len(f.Textures))}
+	for i := range v.file.Textures {
+		key := indexKey(v.file.Textures[i].PAAFile, IndexOptions{})
+		if _, ok := v.index[key]; !ok {
+			v.index[key] = i
+		}
+	}
+
+	return v
+}
+
+// Len returns number of entries.
+func (v *FileView) Len() int {
+	return len(v.file.Textures)
+}
+
+// At returns copy of entry at index i; it panics when i is out of range.
+func (v *FileView) At(i int) TextureEntry {
+	return cloneEntry(&v.file.Textures[i])
+}
+
+// FindByPath returns copy of the first entry matching path after default normalization.
+func (v *FileView) FindByPath(path string) (TextureEntry, bool) {
+	i, ok := v.index[indexKey(path, IndexOptions{})]
+	if !ok {
+		return TextureEntry{}, false
+	}
+
+	return cloneEntry(&v.file.Textures[i]), true
+}
+
+// All returns iterator over entry indexes and entry copies.
+func (v *FileView) All() iter.Seq2[int, TextureEntry] {
+	return func(yield func(int, TextureEntry) bool) {
+		for i := range v.file.Textures {
+			if !yield(i, cloneEntry(&v.file.Textures[i])) {
+				return
+			}
+		}
+	}
+}
+
+// Paths returns iterator over stored entry paths in file order.
+func (v *FileView) Paths() iter.Seq[string] {
+	return v.file.Paths()
+}
+
+// File returns a deep copy of viewed file for editing.
+func (v *FileView) File() *File {
+	return v.file.Clone()
+}
@@ -0,0 +1,57 @@
+package texheaders
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFileView(t *testing.T) {
+	t.Parallel()
+
+	f := &File{Magic: FileMagic, Version: 1, Textures: []TextureEntry{
+		{PAAFile: `data\a_co.paa`, MipMaps: []MipMap{{Width: 4, Height: 4}}},
+		{PAAFile: `data\b_co.paa`},
+	}}
+
+	v := NewFileView(f)
+	f.Textures[0].PAAFile = "changed.paa"
+
+	if v.Len() != 2 || v.At(0).PAAFile != `data\a_co.paa` {
+		t.Fatal("NewFileView() must snapshot source file")
+	}
+
+	e, ok := v.FindByPath("DATA/A_CO.paa")
+	if !ok || e.Width() != 4 {
+		t.Fatalf("FindByPath() = %+v, %v", e, ok)
+	}
+
+	e.MipMaps[0].Width = 8
+	if again, _ := v.FindByPath(`data\a_co.paa`); again.Width() != 4 {
+		t.Fatal("FindByPath() result shares mipmaps with view")
+	}
+
+	if _, ok = v.FindByPath("missing.paa"); ok {
+		t.Fatal("FindByPath(missing) = true")
+	}
+
+	if paths := slices.Collect(v.Paths()); !slices.Equal(paths, []string{`data\a_co.paa`, `data\b_co.paa`}) {
+		t.Fatalf("Paths() = %v", paths)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for i, e := range v.All() {
+				if _, ok := v.FindByPath(e.PAAFile); !ok || i >= v.Len() {
+					t.Errorf("concurrent lookup of %q failed", e.PAAFile)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if NewFileView(nil).Len() != 0 {
+		t.Fatal("NewFileView(nil) must be empty")
+	}
+}
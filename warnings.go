@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// DefaultMaxTextureSize is the engine texture dimension limit used when
+// BuildOptions.MaxTextureSize is zero.
+const DefaultMaxTextureSize = 4096
+
+// entryWarnings returns warnings about texture dimensions and mip chain of built entry.
+func (b *Builder) entryWarnings(path string, entry *TextureEntry) []BuildIssue {
+	var out []BuildIssue
+	warn := func(kind IssueKind, format string, args ...any) {
+		out = append(out, BuildIssue{Path: path, Error: fmt.Sprintf(format, args...), Kind: kind, Warning: true})
+	}
+
+	if len(entry.MipMaps) == 0 {
+		warn(IssueNoMipmaps, "texture has no mipmaps")
+		return out
+	}
+
+	limit := b.opts.MaxTextureSize
+	if limit <= 0 {
+		limit = DefaultMaxTextureSize
+	}
+
+	w, h := mipDimensions(entry.MipMaps[0])
+	if !isPowerOfTwo(w) || !isPowerOfTwo(h) {
+		warn(IssueNonPowerOfTwo, "top mip %dx%d is not power of two", w, h)
+	}
+
+	if int(w) > limit || int(h) > limit {
+		warn(IssueOversized, "top mip %dx%d exceeds limit %d", w, h, limit)
+	}
+
+	for i := 1; i < len(entry.MipMaps); i++ {
+		pw, ph := mipDimensions(entry.MipMaps[i-1])
+		cw, ch := mipDimensions(entry.MipMaps[i])
+		if cw != max(pw/2, 1) || ch != max(ph/2, 1) {
+			warn(IssueBrokenMipChain, "mip chain broken at level %d: %dx%d after %dx%d", i, cw, ch, pw, ph)
+			break
+		}
+	}
+
+	return out
+}
+
+// mipCompressedFlag marks LZO-compressed DXT mip in width field.
+const mipCompressedFlag = 0x8000
+
+// mipDimensions returns mip size without the LZO compression flag.
+func mipDimensions(m MipMap) (uint16, uint16) {
+	return m.Width &^ mipCompressedFlag, m.Height
+}
+
+// isPowerOfTwo reports whether v is a non-zero power of two.
+func isPowerOfTwo(v uint16) bool {
+	return bits.OnesCount16(v) == 1
+}
@@ -0,0 +1,122 @@
+package texheaders
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/paa"
+)
+
+func TestBuilder_EntryWarnings(t *testing.T) {
+	t.Parallel()
+
+	mip := func(w, h uint16) MipMap {
+		return MipMap{Width: w, Height: h, AlwaysThree: 3}
+	}
+
+	tests := []struct {
+		name string
+		want string
+		mips []MipMap
+	}{
+		{name: "ok", mips: []MipMap{mip(8, 4), mip(4, 2), mip(2, 1), mip(1, 1)}},
+		{name: "compressed flag", mips: []MipMap{mip(0x8000|8, 8), mip(4, 4)}},
+		{name: "npot", mips: []MipMap{mip(12, 8)}, want: "not power of two"},
+		{name: "oversized", mips: []MipMap{mip(8192, 8192)}, want: "exceeds limit 4096"},
+		{name: "chain", mips: []MipMap{mip(16, 16), mip(4, 4)}, want: "mip chain broken at level 1"},
+		{name: "empty", want: "no mipmaps"},
+	}
+
+	b := NewBuilder(BuildOptions{})
+	for _, tt := range tests {
+		entry := TextureEntry{MipMaps: tt.mips}
+		issues := b.entryWarnings("x.paa", &entry)
+		if tt.want == "" {
+			if len(issues) != 0 {
+				t.Fatalf("%s: warnings = %+v, want none", tt.name, issues)
+			}
+
+			continue
+		}
+
+		if len(issues) != 1 || !issues[0].Warning || !strings.Contains(issues[0].Error, tt.want) {
+			t.Fatalf("%s: warnings = %+v, want one containing %q", tt.name, issues, tt.want)
+		}
+	}
+}
+
+func TestBuilder_BuildReportsWarnings(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "npot_co.paa")
+	noMips := false
+	writeTestPAA(t, path, solidImage(12, 4, testRed), &paa.EncodeOptions{Type: paa.PaxDXT1, GenerateMipmaps: &noMips})
+
+	b := NewBuilder(BuildOptions{BaseDir: dir, MaxTextureSize: 8})
+	if err := b.Append(path); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(f.Textures) != 1 {
+		t.Fatalf("textures = %d, want 1", len(f.Textures))
+	}
+
+	issues := b.Issues()
+	if len(issues) != 2 || issues[0].Path != path {
+		t.Fatalf("issues = %+v, want npot and size warnings", issues)
+	}
+
+	for i, kind := range []IssueKind{IssueNonPowerOfTwo, IssueOversized} {
+		if !issues[i].Warning || issues[i].Kind != kind {
+			t.Fatalf("issue[%d] = %+v, want %s warning", i, issues[i], kind)
+		}
+	}
+}
+
+func TestBuilder_BuildReportsBrokenMipChain(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chain_co.paa")
+	writeTestPAA(t, path, solidImage(16, 16, testRed), &paa.EncodeOptions{Type: paa.PaxDXT1})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	meta, err := paa.DecodeMetadataHeadersBytes(data)
+	if err != nil || len(meta.MipHeaders) < 2 {
+		t.Fatalf("DecodeMetadataHeadersBytes() = %+v, %v", meta, err)
+	}
+
+	// Shrink second mip header from 8x8 to 4x4 to skip one level.
+	off := meta.MipHeaders[1].Offset
+	binary.LittleEndian.PutUint16(data[off:], 4)
+	binary.LittleEndian.PutUint16(data[off+2:], 4)
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	b := NewBuilder(BuildOptions{BaseDir: dir})
+	if err = b.Append(path); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if _, err = b.Build(); err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if issues := b.Issues(); len(issues) != 1 || !issues[0].Warning || issues[0].Kind != IssueBrokenMipChain {
+		t.Fatalf("issues = %+v, want broken mip chain warning", issues)
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval used when WatchOptions.Interval is zero.
+const DefaultWatchInterval = time.Second
+
+// watchSettle is how long Run waits for more change notifications before
+// rescanning, so a burst of writes triggers one rebuild.
+const watchSettle = 100 * time.Millisecond
+
+// errNotifierClosed means change notifications stopped and Run must poll.
+var errNotifierClosed = errors.New("change notifier closed")
+
+// watchNotifier delivers change notifications for watched directories.
+type watchNotifier interface {
+	// sync watches dirs and stops watching others; added reports new watches.
+	sync(dirs []string) (added bool, err error)
+	// events signals changes; it is closed when notifications stop.
+	events() <-chan struct{}
+	// close releases notifier resources.
+	close() error
+}
+
+// WatchOptions controls Watcher behavior.
+type WatchOptions struct {
+	// OnBuild is called after every rebuild, including the initial one.
+	// It runs synchronously while Poll holds its lock, so a slow callback
+	// delays the next scan and Run; hand long work off to a goroutine.
+	OnBuild func(WatchResult) `json:"-" yaml:"-"`
+	// OutputPath is written with the rebuilt file when not empty.
+	OutputPath string `json:"output_path,omitempty" yaml:"output_path,omitempty"`
+	// Interval is the polling interval between directory scans when polling.
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+	// Poll makes Run rescan every Interval instead of waiting for change
+	// notifications. Use it for network shares and virtual drives, where
+	// the OS does not report changes made by other machines.
+	Poll bool `json:"poll,omitempty" yaml:"poll,omitempty"`
+}
+
+// WatchResult describes one rebuild triggered by Watcher.
+type WatchResult struct {
+	// File is the rebuilt model, nil when Err is set.
+	File *File `json:"file,omitempty" yaml:"file,omitempty"`
+	// Err is the build or write error, if any.
+	Err error `json:"-" yaml:"-"`
+	// Issues holds build warnings and skipped inputs when BuildOptions.SkipInvalid is set.
+	Issues []BuildIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// Watcher rebuilds texheaders model whenever textures in watched directories change.
+//
+// Run waits for OS change notifications (inotify on Linux,
+// ReadDirectoryChangesW on Windows) and then rescans, comparing file size
+// and modification time to decide whether to rebuild. It polls every
+// Interval instead when WatchOptions.Poll is set, on other systems, and
+// when notifications cannot be set up or stop.
+type Watcher struct {
+	state   map[string]scannedFile // state is the last observed file snapshot.
+	dirs    []watchDir             // dirs is the list of watched directories.
+	scanned []string               // scanned holds resolved directories seen by the last scan.
+	opts    BuildOptions           // opts is used for every rebuild.
+	wopts   WatchOptions           // wopts is the watcher options.
+	mu      sync.Mutex             // mu guards dirs, scanned and state; never held across scans or rebuilds.
+	poll    sync.Mutex             // poll serializes Poll calls so rebuilds are not reordered.
+}
+
+// watchDir is one watched directory with its scan options.
+type watchDir struct {
+	path string
+	opts DirOptions
+}
+
+// NewWatcher creates a watcher which builds with opts.
+func NewWatcher(opts BuildOptions, wopts WatchOptions) *Watcher {
+	if wopts.Interval <= 0 {
+		wopts.Interval = DefaultWatchInterval
+	}
+
+	return &Watcher{
+		opts:  opts,
+		wopts: wopts,
+	}
+}
+
+// AppendDir registers one directory for watching.
+func (w *Watcher) AppendDir(dir string, opts DirOptions) error {
+	if strings.TrimSpace(dir) == "" {
+		return ErrEmptyInputPath
+	}
+
+	w.mu.Lock()
+	w.dirs = append(w.dirs, watchDir{path: dir, opts: opts})
+	w.mu.Unlock()
+
+	return nil
+}
+
+// Run builds once and then rebuilds on every detected change until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	if !w.wopts.Poll {
+		if n, err := newWatchNotifier(); err == nil {
+			err = w.runNotify(ctx, n)
+			_ = n.close()
+			if !errors.Is(err, errNotifierClosed) {
+				return err
+			}
+		}
+	}
+
+	return w.runPoll(ctx)
+}
+
+// runPoll rescans every Interval until ctx is done.
+func (w *Watcher) runPoll(ctx context.Context) error {
+	ticker := time.NewTicker(w.wopts.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.pollReport()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNotify rescans after change notifications until ctx is done. It
+// returns errNotifierClosed when notifications stop or cannot cover
+// scanned directories.
+func (w *Watcher) runNotify(ctx context.Context, n watchNotifier) error {
+	for {
+		w.pollReport()
+
+		w.mu.Lock()
+		dirs := w.scanned
+		w.mu.Unlock()
+
+		// Changes made before new directories were watched are only
+		// seen by another scan.
+		added, err := n.sync(dirs)
+		if err != nil {
+			return errNotifierClosed
+		}
+
+		if added {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-n.events():
+			if !ok {
+				return errNotifierClosed
+			}
+		}
+
+		if err = settle(ctx, n.events()); err != nil {
+			return err
+		}
+	}
+}
+
+// settle drains notifications until none arrive for watchSettle.
+func settle(ctx context.Context, events <-chan struct{}) error {
+	timer := time.NewTimer(watchSettle)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case _, ok := <-events:
+			if !ok {
+				return errNotifierClosed
+			}
+
+			timer.Reset(watchSettle)
+		}
+	}
+}
+
+// pollReport polls once and reports scan errors to OnBuild.
+func (w *Watcher) pollReport() {
+	if err := w.Poll(); err != nil && w.wopts.OnBuild != nil {
+		w.wopts.OnBuild(WatchResult{Err: err})
+	}
+}
+
+// Poll scans watched directories once and rebuilds if anything changed.
+// The first call always rebuilds. AppendDir is not blocked while Poll scans
+// or rebuilds, so OnBuild may call it.
+func (w *Watcher) Poll() error {
+	w.poll.Lock()
+	defer w.poll.Unlock()
+
+	w.mu.Lock()
+	dirs := slices.Clone(w.dirs)
+	prev := w.state
+	w.mu.Unlock()
+
+	state := make(map[string]scannedFile, len(prev))
+	paths := make([]string, 0, len(prev))
+	var prefixes []prefixRoot
+	var scanned []string
+	for _, dir := range dirs {
+		scan, err := scanDir(dir.path, dir.opts, w.opts.SymlinkPolicy)
+		if err != nil {
+			return err
+		}
+
+		prefixes = append(prefixes, scan.prefixes...)
+		scanned = append(scanned, scan.dirs...)
+		for _, file := range scan.files {
+			if _, ok := state[file.path]; ok {
+				continue
+			}
+
+			state[file.path] = file
+			paths = append(paths, file.path)
+		}
+	}
+
+	w.mu.Lock()
+	w.scanned = scanned
+	w.mu.Unlock()
+
+	if prev != nil && !stateChanged(prev, state) {
+		return nil
+	}
+
+	w.mu.Lock()
+	w.state = state
+	w.mu.Unlock()
+
+	w.rebuild(paths, prefixes)
+	return nil
+}
+
+// rebuild builds paths and reports the result.
+func (w *Watcher) rebuild(paths []string, prefixes []prefixRoot) {
+	b := NewBuilder(w.opts)
+	b.prefixRoots = prefixes
+	res := WatchResult{}
+
+	if res.Err = b.AppendMany(paths...); res.Err == nil {
+		res.File, res.Err = b.Build()
+		res.Issues = b.Issues()
+	}
+
+	if res.Err == nil && w.wopts.OutputPath != "" {
+		res.Err = WriteFile(w.wopts.OutputPath, res.File)
+	}
+
+	if w.wopts.OnBuild != nil {
+		w.wopts.OnBuild(res)
+	}
+}
+
+// stateChanged reports whether two directory snapshots differ.
+func stateChanged(prev, next map[string]scannedFile) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+
+	for path, n := range next {
+		p, ok := prev[path]
+		if !ok || p.size != n.size || !p.modTime.Equal(n.modTime) {
+			return true
+		}
+	}
+
+	return false
+}
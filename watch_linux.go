@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+//go:build linux
+
+package texheaders
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// inotifyMask selects directory events which may change scanned files.
+const inotifyMask = syscall.IN_ATTRIB | syscall.IN_CLOSE_WRITE | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_DELETE_SELF | syscall.IN_MODIFY |
+	syscall.IN_MOVE_SELF | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_ONLYDIR
+
+// inotifyNotifier watches every scanned directory with one inotify watch,
+// as inotify is not recursive.
+type inotifyNotifier struct {
+	file   *os.File       // file wraps inotify descriptor for blocking reads.
+	ch     chan struct{}  // ch signals pending changes.
+	wds    map[string]int // wds maps watched directory to watch descriptor.
+	fd     int            // fd is the inotify descriptor.
+	mu     sync.Mutex     // mu guards wds and closed.
+	closed bool           // closed is set once close was called.
+}
+
+// newWatchNotifier creates inotify instance and starts reading its events.
+func newWatchNotifier() (watchNotifier, error) {
+	// Non-blocking descriptor lets runtime poller unblock Read on Close.
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	n := &inotifyNotifier{
+		file: os.NewFile(uintptr(fd), "inotify"),
+		ch:   make(chan struct{}, 1),
+		wds:  make(map[string]int),
+		fd:   fd,
+	}
+	go n.read()
+
+	return n, nil
+}
+
+// read signals ch for every batch of events until descriptor is closed.
+// Events are not decoded: any of them makes Watcher rescan.
+func (n *inotifyNotifier) read() {
+	defer close(n.ch)
+
+	buf := make([]byte, 16<<10)
+	for {
+		if _, err := n.file.Read(buf); err != nil {
+			return
+		}
+
+		select {
+		case n.ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sync adds watches for dirs and removes watches of other directories.
+// Adding existing watch is cheap and returns a new descriptor only when
+// directory was replaced, which counts as added.
+func (n *inotifyNotifier) sync(dirs []string) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return false, errNotifierClosed
+	}
+
+	added := false
+	wds := make(map[string]int, len(dirs))
+	for _, dir := range dirs {
+		wd, err := syscall.InotifyAddWatch(n.fd, dir, inotifyMask)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+			// Removed since scan; its parent reports the removal.
+			continue
+		}
+
+		if err != nil {
+			return false, os.NewSyscallError("inotify_add_watch", err)
+		}
+
+		if old, ok := n.wds[dir]; !ok || old != wd {
+			added = true
+		}
+
+		wds[dir] = wd
+	}
+
+	for dir, wd := range n.wds {
+		if _, ok := wds[dir]; !ok {
+			// Fails for already removed directories; nothing to undo.
+			_, _ = syscall.InotifyRmWatch(n.fd, uint32(wd))
+		}
+	}
+
+	n.wds = wds
+	return added, nil
+}
+
+// events returns change signal channel.
+func (n *inotifyNotifier) events() <-chan struct{} {
+	return n.ch
+}
+
+// close stops reading and releases inotify descriptor with its watches.
+func (n *inotifyNotifier) close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return nil
+	}
+
+	n.closed = true
+	return n.file.Close()
+}
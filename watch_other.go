@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+//go:build !linux && !windows
+
+package texheaders
+
+import "errors"
+
+// newWatchNotifier reports that change notifications are not supported, so
+// Watcher polls.
+func newWatchNotifier() (watchNotifier, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,214 @@
+package texheaders
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// copyFixture copies one testdata file into dir under name.
+func copyFixture(t *testing.T, src, dir, name string) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", src))
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error: %v", src, err)
+	}
+
+	dst := filepath.Join(dir, name)
+	if err = os.WriteFile(dst, raw, 0o600); err != nil {
+		t.Fatalf("WriteFile(%s) error: %v", dst, err)
+	}
+
+	return dst
+}
+
+func TestWatcher_PollRebuildsOnChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+
+	var results []WatchResult
+	w := NewWatcher(BuildOptions{BaseDir: dir}, WatchOptions{
+		OutputPath: filepath.Join(dir, "texHeaders.bin"),
+		OnBuild: func(res WatchResult) {
+			results = append(results, res)
+		},
+	})
+
+	if err := w.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	for range 2 {
+		if err := w.Poll(); err != nil {
+			t.Fatalf("Poll() error: %v", err)
+		}
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("rebuilds after unchanged poll = %d, want 1", len(results))
+	}
+
+	copyFixture(t, "test_nohq.paa", dir, "a_nohq.paa")
+	if err := w.Poll(); err != nil {
+		t.Fatalf("Poll() error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("rebuilds after change = %d, want 2", len(results))
+	}
+
+	last := results[1]
+	if last.Err != nil {
+		t.Fatalf("rebuild error: %v", last.Err)
+	}
+
+	if len(last.File.Textures) != 2 {
+		t.Fatalf("textures = %d, want 2", len(last.File.Textures))
+	}
+
+	written, err := ReadFile(filepath.Join(dir, "texHeaders.bin"))
+	if err != nil {
+		t.Fatalf("ReadFile(output) error: %v", err)
+	}
+
+	if len(written.Textures) != 2 {
+		t.Fatalf("written textures = %d, want 2", len(written.Textures))
+	}
+}
+
+func TestWatcher_OnBuildAppendsDir(t *testing.T) {
+	t.Parallel()
+
+	dir, extra := t.TempDir(), t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+	copyFixture(t, "test_nohq.paa", extra, "b_nohq.paa")
+
+	var w *Watcher
+	var textures []int
+	w = NewWatcher(BuildOptions{}, WatchOptions{
+		OnBuild: func(res WatchResult) {
+			if res.Err != nil {
+				t.Errorf("rebuild error: %v", res.Err)
+				return
+			}
+
+			textures = append(textures, len(res.File.Textures))
+			if len(textures) == 1 {
+				if err := w.AppendDir(extra, DirOptions{}); err != nil {
+					t.Errorf("AppendDir() error: %v", err)
+				}
+			}
+		},
+	})
+
+	if err := w.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	for range 2 {
+		if err := w.Poll(); err != nil {
+			t.Fatalf("Poll() error: %v", err)
+		}
+	}
+
+	if len(textures) != 2 || textures[0] != 1 || textures[1] != 2 {
+		t.Fatalf("rebuild texture counts = %v, want [1 2]", textures)
+	}
+}
+
+// runWatcher starts w.Run and returns channel receiving texture counts of
+// successful rebuilds; Run stops when test ends.
+func runWatcher(t *testing.T, wopts WatchOptions, dir string) <-chan int {
+	t.Helper()
+
+	counts := make(chan int, 16)
+	wopts.OnBuild = func(res WatchResult) {
+		if res.Err != nil {
+			t.Errorf("rebuild error: %v", res.Err)
+			return
+		}
+
+		counts <- len(res.File.Textures)
+	}
+
+	w := NewWatcher(BuildOptions{BaseDir: dir}, wopts)
+	if err := w.AppendDir(dir, DirOptions{}); err != nil {
+		t.Fatalf("AppendDir() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	return counts
+}
+
+// waitCount waits for rebuild with want textures.
+func waitCount(t *testing.T, counts <-chan int, want int) {
+	t.Helper()
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case got := <-counts:
+			if got == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no rebuild with %d textures", want)
+		}
+	}
+}
+
+func TestWatcher_RunNotify(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS != "linux" && runtime.GOOS != "windows" {
+		t.Skip("change notifications are not supported on " + runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+
+	// Polling would not rescan within test timeout.
+	counts := runWatcher(t, WatchOptions{Interval: time.Hour}, dir)
+	waitCount(t, counts, 1)
+
+	// New subdirectory must be watched too.
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir() error: %v", err)
+	}
+
+	copyFixture(t, "test_nohq.paa", sub, "b_nohq.paa")
+	waitCount(t, counts, 2)
+
+	copyFixture(t, "test_co.paa", sub, "c_co.paa")
+	waitCount(t, counts, 3)
+}
+
+func TestWatcher_RunPoll(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	copyFixture(t, "test_co.paa", dir, "a_co.paa")
+
+	counts := runWatcher(t, WatchOptions{Poll: true, Interval: 10 * time.Millisecond}, dir)
+	waitCount(t, counts, 1)
+
+	copyFixture(t, "test_nohq.paa", dir, "b_nohq.paa")
+	waitCount(t, counts, 2)
+}
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+//go:build windows
+
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// dirChangeMask selects directory changes which may change scanned files.
+	dirChangeMask = syscall.FILE_NOTIFY_CHANGE_FILE_NAME | syscall.FILE_NOTIFY_CHANGE_DIR_NAME |
+		syscall.FILE_NOTIFY_CHANGE_SIZE | syscall.FILE_NOTIFY_CHANGE_LAST_WRITE
+	// stopKey is completion key posted by close to stop reading.
+	stopKey = 1
+)
+
+// dirWatch is one pending ReadDirectoryChangesW call on a directory tree.
+type dirWatch struct {
+	ov  syscall.Overlapped // ov must stay first: completions return its address.
+	h   syscall.Handle     // h is the directory handle.
+	buf [16 << 10]byte     // buf receives change records, which are not decoded.
+}
+
+// dirNotifier watches directory trees with ReadDirectoryChangesW, one
+// recursive watch per scanned directory not nested in another one.
+type dirNotifier struct {
+	ch       chan struct{}          // ch signals pending changes.
+	watches  map[string]*dirWatch   // watches maps watched root to its watch.
+	closing  map[*dirWatch]struct{} // closing holds closed watches until their I/O completes.
+	port     syscall.Handle         // port is the I/O completion port.
+	mu       sync.Mutex             // mu guards watches, closing and closed.
+	closed   bool                   // closed is set once close was called.
+	stopping bool                   // stopping is set by read loop on stop request.
+}
+
+// newWatchNotifier creates completion port and starts reading its events.
+func newWatchNotifier() (watchNotifier, error) {
+	port, err := syscall.CreateIoCompletionPort(syscall.InvalidHandle, 0, 0, 0)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateIoCompletionPort", err)
+	}
+
+	n := &dirNotifier{
+		ch:      make(chan struct{}, 1),
+		watches: make(map[string]*dirWatch),
+		closing: make(map[*dirWatch]struct{}),
+		port:    port,
+	}
+	go n.read()
+
+	return n, nil
+}
+
+// read signals ch for every completed directory read and queues the next
+// one, until close was requested and all closed watches completed.
+func (n *dirNotifier) read() {
+	defer close(n.ch)
+	defer syscall.CloseHandle(n.port)
+
+	for {
+		var qty, key uint32
+		var ov *syscall.Overlapped
+		err := syscall.GetQueuedCompletionStatus(n.port, &qty, &key, &ov, syscall.INFINITE)
+		if ov == nil {
+			if key != stopKey && err == nil {
+				continue
+			}
+
+			n.mu.Lock()
+			n.stopping = true
+			done := len(n.closing) == 0
+			n.mu.Unlock()
+			if done {
+				return
+			}
+
+			continue
+		}
+
+		w := (*dirWatch)(unsafe.Pointer(ov))
+		n.mu.Lock()
+		if _, ok := n.closing[w]; ok {
+			delete(n.closing, w)
+			done := n.stopping && len(n.closing) == 0
+			n.mu.Unlock()
+			if done {
+				return
+			}
+
+			continue
+		}
+
+		// Failed read means watched root is gone; drop it, next sync
+		// re-adds it when it is scanned again.
+		if err != nil || n.queue(w) != nil {
+			n.drop(w)
+		}
+		n.mu.Unlock()
+
+		select {
+		case n.ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// queue starts asynchronous read of changes under w.
+func (n *dirNotifier) queue(w *dirWatch) error {
+	w.ov = syscall.Overlapped{}
+	return syscall.ReadDirectoryChanges(w.h, &w.buf[0], uint32(len(w.buf)), true, dirChangeMask, nil, &w.ov, 0)
+}
+
+// drop closes watch with no pending read and forgets it.
+func (n *dirNotifier) drop(w *dirWatch) {
+	for root, v := range n.watches {
+		if v == w {
+			delete(n.watches, root)
+		}
+	}
+
+	_ = syscall.CloseHandle(w.h)
+}
+
+// sync watches directory trees covering dirs and stops watching others.
+func (n *dirNotifier) sync(dirs []string) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return false, errNotifierClosed
+	}
+
+	roots := watchRoots(dirs)
+	added := false
+	for _, root := range roots {
+		if _, ok := n.watches[root]; ok {
+			continue
+		}
+
+		w, err := n.watch(root)
+		if errors.Is(err, syscall.ERROR_FILE_NOT_FOUND) || errors.Is(err, syscall.ERROR_PATH_NOT_FOUND) {
+			// Removed since scan; its parent reports the removal.
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		n.watches[root] = w
+		added = true
+	}
+
+	for root, w := range n.watches {
+		if !slices.Contains(roots, root) {
+			n.release(root, w)
+		}
+	}
+
+	return added, nil
+}
+
+// watch opens root and starts reading its changes.
+func (n *dirNotifier) watch(root string) (*dirWatch, error) {
+	name, err := syscall.UTF16PtrFromString(root)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := syscall.CreateFile(name, syscall.FILE_LIST_DIRECTORY,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS|syscall.FILE_FLAG_OVERLAPPED, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: root, Err: err}
+	}
+
+	if _, err = syscall.CreateIoCompletionPort(h, n.port, 0, 0); err != nil {
+		_ = syscall.CloseHandle(h)
+		return nil, os.NewSyscallError("CreateIoCompletionPort", err)
+	}
+
+	w := &dirWatch{h: h}
+	if err = n.queue(w); err != nil {
+		_ = syscall.CloseHandle(h)
+		return nil, os.NewSyscallError("ReadDirectoryChangesW", err)
+	}
+
+	return w, nil
+}
+
+// release closes watch with pending read. Closing handle aborts the read;
+// w is kept in closing until its completion arrives.
+func (n *dirNotifier) release(root string, w *dirWatch) {
+	delete(n.watches, root)
+	n.closing[w] = struct{}{}
+	_ = syscall.CloseHandle(w.h)
+}
+
+// events returns change signal channel.
+func (n *dirNotifier) events() <-chan struct{} {
+	return n.ch
+}
+
+// close releases all watches and stops read loop.
+func (n *dirNotifier) close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return nil
+	}
+
+	n.closed = true
+	for root, w := range n.watches {
+		n.release(root, w)
+	}
+
+	return os.NewSyscallError("PostQueuedCompletionStatus", syscall.PostQueuedCompletionStatus(n.port, 0, stopKey, nil))
+}
+
+// watchRoots returns dirs not nested in another of dirs, compared
+// case-insensitively.
+func watchRoots(dirs []string) []string {
+	var roots []string
+	for _, dir := range dirs {
+		nested := slices.ContainsFunc(dirs, func(other string) bool {
+			return len(other) < len(dir) && strings.EqualFold(dir[:len(other)], other) &&
+				(strings.ContainsRune(`\/`, rune(dir[len(other)])) || strings.HasSuffix(other, `\`))
+		})
+
+		if !nested && !slices.Contains(roots, dir) {
+			roots = append(roots, dir)
+		}
+	}
+
+	return roots
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Workspace defaults.
+const (
+	// DefaultOutputName is the texheaders file name written into every addon root.
+	DefaultOutputName = "texHeaders.bin"
+	// addonConfigFile marks addon root directory.
+	addonConfigFile = "config.cpp"
+)
+
+// WorkspaceOptions controls BuildWorkspace.
+type WorkspaceOptions struct {
+	// OutputName is the file name written into every addon root.
+	// If empty, DefaultOutputName is used.
+	OutputName string `json:"output_name,omitempty" yaml:"output_name,omitempty"`
+	// Dir controls texture scanning inside addons; Exclude and SkipHidden
+	// also apply to addon discovery.
+	Dir DirOptions `json:"dir,omitempty" yaml:"dir,omitempty"`
+	// Build is used for every addon build. BaseDir is set to addon root and
+	// PBOPrefix defaults to addon directory name when no $PBOPREFIX$ exists.
+	Build BuildOptions `json:"build,omitempty" yaml:"build,omitempty"`
+	// DryRun builds models without writing output files.
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+// AddonResult is the build outcome of one addon found by BuildWorkspace.
+type AddonResult struct {
+	// Err is the scan, build or write error of the addon.
+	Err error `json:"-" yaml:"-"`
+	// File is the built model, nil when Err is set.
+	File *File `json:"file,omitempty" yaml:"file,omitempty"`
+	// Root is the addon root directory.
+	Root string `json:"root,omitempty" yaml:"root,omitempty"`
+	// OutputPath is the written texheaders path, empty in dry run.
+	OutputPath string `json:"output_path,omitempty" yaml:"output_path,omitempty"`
+	// Issues holds build warnings and skipped inputs, also when build failed.
+	Issues []BuildIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// BuildWorkspace finds addon roots (directories with config.cpp or
+// $PBOPREFIX$) under root and builds a separate texheaders file for each.
+//
+// Addons are built concurrently while total entry builds in flight are
+// limited by one worker pool sized from opts.Build.Workers, and open files
+// by one opts.Build.MaxOpenFiles limit. Per-addon
+// failures are reported in results; the error covers addon discovery only.
+func BuildWorkspace(root string, opts WorkspaceOptions) ([]AddonResult, error) {
+	if strings.TrimSpace(root) == "" {
+		return nil, ErrEmptyInputPath
+	}
+
+	addons, err := findAddons(root, opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Build.Workers
+	if workers == WorkersAdaptive {
+		workers = WorkersAuto
+	}
+
+	workers = resolveBuildWorkers(workers, maxAdaptiveWorkers)
+	pool := make(chan struct{}, workers)
+	files := newFileLimit(opts.Build.MaxOpenFiles)
+
+	results := make([]AddonResult, len(addons))
+	var wg sync.WaitGroup
+	for i, addon := range addons {
+		wg.Go(func() {
+			results[i] = buildAddon(addon, opts, workers, pool, files)
+		})
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// buildAddon builds and writes one addon index using shared worker pool and file limit.
+func buildAddon(root string, opts WorkspaceOptions, workers int, pool, files chan struct{}) AddonResult {
+	res := AddonResult{Root: root}
+
+	bopts := opts.Build
+	bopts.BaseDir = root
+	bopts.Workers = workers
+	if bopts.PBOPrefix == "" {
+		bopts.PBOPrefix = filepath.Base(root)
+	}
+
+	b := NewBuilder(bopts)
+	b.pool = pool
+	b.files = files
+	if res.Err = b.AppendDir(root, opts.Dir); res.Err != nil {
+		return res
+	}
+
+	// Issues are kept on failure too, they help to diagnose it.
+	res.File, res.Err = b.Build()
+	res.Issues = b.Issues()
+	if res.Err != nil || opts.DryRun {
+		return res
+	}
+
+	name := opts.OutputName
+	if name == "" {
+		name = DefaultOutputName
+	}
+
+	res.OutputPath = filepath.Join(root, name)
+	res.Err = WriteFile(res.OutputPath, res.File)
+	return res
+}
+
+// findAddons returns sorted addon root directories under root, not descending into found addons.
+func findAddons(root string, opts DirOptions) ([]string, error) {
+	exclude, err := compileGlobs(opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	s := dirScanner{root: root, exclude: exclude, opts: opts}
+	var addons []string
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if path != root && s.skipped(path, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		if isAddonRoot(path) {
+			addons = append(addons, path)
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan workspace %q: %w", root, err)
+	}
+
+	slices.Sort(addons)
+	return addons, nil
+}
+
+// isAddonRoot reports whether dir contains config.cpp or $PBOPREFIX$ file.
+func isAddonRoot(dir string) bool {
+	entries, err := os.ReadDir(ioPath(dir))
+	if err != nil {
+		return false
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if strings.EqualFold(e.Name(), addonConfigFile) || strings.EqualFold(e.Name(), PBOPrefixFile) {
+			return true
+		}
+	}
+
+	return false
+}
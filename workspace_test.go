@@ -0,0 +1,124 @@
+package texheaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildWorkspace(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	first := filepath.Join(root, "first")
+	second := filepath.Join(root, "mods", "second")
+	nested := filepath.Join(first, "data")
+	for _, dir := range []string{nested, second, filepath.Join(root, "loose")} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("MkdirAll(%s) error: %v", dir, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(first, "config.cpp"), nil, 0o600); err != nil {
+		t.Fatalf("WriteFile(config.cpp) error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(second, PBOPrefixFile), []byte("my\\second\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(prefix) error: %v", err)
+	}
+
+	copyFixture(t, "test_co.paa", nested, "a_co.paa")
+	copyFixture(t, "test_co.paa", second, "b_co.paa")
+	copyFixture(t, "test_co.paa", filepath.Join(root, "loose"), "c_co.paa")
+
+	results, err := BuildWorkspace(root, WorkspaceOptions{Build: BuildOptions{Workers: 2}})
+	if err != nil {
+		t.Fatalf("BuildWorkspace() error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("BuildWorkspace() results=%d, want 2", len(results))
+	}
+
+	want := map[string]string{
+		first:  `first\data\a_co.paa`,
+		second: `my\second\b_co.paa`,
+	}
+	for _, res := range results {
+		if res.Err != nil {
+			t.Fatalf("addon %s error: %v", res.Root, res.Err)
+		}
+
+		if res.OutputPath != filepath.Join(res.Root, DefaultOutputName) {
+			t.Fatalf("addon %s output=%q", res.Root, res.OutputPath)
+		}
+
+		f, err := ReadFile(res.OutputPath)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error: %v", res.OutputPath, err)
+		}
+
+		if len(f.Textures) != 1 || f.Textures[0].PAAFile != want[res.Root] {
+			t.Fatalf("addon %s textures=%+v, want %q", res.Root, f.Textures, want[res.Root])
+		}
+	}
+}
+
+func TestBuildWorkspaceDryRun(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	addon := filepath.Join(root, "addon")
+	if err := os.MkdirAll(addon, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(addon, "CONFIG.CPP"), nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	copyFixture(t, "test_co.paa", addon, "a_co.paa")
+
+	results, err := BuildWorkspace(root, WorkspaceOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("BuildWorkspace() error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Err != nil || results[0].File == nil || results[0].OutputPath != "" {
+		t.Fatalf("BuildWorkspace() results=%+v", results)
+	}
+
+	if _, err = os.Stat(filepath.Join(addon, DefaultOutputName)); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote output, stat error: %v", err)
+	}
+}
+
+func TestBuildWorkspace_FailureKeepsIssues(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	addon := filepath.Join(root, "addon")
+	if err := os.MkdirAll(addon, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(addon, "config.cpp"), nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	for _, name := range []string{"a_co.paa", "b_co.paa"} {
+		if err := os.WriteFile(filepath.Join(addon, name), []byte("bad"), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", name, err)
+		}
+	}
+
+	opts := WorkspaceOptions{Build: BuildOptions{SkipInvalid: true, MaxIssues: 1, Workers: 1}}
+	results, err := BuildWorkspace(root, opts)
+	if err != nil {
+		t.Fatalf("BuildWorkspace() error: %v", err)
+	}
+
+	if len(results) != 1 || !errors.Is(results[0].Err, ErrTooManyIssues) {
+		t.Fatalf("BuildWorkspace() results=%+v, want %v", results, ErrTooManyIssues)
+	}
+
+	if issues := results[0].Issues; len(issues) == 0 || issues[0].Kind != IssueCorruptPAA {
+		t.Fatalf("failed addon issues = %+v", issues)
+	}
+}
@@ -6,10 +6,16 @@ package texheaders
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 // encoder is a reusable little-endian writer with shared scratch buffer.
@@ -37,6 +43,44 @@ func WriteFile(path string, f *File) error {
 	return nil
 }
 
+// createTemp creates temporary file next to path, to be renamed over it;
+// "*" in pattern is replaced with random string. File gets perm or, when
+// perm is zero, mode of existing path, or 0o666 minus umask like os.Create
+// when path does not exist.
+func createTemp(path, pattern string, perm fs.FileMode) (*os.File, error) {
+	if perm == 0 {
+		if info, err := os.Stat(path); err == nil {
+			perm = info.Mode().Perm()
+		}
+	}
+
+	dir := filepath.Dir(path)
+	for range 100 {
+		name := filepath.Join(dir, strings.Replace(pattern, "*", strconv.FormatUint(rand.Uint64(), 36), 1))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Chmod is exact, unlike OpenFile mode which umask reduces.
+		if perm != 0 {
+			if err = f.Chmod(perm); err != nil {
+				_ = f.Close()
+				_ = os.Remove(name)
+				return nil, err
+			}
+		}
+
+		return f, nil
+	}
+
+	return nil, &fs.PathError{Op: "createtemp", Path: filepath.Join(dir, pattern), Err: fs.ErrExist}
+}
+
 // Write encodes texHeaders.bin into stream.
 func Write(w io.Writer, f *File) error {
 	if f == nil {
@@ -139,7 +183,7 @@ func (e *encoder) writeTextureEntry(entry *TextureEntry) error {
 		return fmt.Errorf("write mip count: %w", err)
 	}
 
-	if err := e.writeU32(entry.PaxFormat); err != nil {
+	if err := e.writeU32(uint32(entry.PaxFormat)); err != nil {
 		return fmt.Errorf("write pax format: %w", err)
 	}
 
@@ -155,7 +199,7 @@ func (e *encoder) writeTextureEntry(entry *TextureEntry) error {
 		return fmt.Errorf("write paa path: %w", err)
 	}
 
-	if err := e.writeU32(entry.PaxSuffixType); err != nil {
+	if err := e.writeU32(uint32(entry.PaxSuffixType)); err != nil {
 		return fmt.Errorf("write pax suffix type: %w", err)
 	}
 
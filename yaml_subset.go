@@ -0,0 +1,541 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/texheaders
+
+package texheaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlNumber matches plain scalars decoded as numbers; the syntax is the
+// JSON one, so matched text is passed through unchanged.
+var yamlNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// yamlLine is one significant line of YAML document.
+type yamlLine struct {
+	text   string // text is line content without indentation and comment.
+	indent int    // indent is count of leading spaces.
+	num    int    // num is 1-based line number for errors.
+}
+
+// yamlParser parses block-style YAML subset used by config files:
+// mappings, sequences, plain and quoted scalars and single-line flow
+// sequences. Anchors, tags, multi-line scalars and flow mappings are not
+// supported.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// yamlToJSON converts YAML subset document to JSON, so configs decode
+// through the same struct tags and text unmarshalers as JSON input.
+func yamlToJSON(data []byte) ([]byte, error) {
+	p := &yamlParser{}
+	if err := p.split(string(data)); err != nil {
+		return nil, err
+	}
+
+	if len(p.lines) == 0 {
+		return []byte("null"), nil
+	}
+
+	// Document of one scalar line, as jsonToYAML writes scalar roots.
+	if first := p.lines[0]; len(p.lines) == 1 && !isYAMLSeqItem(first.text) && !isYAMLMapEntry(first.text) {
+		v, err := p.scalar(first.text)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(v)
+	}
+
+	v, err := p.block(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+
+	return json.Marshal(v)
+}
+
+// split collects significant lines, dropping comments and blanks.
+func (p *yamlParser) split(doc string) error {
+	for i, raw := range strings.Split(doc, "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return fmt.Errorf("%w: line %d: tab indentation", ErrInvalidYAML, i+1)
+		}
+
+		text = strings.TrimSpace(stripYAMLComment(text))
+		if text == "" || text == "---" {
+			continue
+		}
+
+		p.lines = append(p.lines, yamlLine{text: text, indent: len(raw) - len(strings.TrimLeft(raw, " ")), num: i + 1})
+	}
+
+	return nil
+}
+
+// errorf returns ErrInvalidYAML error for current line.
+func (p *yamlParser) errorf(format string, args ...any) error {
+	num := 0
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].num
+	} else if len(p.lines) > 0 {
+		num = p.lines[len(p.lines)-1].num
+	}
+
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidYAML, num, fmt.Sprintf(format, args...))
+}
+
+// block parses mapping or sequence starting at current line with indent.
+func (p *yamlParser) block(indent int) (any, error) {
+	if isYAMLSeqItem(p.lines[p.pos].text) {
+		return p.seq(indent)
+	}
+
+	return p.mapping(indent)
+}
+
+// seq parses block sequence items at indent.
+func (p *yamlParser) seq(indent int) ([]any, error) {
+	out := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSeqItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		switch {
+		case rest == "":
+			p.pos++
+			v, err := p.nested(indent)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+
+		case isYAMLMapEntry(rest):
+			// Inline mapping item continues at the column of its first key.
+			itemIndent := indent + len(line.text) - len(rest)
+			p.lines[p.pos] = yamlLine{text: rest, indent: itemIndent, num: line.num}
+			v, err := p.mapping(itemIndent)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+
+		default:
+			v, err := p.scalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			p.pos++
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+// mapping parses block mapping entries at indent.
+func (p *yamlParser) mapping(indent int) (map[string]any, error) {
+	out := map[string]any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		text := p.lines[p.pos].text
+		if !isYAMLMapEntry(text) {
+			return nil, p.errorf("expected \"key: value\"")
+		}
+
+		key, rest := splitYAMLMapEntry(text)
+		key, err := unquoteYAML(key)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+
+		if _, dup := out[key]; dup {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+
+		var v any
+		if rest == "" {
+			p.pos++
+			if v, err = p.nested(indent); err != nil {
+				return nil, err
+			}
+		} else {
+			if v, err = p.scalar(rest); err != nil {
+				return nil, err
+			}
+			p.pos++
+		}
+
+		out[key] = v
+	}
+
+	return out, nil
+}
+
+// nested parses value of empty key or sequence item placed on following
+// lines. Sequences may share parent mapping indent, as YAML allows.
+func (p *yamlParser) nested(parent int) (any, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	next := p.lines[p.pos]
+	switch {
+	case next.indent > parent:
+		return p.block(next.indent)
+	case next.indent == parent && isYAMLSeqItem(next.text):
+		return p.seq(parent)
+	default:
+		return nil, nil
+	}
+}
+
+// scalar parses inline value: flow sequence, quoted or plain scalar.
+func (p *yamlParser) scalar(text string) (any, error) {
+	switch {
+	case text == "{}":
+		return map[string]any{}, nil
+	case strings.HasPrefix(text, "{"):
+		return nil, p.errorf("flow mappings are not supported")
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, p.errorf("unterminated flow sequence")
+		}
+
+		out := []any{}
+		for _, item := range splitYAMLFlow(text[1 : len(text)-1]) {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+
+			v, err := p.scalar(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+
+		return out, nil
+	case strings.HasPrefix(text, `"`), strings.HasPrefix(text, "'"):
+		s, err := unquoteYAML(text)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+
+		return s, nil
+	}
+
+	switch strings.ToLower(text) {
+	case "null", "~":
+		return nil, nil
+	case "true", "yes", "on":
+		return true, nil
+	case "false", "no", "off":
+		return false, nil
+	}
+
+	if yamlNumber.MatchString(text) {
+		return json.Number(text), nil
+	}
+
+	return text, nil
+}
+
+// isYAMLSeqItem reports whether line starts block sequence item.
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// isYAMLMapEntry reports whether text holds "key:" or "key: value".
+func isYAMLMapEntry(text string) bool {
+	key, _ := splitYAMLMapEntry(text)
+	return key != ""
+}
+
+// splitYAMLMapEntry splits "key: value" at first colon outside quotes that
+// is followed by space or end of line. Key is empty when there is none.
+func splitYAMLMapEntry(text string) (key, rest string) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && opensYAMLQuote(text, i):
+			quote = ch
+		case ch == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		}
+	}
+
+	return "", ""
+}
+
+// opensYAMLQuote reports whether quote at i starts quoted scalar rather
+// than being part of plain text such as "it's".
+func opensYAMLQuote(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	switch text[i-1] {
+	case ' ', ',', '[':
+		return true
+	default:
+		return false
+	}
+}
+
+// splitYAMLFlow splits flow sequence body on commas outside quotes.
+func splitYAMLFlow(body string) []string {
+	var (
+		out   []string
+		quote byte
+		start int
+	)
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && opensYAMLQuote(body, i):
+			quote = ch
+		case ch == ',':
+			out = append(out, body[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(out, body[start:])
+}
+
+// stripYAMLComment removes trailing "# comment" outside quotes.
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && opensYAMLQuote(text, i):
+			quote = ch
+		case ch == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+// unquoteYAML returns scalar text with surrounding quotes removed.
+func unquoteYAML(s string) (string, error) {
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return strconv.Unquote(s)
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case strings.HasPrefix(s, `"`), strings.HasPrefix(s, "'"):
+		return "", fmt.Errorf("unterminated quoted scalar %s", s)
+	default:
+		return s, nil
+	}
+}
+
+// jsonToYAML converts JSON document to block-style YAML readable by
+// yamlToJSON, keeping object key order and number text. Arrays of scalars
+// are written as one-line flow sequences.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := readYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch {
+	case root.scalar():
+		buf.WriteString(yamlScalar(root.value) + "\n")
+	case root.inline():
+		buf.WriteString(root.flow() + "\n")
+	default:
+		root.emit(&buf, 0)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// yamlNode is JSON value with object key order kept.
+type yamlNode struct {
+	value json.Token  // value is scalar token, or '{' / '[' delimiter.
+	keys  []string    // keys lists object member names.
+	items []*yamlNode // items lists object member values or array items.
+}
+
+// readYAMLNode reads one JSON value from decoder.
+func readYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &yamlNode{value: tok}
+	if tok != json.Delim('{') && tok != json.Delim('[') {
+		return n, nil
+	}
+
+	for dec.More() {
+		if tok == json.Delim('{') {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key.(string))
+		}
+
+		item, err := readYAMLNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+	}
+
+	_, err = dec.Token()
+	return n, err
+}
+
+// scalar reports whether node is not object or array.
+func (n *yamlNode) scalar() bool {
+	return n.value != json.Delim('{') && n.value != json.Delim('[')
+}
+
+// inline reports whether node is written on one line: scalar, empty
+// collection or array of scalars.
+func (n *yamlNode) inline() bool {
+	if n.scalar() || len(n.items) == 0 {
+		return true
+	}
+
+	if n.value == json.Delim('{') {
+		return false
+	}
+
+	for _, item := range n.items {
+		if !item.scalar() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flow formats inline node.
+func (n *yamlNode) flow() string {
+	switch {
+	case n.scalar():
+		return yamlScalar(n.value)
+	case n.value == json.Delim('{'):
+		return "{}"
+	}
+
+	parts := make([]string, len(n.items))
+	for i, item := range n.items {
+		parts[i] = yamlScalar(item.value)
+	}
+
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// emit writes block object or array at indent.
+func (n *yamlNode) emit(buf *bytes.Buffer, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for i, item := range n.items {
+		if n.value == json.Delim('{') {
+			buf.WriteString(pad + yamlScalar(n.keys[i]) + ":")
+		} else {
+			buf.WriteString(pad + "-")
+		}
+
+		switch {
+		case item.inline():
+			buf.WriteString(" " + item.flow() + "\n")
+		case n.value == json.Delim('[') && item.value == json.Delim('{'):
+			// Mapping item starts on "- " line and continues aligned to it.
+			var sub bytes.Buffer
+			item.emit(&sub, indent+2)
+			buf.WriteString(" ")
+			buf.Write(bytes.TrimLeft(sub.Bytes(), " "))
+		default:
+			buf.WriteString("\n")
+			item.emit(buf, indent+2)
+		}
+	}
+}
+
+// yamlScalar formats JSON scalar token, quoting strings yamlToJSON would
+// read as anything else.
+func yamlScalar(tok json.Token) string {
+	switch v := tok.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if isPlainYAML(v) {
+			return v
+		}
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isPlainYAML reports whether s can be written unquoted and read back as
+// the same string.
+func isPlainYAML(s string) bool {
+	if s == "" || s[0] == '-' || yamlNumber.MatchString(s) {
+		return false
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') &&
+			c != '_' && c != '.' && c != '/' && c != '\\' && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,130 @@
+package texheaders
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name, in, want string
+	}{
+		{"empty", "# only comment\n---\n", `null`},
+		{"scalars", "a: 1\nb: true\nc: ~\nd: it's # note\ne: \"x # y\"\nf: 'a''b'\n", `{"a":1,"b":true,"c":null,"d":"it's","e":"x # y","f":"a'b"}`},
+		{"nested", "top:\n  inner:\n    - a\n    -\n      k: v\n", `{"top":{"inner":["a",{"k":"v"}]}}`},
+		{"same indent seq", "list:\n- a\n- b\nnext: [1, \"x, y\", ]\n", `{"list":["a","b"],"next":[1,"x, y"]}`},
+		{"seq of maps", "- k: 1\n  v: 2\n- k: 3\n", `[{"k":1,"v":2},{"k":3}]`},
+		{"crlf", "a: b\r\n", `{"a":"b"}`},
+		{"scalar root", "plain\n", `"plain"`},
+		{"flow root", "[1, a]\n", `[1,"a"]`},
+	}
+	for _, tc := range cases {
+		got, err := yamlToJSON([]byte(tc.in))
+		if err != nil {
+			t.Fatalf("%s: yamlToJSON() error: %v", tc.name, err)
+		}
+
+		if string(got) != tc.want {
+			t.Fatalf("%s: yamlToJSON() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	for _, in := range []string{"a: 1\na: 2\n", "a: \"open\n", "a: [1, 2\n", "plain\nmore\n"} {
+		if _, err := yamlToJSON([]byte(in)); err == nil {
+			t.Fatalf("yamlToJSON(%q) expected error", in)
+		}
+	}
+}
+
+func TestJSONToYAMLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	docs := []string{
+		`{"a":"plain","b":"","c":"true","d":"-x","e":"line\nbreak","f":"x: y # z","g":1.5e-7,"h":-3,"i":null,"j":false}`,
+		`{"list":[[1,2],[],{"k":{}},{"k":[{"x":"y"}]}],"empty":{},"flow":["a b","c"]}`,
+		`[{"a":1,"b":[1,2]},"s",[]]`,
+	}
+	for _, doc := range docs {
+		y, err := jsonToYAML([]byte(doc))
+		if err != nil {
+			t.Fatalf("jsonToYAML(%s) error: %v", doc, err)
+		}
+
+		back, err := yamlToJSON(y)
+		if err != nil {
+			t.Fatalf("yamlToJSON() error: %v\n%s", err, y)
+		}
+
+		var want, got any
+		if err := json.Unmarshal([]byte(doc), &want); err != nil {
+			t.Fatalf("json.Unmarshal(want) error: %v", err)
+		}
+		if err := json.Unmarshal(back, &got); err != nil {
+			t.Fatalf("json.Unmarshal(got) error: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("round trip of %s = %s\n%s", doc, back, y)
+		}
+	}
+}
+
+func FuzzYAMLToJSON(f *testing.F) {
+	for _, seed := range []string{
+		"a: 1\nb: true\nc: ~\nd: it's # note\ne: \"x # y\"\nf: 'a''b'\n",
+		"top:\n  inner:\n    - a\n    -\n      k: v\n",
+		"list:\n- a\n- b\nnext: [1, \"x, y\", ]\n",
+		"- k: 1\n  v: 2\n- k: 3\n",
+		"a: \"open\n",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		out, err := yamlToJSON(data)
+		if err == nil && !json.Valid(out) {
+			t.Fatalf("yamlToJSON(%q) = invalid JSON %q", data, out)
+		}
+	})
+}
+
+func FuzzJSONToYAML(f *testing.F) {
+	for _, seed := range []string{
+		`{"a":"plain","b":"","c":"true","d":"-x","e":"line\nbreak","f":"x: y # z","g":1.5e-7,"h":-3,"i":null,"j":false}`,
+		`{"list":[[1,2],[],{"k":{}},{"k":[{"x":"y"}]}],"empty":{},"flow":["a b","c"]}`,
+		`[{"a":1,"b":[1,2]},"s",[]]`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Callers pass encoding/json output: re-marshal to drop duplicate keys.
+		var want any
+		if json.Unmarshal(data, &want) != nil {
+			return
+		}
+
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("json.Marshal() error: %v", err)
+		}
+
+		y, err := jsonToYAML(data)
+		if err != nil {
+			t.Fatalf("jsonToYAML(%q) error: %v", data, err)
+		}
+
+		back, err := yamlToJSON(y)
+		if err != nil {
+			t.Fatalf("yamlToJSON(jsonToYAML(%q)) error: %v\n%s", data, err, y)
+		}
+
+		var got any
+		if err = json.Unmarshal(back, &got); err != nil || !reflect.DeepEqual(got, want) {
+			t.Fatalf("round trip of %q = %s (%v)\n%s", data, back, err, y)
+		}
+	})
+}